@@ -0,0 +1,81 @@
+package dnslookupapi
+
+import (
+	"sort"
+	"sync"
+)
+
+// TypeUsage is the accumulated usage of a single DNS record type.
+type TypeUsage struct {
+	// DNSType is the DNS record type.
+	DNSType string `json:"dnsType"`
+
+	// Records is the number of received records of the type.
+	Records int `json:"records"`
+
+	// Bytes is the total size of raw JSON representations of the received records of the type.
+	Bytes int `json:"bytes"`
+}
+
+// Accounting accumulates the number of records and bytes per DNS record type across a session.
+// It is safe for concurrent use. The zero value is ready to use.
+type Accounting struct {
+	mu    sync.Mutex
+	usage map[string]*TypeUsage
+}
+
+// Add accounts the DNS records.
+func (a *Accounting) Add(records *DNSRecords) {
+	if records == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.usage == nil {
+		a.usage = make(map[string]*TypeUsage)
+	}
+
+	for _, record := range records.All {
+		dnsType := record.CommonFields.DNSType
+
+		usage, ok := a.usage[dnsType]
+		if !ok {
+			usage = &TypeUsage{DNSType: dnsType}
+			a.usage[dnsType] = usage
+		}
+
+		usage.Records++
+		usage.Bytes += len(record.Raw)
+	}
+}
+
+// Usage returns the accumulated usage sorted by bytes in descending order.
+func (a *Accounting) Usage() []TypeUsage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]TypeUsage, 0, len(a.usage))
+	for _, usage := range a.usage {
+		result = append(result, *usage)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Bytes != result[j].Bytes {
+			return result[i].Bytes > result[j].Bytes
+		}
+
+		return result[i].DNSType < result[j].DNSType
+	})
+
+	return result
+}
+
+// Reset clears the accumulated usage.
+func (a *Accounting) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.usage = nil
+}
@@ -0,0 +1,60 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestAccounting tests the Accounting functions.
+func TestAccounting(t *testing.T) {
+	const records = `[
+{"type":1,"dnsType":"A","address":"1.1.1.1"},
+{"type":1,"dnsType":"A","address":"2.2.2.2"},
+{"type":16,"dnsType":"TXT","strings":["v=spf1 -all"]}
+]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	var a Accounting
+	a.Add(&r)
+	a.Add(&r)
+	a.Add(nil)
+
+	want := []TypeUsage{
+		{DNSType: "A", Records: 4, Bytes: 4 * len(`{"type":1,"dnsType":"A","address":"1.1.1.1"}`)},
+		{DNSType: "TXT", Records: 2, Bytes: 2 * len(`{"type":16,"dnsType":"TXT","strings":["v=spf1 -all"]}`)},
+	}
+	if got := a.Usage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Usage() = %v, want %v", got, want)
+	}
+
+	a.Reset()
+	if got := a.Usage(); len(got) != 0 {
+		t.Errorf("Usage() = %v, want empty", got)
+	}
+}
+
+// TestAccountingClient tests that the client accounts parsed records.
+func TestAccountingClient(t *testing.T) {
+	const resp = `{"DNSData":{"dnsRecords":[{"type":1,"dnsType":"A","address":"1.1.1.1"}]}}`
+
+	server := dummyServer(resp, resp, resp)
+	defer server.Close()
+
+	api := newAPI(server, pathDNSLookupResponseOK)
+	api.accounting = &Accounting{}
+
+	if _, _, err := api.Get(context.Background(), "whoisxmlapi.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []TypeUsage{{DNSType: "A", Records: 1, Bytes: len(`{"type":1,"dnsType":"A","address":"1.1.1.1"}`)}}
+	if got := api.accounting.Usage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Usage() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,218 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults of AdaptiveConcurrency.
+const (
+	defaultAdaptiveMax     = 64
+	defaultAdaptiveBackoff = 0.5
+)
+
+// AdaptiveConcurrency limits the concurrent API requests of the client and tunes the limit to the capacity
+// the API grants, AIMD-style: the limit grows by about one per round of successful requests while at least
+// half of it is used, and is multiplied by Backoff when the API throttles the requests (429 or 503) or responds
+// slower than LatencyTarget. The requests wait while the API asks to with the Retry-After or the rate-limit
+// headers, so the request rate follows the limit. Use it instead of a static number of workers for bulk workloads.
+// The zero value is ready to use. It is safe for concurrent use and can be shared by several clients of the same API.
+type AdaptiveConcurrency struct {
+	// MinConcurrency is the lower bound of the limit
+	// If it's not positive then 1 is used
+	MinConcurrency int
+
+	// MaxConcurrency is the upper bound of the limit
+	// If it's not positive then 64 is used
+	MaxConcurrency int
+
+	// InitialConcurrency is the limit before any responses are observed
+	// If it's not positive then 4 is used
+	InitialConcurrency int
+
+	// LatencyTarget makes the responses slower than it decrease the limit like throttling
+	// If it's zero then the latency is not observed
+	LatencyTarget time.Duration
+
+	// Backoff is the factor the limit is multiplied by on throttling, between 0 and 1
+	// If it's not in the range then 0.5 is used
+	Backoff float64
+
+	// OnLimit is called when the limit changes, e.g. to export the current concurrency as a metric
+	// It's called synchronously and must not use the controller
+	OnLimit func(limit int)
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	changed  chan struct{}
+
+	// lastDecrease is the time the limit was last decreased, requests started before it don't decrease it again
+	lastDecrease time.Time
+	pausedUntil  time.Time
+
+	// now is used for testing
+	now func() time.Time
+}
+
+// Limit returns the current concurrency limit.
+func (a *AdaptiveConcurrency) Limit() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.init()
+
+	return int(a.limit)
+}
+
+// InFlight returns the number of requests in flight.
+func (a *AdaptiveConcurrency) InFlight() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.inFlight
+}
+
+// acquire waits until the request can be sent and returns the function to call with its outcome.
+func (a *AdaptiveConcurrency) acquire(ctx context.Context) (func(resp *Response, err error), error) {
+	for {
+		a.mu.Lock()
+		a.init()
+
+		wait := a.pausedUntil.Sub(a.clock())
+		if wait <= 0 && a.inFlight < int(a.limit) {
+			a.inFlight++
+			start := a.clock()
+			a.mu.Unlock()
+
+			return func(resp *Response, err error) {
+				a.release(start, resp, err)
+			}, nil
+		}
+
+		changed := a.changed
+		a.mu.Unlock()
+
+		if wait > 0 {
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// release adjusts the limit by the outcome of the request started at the time.
+func (a *AdaptiveConcurrency) release(start time.Time, resp *Response, err error) {
+	var header http.Header
+
+	throttled := false
+
+	if resp != nil && resp.Response != nil {
+		header = resp.Header
+		throttled = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+	}
+
+	a.mu.Lock()
+
+	now := a.clock()
+	used := a.inFlight*2 >= int(a.limit)
+	a.inFlight--
+
+	if header != nil {
+		if until := throttledUntil(header, now); until.After(a.pausedUntil) {
+			a.pausedUntil = until
+		}
+	}
+
+	slow := a.LatencyTarget > 0 && now.Sub(start) > a.LatencyTarget
+	limit := a.limit
+
+	switch {
+	case throttled || slow:
+		if start.Before(a.lastDecrease) {
+			break
+		}
+
+		backoff := a.Backoff
+		if backoff <= 0 || backoff >= 1 {
+			backoff = defaultAdaptiveBackoff
+		}
+
+		a.limit = a.clamp(a.limit * backoff)
+		a.lastDecrease = now
+	case err == nil && used:
+		a.limit = a.clamp(a.limit + 1/a.limit)
+	}
+
+	changed := int(limit) != int(a.limit)
+
+	close(a.changed)
+	a.changed = make(chan struct{})
+
+	newLimit := int(a.limit)
+	a.mu.Unlock()
+
+	if changed && a.OnLimit != nil {
+		a.OnLimit(newLimit)
+	}
+}
+
+// init sets the initial limit.
+func (a *AdaptiveConcurrency) init() {
+	if a.changed == nil {
+		a.changed = make(chan struct{})
+	}
+
+	if a.limit == 0 {
+		initial := a.InitialConcurrency
+		if initial <= 0 {
+			initial = defaultConcurrency
+		}
+
+		a.limit = a.clamp(float64(initial))
+	}
+}
+
+// clamp returns the limit within the bounds.
+func (a *AdaptiveConcurrency) clamp(limit float64) float64 {
+	min, max := a.MinConcurrency, a.MaxConcurrency
+	if min <= 0 {
+		min = 1
+	}
+
+	if max <= 0 {
+		max = defaultAdaptiveMax
+	}
+
+	if max < min {
+		max = min
+	}
+
+	switch {
+	case limit < float64(min):
+		return float64(min)
+	case limit > float64(max):
+		return float64(max)
+	}
+
+	return limit
+}
+
+// clock returns the current time.
+func (a *AdaptiveConcurrency) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,165 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// throttledResponse returns the response with the status code and headers.
+func throttledResponse(statusCode int, header http.Header) *Response {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &Response{Response: &http.Response{StatusCode: statusCode, Header: header}}
+}
+
+// TestAdaptiveConcurrency tests that the limit grows with the successful requests and is halved on throttling.
+func TestAdaptiveConcurrency(t *testing.T) {
+	now := time.Now()
+
+	var limits []int
+
+	adaptive := &AdaptiveConcurrency{
+		InitialConcurrency: 2,
+		MaxConcurrency:     3,
+		OnLimit:            func(limit int) { limits = append(limits, limit) },
+		now:                func() time.Time { return now },
+	}
+
+	ctx := context.Background()
+
+	// two rounds of fully used limit grow it by about one each, up to the maximum
+	for i := 0; i < 4; i++ {
+		first, _ := adaptive.acquire(ctx)
+		second, _ := adaptive.acquire(ctx)
+
+		first(throttledResponse(http.StatusOK, nil), nil)
+		second(throttledResponse(http.StatusOK, nil), nil)
+	}
+
+	if adaptive.Limit() != 3 || adaptive.InFlight() != 0 {
+		t.Fatalf("Limit() = %d, InFlight() = %d, want 3, 0", adaptive.Limit(), adaptive.InFlight())
+	}
+
+	now = now.Add(time.Second)
+
+	first, _ := adaptive.acquire(ctx)
+	second, _ := adaptive.acquire(ctx)
+
+	now = now.Add(time.Second)
+
+	// the requests started before the decrease don't decrease the limit again
+	first(throttledResponse(http.StatusTooManyRequests, nil), nil)
+	second(throttledResponse(http.StatusServiceUnavailable, nil), nil)
+
+	if adaptive.Limit() != 1 {
+		t.Errorf("Limit() = %d, want 1", adaptive.Limit())
+	}
+
+	if len(limits) != 2 || limits[0] != 3 || limits[1] != 1 {
+		t.Errorf("OnLimit() calls = %v, want [3 1]", limits)
+	}
+}
+
+// TestAdaptiveConcurrencyWait tests that the requests wait for a free slot and while the API asks to.
+func TestAdaptiveConcurrencyWait(t *testing.T) {
+	now := time.Now()
+	adaptive := &AdaptiveConcurrency{MaxConcurrency: 1, now: func() time.Time { return now }}
+
+	release, err := adaptive.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err = adaptive.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	done := make(chan error)
+
+	go func() {
+		release, err := adaptive.acquire(context.Background())
+		if err == nil {
+			release(nil, nil)
+		}
+
+		done <- err
+	}()
+
+	release(throttledResponse(http.StatusOK, nil), nil)
+
+	if err = <-done; err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	release, _ = adaptive.acquire(context.Background())
+	release(throttledResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}}), nil)
+
+	// the fixed clock keeps the controller paused until the context is done
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err = adaptive.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if !adaptive.pausedUntil.Equal(now.Add(time.Second)) {
+		t.Errorf("pausedUntil = %v, want %v", adaptive.pausedUntil, now.Add(time.Second))
+	}
+}
+
+// TestAdaptiveConcurrencyLatency tests that the slow responses decrease the limit.
+func TestAdaptiveConcurrencyLatency(t *testing.T) {
+	now := time.Now()
+	adaptive := &AdaptiveConcurrency{
+		LatencyTarget: time.Second,
+		Backoff:       0.25,
+		now:           func() time.Time { return now },
+	}
+
+	release, _ := adaptive.acquire(context.Background())
+	now = now.Add(2 * time.Second)
+	release(throttledResponse(http.StatusOK, nil), nil)
+
+	if adaptive.Limit() != 1 {
+		t.Errorf("Limit() = %d, want 1", adaptive.Limit())
+	}
+}
+
+// TestClientAdaptiveConcurrency tests that the client requests report the throttling to the controller.
+func TestClientAdaptiveConcurrency(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	adaptive := &AdaptiveConcurrency{InitialConcurrency: 8}
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:          server.Client(),
+		DNSLookupBaseURL:    baseURL,
+		AdaptiveConcurrency: adaptive,
+	})
+
+	if _, _, err := client.Get(context.Background(), "example.com"); err == nil {
+		t.Fatal("Get() error = nil")
+	}
+
+	if adaptive.Limit() != 4 || adaptive.InFlight() != 0 || requests == 0 {
+		t.Errorf("Limit() = %d, InFlight() = %d after %d requests", adaptive.Limit(), adaptive.InFlight(), requests)
+	}
+}
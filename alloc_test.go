@@ -0,0 +1,41 @@
+//go:build !race
+
+package dnslookupapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/dnslookupapitest"
+)
+
+// allocsPerRecord is the allocation budget of parsing a single DNS record.
+const allocsPerRecord = 6
+
+// TestAllocationBudget checks that parsing DNS records stays within the allocation budget.
+func TestAllocationBudget(t *testing.T) {
+	data := []byte(dnslookupapitest.SizedRecords(dnslookupapitest.MediumRecords))
+
+	allocs := testing.AllocsPerRun(10, func() {
+		var records dnslookupapi.DNSRecords
+		if err := json.Unmarshal(data, &records); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if perRecord := allocs / dnslookupapitest.MediumRecords; perRecord > allocsPerRecord {
+		t.Errorf("%.1f allocations per record, budget is %d", perRecord, allocsPerRecord)
+	}
+
+	date := []byte(`"2022-07-12 11:46:25 UTC"`)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		var v dnslookupapi.Time
+		_ = v.UnmarshalJSON(date)
+	})
+
+	if allocs > 1 {
+		t.Errorf("Time.UnmarshalJSON() made %.0f allocations, budget is 1", allocs)
+	}
+}
@@ -0,0 +1,197 @@
+// Package archive persists DNS Lookup API responses so the lookup evidence can be retained and reviewed later.
+//
+// Store is implemented by FileStore and SQLStore. Service wraps a DNSLookupService and archives
+// every response it returns.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// ErrNotFound is returned by Store.Get when there is no entry for the domain and time.
+var ErrNotFound = errors.New("archive entry not found")
+
+// Entry is a single archived response.
+type Entry struct {
+	// DomainName is the requested domain name.
+	DomainName string `json:"domainName"`
+
+	// Time is the time the response was received.
+	Time time.Time `json:"time"`
+
+	// Query holds the query parameters set by the options, without the API key.
+	Query url.Values `json:"query,omitempty"`
+
+	// StatusCode is the HTTP status code of the response, zero if unknown.
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Raw is the response body as returned by the API.
+	Raw []byte `json:"raw"`
+
+	// Parsed reports whether the response was parsed, i.e. it was archived by Get.
+	Parsed bool `json:"parsed,omitempty"`
+
+	// Response is the parsed response. It's nil for responses archived by GetRaw.
+	Response *dnslookupapi.DNSLookupResponse `json:"-"`
+}
+
+// Store persists archived responses. Implementations must be safe for concurrent use.
+type Store interface {
+	// Put saves the entry.
+	Put(ctx context.Context, entry *Entry) error
+
+	// Get returns the entry of the domain saved at the time or ErrNotFound.
+	Get(ctx context.Context, domainName string, t time.Time) (*Entry, error)
+
+	// List returns the times of the entries of the domain saved in [since, until) in ascending order.
+	// Zero since or until means the range is not limited from that side.
+	List(ctx context.Context, domainName string, since, until time.Time) ([]time.Time, error)
+}
+
+// Service is the DNSLookupService archiving every response returned by the wrapped service.
+type Service struct {
+	// Service is the wrapped service.
+	Service dnslookupapi.DNSLookupService
+
+	// Store is where the responses are archived.
+	Store Store
+
+	// OnError is called when a response cannot be archived.
+	// If it's nil then the archiving error is returned from Get and GetRaw together with the response.
+	OnError func(entry *Entry, err error)
+
+	now func() time.Time
+}
+
+var _ dnslookupapi.DNSLookupService = &Service{}
+
+// Wrap creates the Service archiving the responses of the service to the store.
+func Wrap(service dnslookupapi.DNSLookupService, store Store) *Service {
+	return &Service{Service: service, Store: store}
+}
+
+// Get returns parsed DNS Lookup API response and archives it.
+func (s *Service) Get(
+	ctx context.Context,
+	domainName string,
+	opts ...dnslookupapi.Option,
+) (*dnslookupapi.DNSLookupResponse, *dnslookupapi.Response, error) {
+	dnsLookupResp, resp, err := s.Service.Get(ctx, domainName, opts...)
+	if err != nil {
+		return dnsLookupResp, resp, err
+	}
+
+	entry := s.newEntry(domainName, resp, opts)
+	entry.Response, entry.Parsed = dnsLookupResp, dnsLookupResp != nil
+
+	if len(entry.Raw) == 0 && dnsLookupResp != nil {
+		if entry.Raw, err = dnsLookupResp.MarshalAPI(); err != nil {
+			return dnsLookupResp, resp, s.fail(entry, err)
+		}
+	}
+
+	return dnsLookupResp, resp, s.put(ctx, entry)
+}
+
+// GetRaw returns raw DNS Lookup API response and archives it.
+func (s *Service) GetRaw(
+	ctx context.Context,
+	domainName string,
+	opts ...dnslookupapi.Option,
+) (*dnslookupapi.Response, error) {
+	resp, err := s.Service.GetRaw(ctx, domainName, opts...)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, s.put(ctx, s.newEntry(domainName, resp, opts))
+}
+
+// newEntry creates the entry for the response.
+func (s *Service) newEntry(domainName string, resp *dnslookupapi.Response, opts []dnslookupapi.Option) *Entry {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+
+	entry := &Entry{
+		DomainName: domainName,
+		Time:       now().UTC(),
+		Query:      url.Values{},
+	}
+
+	for _, opt := range opts {
+		opt(entry.Query)
+	}
+
+	entry.Query.Del("apiKey")
+
+	if resp != nil {
+		entry.Raw = resp.Body
+		if resp.Response != nil {
+			entry.StatusCode = resp.StatusCode
+		}
+	}
+
+	return entry
+}
+
+// put saves the entry to the store.
+func (s *Service) put(ctx context.Context, entry *Entry) error {
+	if err := s.Store.Put(ctx, entry); err != nil {
+		return s.fail(entry, err)
+	}
+
+	return nil
+}
+
+// fail reports the archiving error to OnError or returns it.
+func (s *Service) fail(entry *Entry, err error) error {
+	err = fmt.Errorf("cannot archive response: %w", err)
+
+	if s.OnError != nil {
+		s.OnError(entry, err)
+		return nil
+	}
+
+	return err
+}
+
+// parseEntry parses the raw response body of the entry archived by Get.
+func parseEntry(entry *Entry) error {
+	if !entry.Parsed {
+		return nil
+	}
+
+	raw := entry.Raw
+	if callback := entry.Query.Get("callback"); callback != "" {
+		var err error
+		if raw, err = dnslookupapi.StripJSONP(callback, raw); err != nil {
+			return err
+		}
+	}
+
+	var response struct {
+		DNSData dnslookupapi.DNSLookupResponse `json:"DNSData"`
+	}
+
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return fmt.Errorf("cannot parse archived response: %w", err)
+	}
+
+	entry.Response = &response.DNSData
+
+	return nil
+}
+
+// inRange reports whether t is in [since, until).
+func inRange(t, since, until time.Time) bool {
+	return (since.IsZero() || !t.Before(since)) && (until.IsZero() || t.Before(until))
+}
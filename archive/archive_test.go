@@ -0,0 +1,127 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/dnslookupapitest"
+)
+
+// failingStore is the Store failing every Put.
+type failingStore struct {
+	Store
+}
+
+func (failingStore) Put(context.Context, *Entry) error {
+	return errors.New("disk full")
+}
+
+// TestService tests archiving of the responses.
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(t.TempDir())
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	calls := 0
+
+	service := Wrap(dnslookupapitest.NewFake(), store)
+	service.now = func() time.Time {
+		calls++
+		return base.Add(time.Duration(calls) * time.Second)
+	}
+
+	domain := dnslookupapitest.FixtureDomain
+
+	if _, _, err := service.Get(ctx, domain, dnslookupapi.OptionType("A")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.GetRaw(ctx, domain); err != nil {
+		t.Fatal(err)
+	}
+
+	times, err := store.List(ctx, domain, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(times) != 2 || !times[0].Equal(base.Add(time.Second)) || !times[1].Equal(base.Add(2*time.Second)) {
+		t.Fatalf("List() = %v", times)
+	}
+
+	entry, err := store.Get(ctx, domain, times[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !entry.Parsed || entry.Response == nil || len(entry.Response.DNSRecords.A) != 2 {
+		t.Errorf("Get() parsed entry = %+v", entry)
+	}
+
+	if entry.Query.Get("type") != "A" || entry.Query.Get("apiKey") != "" {
+		t.Errorf("Get() query = %v", entry.Query)
+	}
+
+	entry, err = store.Get(ctx, domain, times[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Parsed || entry.Response != nil || string(entry.Raw) != dnslookupapitest.FixtureResponse {
+		t.Errorf("Get() raw entry = %+v", entry)
+	}
+
+	if _, err = store.Get(ctx, domain, base); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() missing error = %v", err)
+	}
+
+	failing := Wrap(dnslookupapitest.NewFake(), failingStore{})
+	if _, _, err = failing.Get(ctx, domain); err == nil {
+		t.Error("Get() with failing store error = nil")
+	}
+
+	var reported error
+
+	failing.OnError = func(_ *Entry, err error) {
+		reported = err
+	}
+
+	if _, err = failing.GetRaw(ctx, domain); err != nil || reported == nil {
+		t.Errorf("GetRaw() with OnError error = %v, reported = %v", err, reported)
+	}
+}
+
+// TestFileStoreList tests listing of the time range.
+func TestFileStoreList(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(t.TempDir())
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 4; i++ {
+		entry := &Entry{DomainName: "Example.COM.", Time: base.Add(time.Duration(i) * time.Hour), Raw: []byte("{}")}
+		if err := store.Put(ctx, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	times, err := store.List(ctx, "example.com", base.Add(time.Hour), base.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(times) != 2 || !times[0].Equal(base.Add(time.Hour)) || !times[1].Equal(base.Add(2*time.Hour)) {
+		t.Errorf("List() = %v", times)
+	}
+
+	if times, err = store.List(ctx, "other.com", time.Time{}, time.Time{}); err != nil || len(times) != 0 {
+		t.Errorf("List() unknown domain = %v, %v", times, err)
+	}
+
+	if dir := store.domainDir("../etc"); filepath.Dir(dir) != store.Dir {
+		t.Errorf("domainDir() = %v", dir)
+	}
+}
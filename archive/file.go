@@ -0,0 +1,123 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fileExt is the extension of the files written by FileStore.
+const fileExt = ".json"
+
+// FileStore is the Store keeping every entry in a separate JSON file named
+// Dir/<domain name>/<unix nanoseconds>.json.
+type FileStore struct {
+	// Dir is the root directory of the archive.
+	Dir string
+}
+
+var _ Store = &FileStore{}
+
+// NewFileStore creates the FileStore in the directory.
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{Dir: dir}
+}
+
+// Put saves the entry. The domain directory is created if needed.
+func (s *FileStore) Put(_ context.Context, entry *Entry) error {
+	dir := s.domainDir(entry.DomainName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Join(dir, strconv.FormatInt(entry.Time.UnixNano(), 10)+fileExt)
+
+	// write to a temporary file first, so readers never see partial entries
+	tmp := name + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, name)
+}
+
+// Get returns the entry of the domain saved at the time or ErrNotFound.
+func (s *FileStore) Get(_ context.Context, domainName string, t time.Time) (*Entry, error) {
+	name := filepath.Join(s.domainDir(domainName), strconv.FormatInt(t.UnixNano(), 10)+fileExt)
+
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %w", name, err)
+	}
+
+	if err = parseEntry(&entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// List returns the times of the entries of the domain saved in [since, until) in ascending order.
+func (s *FileStore) List(_ context.Context, domainName string, since, until time.Time) ([]time.Time, error) {
+	files, err := os.ReadDir(s.domainDir(domainName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var times []time.Time
+
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+
+		nsec, err := strconv.ParseInt(strings.TrimSuffix(name, fileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if t := time.Unix(0, nsec).UTC(); inRange(t, since, until) {
+			times = append(times, t)
+		}
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	return times, nil
+}
+
+// domainDir returns the directory of the domain entries.
+// The name is lowercased and path separators are escaped, so it can't point outside Dir.
+func (s *FileStore) domainDir(domainName string) string {
+	name := strings.NewReplacer("/", "%2F", `\`, "%5C", "..", "%2E%2E").Replace(normalizeDomain(domainName))
+
+	return filepath.Join(s.Dir, name)
+}
@@ -0,0 +1,215 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultTable is the table used by SQLStore if not specified.
+	defaultTable = "dns_lookup_archive"
+
+	// defaultBinaryType is the column type of the raw responses if not specified.
+	defaultBinaryType = "BLOB"
+)
+
+// SQLStore is the Store keeping the entries in a SQL table. It works with any database/sql driver;
+// the driver is imported by the caller.
+//
+// Times are stored as unix nanoseconds, so no driver-specific time types are needed.
+// The table created by CreateTable uses BLOB for the raw responses, which SQLite and MySQL accept;
+// set BinaryType for other databases or use NewPostgreSQLStore.
+type SQLStore struct {
+	// DB is the database.
+	DB *sql.DB
+
+	// Table is the table name. If it's empty then "dns_lookup_archive" is used.
+	Table string
+
+	// Placeholder returns the placeholder of the n-th query argument starting from 1,
+	// e.g. "$1" for PostgreSQL. If it's nil then "?" is used.
+	Placeholder func(n int) string
+
+	// BinaryType is the column type of the raw responses used by CreateTable,
+	// e.g. "BYTEA" for PostgreSQL or "LONGBLOB" for MySQL. If it's empty then "BLOB" is used.
+	BinaryType string
+}
+
+var _ Store = &SQLStore{}
+
+// NewSQLStore creates the SQLStore using the default table.
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{DB: db}
+}
+
+// NewPostgreSQLStore creates the SQLStore using the default table and the PostgreSQL placeholders and types.
+func NewPostgreSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{DB: db, Placeholder: DollarPlaceholder, BinaryType: "BYTEA"}
+}
+
+// CreateTable creates the table and its index if they don't exist.
+func (s *SQLStore) CreateTable(ctx context.Context) error {
+	table := s.table()
+
+	_, err := s.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+table+` (
+	domain_name VARCHAR(253) NOT NULL,
+	created_at BIGINT NOT NULL,
+	status_code INTEGER NOT NULL,
+	query TEXT NOT NULL,
+	parsed INTEGER NOT NULL,
+	raw `+s.binaryType()+` NOT NULL
+)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.DB.ExecContext(ctx,
+		`CREATE INDEX IF NOT EXISTS `+table+`_domain_time ON `+table+` (domain_name, created_at)`)
+
+	return err
+}
+
+// Put saves the entry.
+func (s *SQLStore) Put(ctx context.Context, entry *Entry) error {
+	query, err := json.Marshal(entry.Query)
+	if err != nil {
+		return err
+	}
+
+	parsed := 0
+	if entry.Parsed {
+		parsed = 1
+	}
+
+	_, err = s.DB.ExecContext(ctx, s.rebind(`INSERT INTO `+s.table()+
+		` (domain_name, created_at, status_code, query, parsed, raw) VALUES (?, ?, ?, ?, ?, ?)`),
+		normalizeDomain(entry.DomainName), entry.Time.UnixNano(), entry.StatusCode, string(query), parsed, entry.Raw)
+
+	return err
+}
+
+// Get returns the entry of the domain saved at the time or ErrNotFound.
+func (s *SQLStore) Get(ctx context.Context, domainName string, t time.Time) (*Entry, error) {
+	row := s.DB.QueryRowContext(ctx, s.rebind(`SELECT status_code, query, parsed, raw FROM `+s.table()+
+		` WHERE domain_name = ? AND created_at = ?`), normalizeDomain(domainName), t.UnixNano())
+
+	entry := Entry{DomainName: domainName, Time: time.Unix(0, t.UnixNano()).UTC()}
+
+	var (
+		query  string
+		parsed int
+	)
+
+	err := row.Scan(&entry.StatusCode, &query, &parsed, &entry.Raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal([]byte(query), &entry.Query); err != nil {
+		return nil, err
+	}
+
+	entry.Parsed = parsed != 0
+
+	if err = parseEntry(&entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// List returns the times of the entries of the domain saved in [since, until) in ascending order.
+func (s *SQLStore) List(ctx context.Context, domainName string, since, until time.Time) ([]time.Time, error) {
+	query := `SELECT created_at FROM ` + s.table() + ` WHERE domain_name = ?`
+	args := []interface{}{normalizeDomain(domainName)}
+
+	if !since.IsZero() {
+		query += ` AND created_at >= ?`
+		args = append(args, since.UnixNano())
+	}
+
+	if !until.IsZero() {
+		query += ` AND created_at < ?`
+		args = append(args, until.UnixNano())
+	}
+
+	rows, err := s.DB.QueryContext(ctx, s.rebind(query+` ORDER BY created_at`), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var times []time.Time
+
+	for rows.Next() {
+		var nsec int64
+		if err = rows.Scan(&nsec); err != nil {
+			return nil, err
+		}
+
+		times = append(times, time.Unix(0, nsec).UTC())
+	}
+
+	return times, rows.Err()
+}
+
+// table returns the table name.
+func (s *SQLStore) table() string {
+	if s.Table == "" {
+		return defaultTable
+	}
+
+	return s.Table
+}
+
+// binaryType returns the column type of the raw responses.
+func (s *SQLStore) binaryType() string {
+	if s.BinaryType == "" {
+		return defaultBinaryType
+	}
+
+	return s.BinaryType
+}
+
+// rebind replaces "?" placeholders of the query using Placeholder.
+func (s *SQLStore) rebind(query string) string {
+	if s.Placeholder == nil {
+		return query
+	}
+
+	var b strings.Builder
+
+	n := 0
+
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+		b.WriteString(s.Placeholder(n))
+	}
+
+	return b.String()
+}
+
+// DollarPlaceholder returns the PostgreSQL style placeholder "$n".
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// normalizeDomain returns the domain name used as the key.
+func normalizeDomain(domainName string) string {
+	return strings.ToLower(strings.TrimSuffix(domainName, "."))
+}
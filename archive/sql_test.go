@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memDriver is the database/sql driver understanding just the statements issued by SQLStore.
+type memDriver struct {
+	mu      sync.Mutex
+	rows    [][]driver.Value
+	queries []string
+}
+
+func (d *memDriver) Open(string) (driver.Conn, error) { return &memConn{d: d}, nil }
+
+type memConn struct{ d *memDriver }
+
+func (c *memConn) Prepare(query string) (driver.Stmt, error) {
+	return &memStmt{d: c.d, query: query}, nil
+}
+func (c *memConn) Close() error              { return nil }
+func (c *memConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type memStmt struct {
+	d     *memDriver
+	query string
+}
+
+func (s *memStmt) Close() error  { return nil }
+func (s *memStmt) NumInput() int { return -1 }
+
+func (s *memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	s.d.queries = append(s.d.queries, s.query)
+
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.d.rows = append(s.d.rows, args)
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+
+	s.d.queries = append(s.d.queries, s.query)
+
+	var result [][]driver.Value
+
+	for _, row := range s.d.rows {
+		if row[0] != args[0] {
+			continue
+		}
+
+		created := row[1].(int64)
+
+		switch {
+		case strings.HasPrefix(s.query, "SELECT status_code"):
+			if created == args[1] {
+				result = append(result, row[2:])
+			}
+		case strings.HasPrefix(s.query, "SELECT created_at"):
+			i := 1
+			if strings.Contains(s.query, ">=") {
+				if created < args[i].(int64) {
+					continue
+				}
+				i++
+			}
+
+			if strings.Contains(s.query, "< ") && created >= args[i].(int64) {
+				continue
+			}
+
+			result = append(result, []driver.Value{created})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, aok := result[i][0].(int64)
+		b, bok := result[j][0].(int64)
+		return aok && bok && a < b
+	})
+
+	columns := []string{"created_at"}
+	if strings.HasPrefix(s.query, "SELECT status_code") {
+		columns = []string{"status_code", "query", "parsed", "raw"}
+	}
+
+	return &memRows{columns: columns, rows: result}, nil
+}
+
+type memRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *memRows) Columns() []string { return r.columns }
+func (r *memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
+
+var testDriver = &memDriver{}
+
+func init() {
+	sql.Register("archivetest", testDriver)
+}
+
+// TestSQLStore tests the SQL store.
+func TestSQLStore(t *testing.T) {
+	db, err := sql.Open("archivetest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	ctx := context.Background()
+	store := NewPostgreSQLStore(db)
+
+	if err = store.CreateTable(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	raw := `{"DNSData":{"domainName":"example.com","dnsRecords":[` +
+		`{"type":1,"dnsType":"A","name":"example.com.","ttl":60,"address":"192.0.2.1"}]}}`
+
+	for i := 0; i < 3; i++ {
+		entry := &Entry{
+			DomainName: "Example.com",
+			Time:       base.Add(time.Duration(i) * time.Hour),
+			Query:      url.Values{"type": {"A"}},
+			StatusCode: 200,
+			Raw:        []byte(raw),
+			Parsed:     i == 0,
+		}
+
+		if err = store.Put(ctx, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entry, err := store.Get(ctx, "example.com", base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.StatusCode != 200 || entry.Query.Get("type") != "A" || entry.Response == nil ||
+		len(entry.Response.DNSRecords.A) != 1 {
+		t.Errorf("Get() = %+v", entry)
+	}
+
+	if _, err = store.Get(ctx, "example.com", base.Add(time.Minute)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() missing error = %v", err)
+	}
+
+	times, err := store.List(ctx, "example.com", base.Add(time.Hour), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(times) != 2 || !times[0].Equal(base.Add(time.Hour)) || !times[1].Equal(base.Add(2*time.Hour)) {
+		t.Errorf("List() = %v", times)
+	}
+
+	for _, query := range testDriver.queries {
+		if strings.HasPrefix(query, "CREATE TABLE") && !strings.Contains(query, "raw BYTEA NOT NULL") {
+			t.Errorf("table not created with PostgreSQL types: %s", query)
+		}
+
+		if strings.HasPrefix(query, "SELECT") && (strings.Contains(query, "?") || !strings.Contains(query, "$1")) {
+			t.Errorf("query not rebound: %s", query)
+		}
+	}
+}
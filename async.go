@@ -0,0 +1,216 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is the result of GetAsync if the dispatcher queue is full.
+// It signals the caller to slow down, e.g. to pause consuming the input.
+var ErrQueueFull = errors.New("dispatcher queue is full")
+
+// Defaults of Dispatcher.
+const (
+	defaultDispatcherQueueSize = 256
+)
+
+// Dispatcher runs the lookups of GetAsync in the background with limited concurrency.
+// Failed lookups are retried by the client according to ClientParams.Retry.
+// When the API reports that the rate limit is exhausted, all lookups of the dispatcher wait until the limit
+// resets or for the time requested by the Retry-After header.
+// The zero value is ready to use. It is safe for concurrent use and can be shared by several clients of the same API.
+type Dispatcher struct {
+	// Concurrency is the maximum number of lookups in flight
+	// If it's not positive then 4 is used
+	Concurrency int
+
+	// QueueSize is the maximum number of pending lookups including the ones in flight,
+	// GetAsync fails with ErrQueueFull above it
+	// If it's not positive then 256 is used
+	QueueSize int
+
+	mu          sync.Mutex
+	sem         chan struct{}
+	pending     int
+	pausedUntil time.Time
+
+	// now is used for testing
+	now func() time.Time
+}
+
+// GetAsync looks up the domain name in the background using ClientParams.Dispatcher and returns
+// the channel receiving the single result, e.g. to make lookups from event-driven consumers
+// without blocking them. The channel is buffered and closed after the result, so it may be left unread.
+func (c *Client) GetAsync(ctx context.Context, domainName string, opts ...Option) <-chan LookupResult {
+	return c.dispatcher.Go(ctx, c.DNSLookupService, domainName, opts...)
+}
+
+// Pending returns the number of lookups waiting or in flight.
+func (d *Dispatcher) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.pending
+}
+
+// Go looks up the domain name with the service in the background and returns the channel receiving
+// the single result. If the queue is full, the result is ErrQueueFull.
+func (d *Dispatcher) Go(
+	ctx context.Context,
+	service DNSLookupService,
+	domainName string,
+	opts ...Option,
+) <-chan LookupResult {
+	results := make(chan LookupResult, 1)
+
+	d.mu.Lock()
+
+	if d.pending >= d.queueSize() {
+		d.mu.Unlock()
+
+		results <- LookupResult{DomainName: domainName, Err: ErrQueueFull}
+		close(results)
+
+		return results
+	}
+
+	d.pending++
+
+	if d.sem == nil {
+		concurrency := d.Concurrency
+		if concurrency <= 0 {
+			concurrency = defaultConcurrency
+		}
+
+		d.sem = make(chan struct{}, concurrency)
+	}
+
+	d.mu.Unlock()
+
+	go func() {
+		result := d.run(ctx, service, domainName, opts)
+
+		d.mu.Lock()
+		d.pending--
+		d.mu.Unlock()
+
+		results <- result
+		close(results)
+	}()
+
+	return results
+}
+
+// run makes the lookup. Failed lookups are retried by the service, see ClientParams.Retry.
+func (d *Dispatcher) run(ctx context.Context, service DNSLookupService, domainName string, opts []Option) LookupResult {
+	result := LookupResult{DomainName: domainName}
+
+	if err := d.acquire(ctx); err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Response, result.Raw, result.Err = service.Get(ctx, domainName, opts...)
+
+	<-d.sem
+
+	d.observe(result.Raw, result.Err)
+
+	return result
+}
+
+// acquire waits for a free slot and for the end of the rate limit pause.
+func (d *Dispatcher) acquire(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case d.sem <- struct{}{}:
+	}
+
+	for {
+		d.mu.Lock()
+		wait := d.pausedUntil.Sub(d.clock())
+		d.mu.Unlock()
+
+		if wait <= 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			<-d.sem
+			return err
+		}
+	}
+}
+
+// observe pauses the lookups if the response reports that the rate limit is exhausted.
+func (d *Dispatcher) observe(resp *Response, err error) {
+	var header http.Header
+
+	var errResp *ErrorResponse
+
+	switch {
+	case resp != nil && resp.Response != nil:
+		header = resp.Header
+	case errors.As(err, &errResp) && errResp.Response != nil:
+		header = errResp.Response.Header
+	default:
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if until := throttledUntil(header, d.clock()); until.After(d.pausedUntil) {
+		d.pausedUntil = until
+	}
+}
+
+// throttledUntil returns the time the API asks to wait until with the rate-limit headers: the Retry-After delay,
+// or the reset of the exhausted rate limit window. It returns the zero time if the headers don't ask to wait.
+func throttledUntil(header http.Header, now time.Time) time.Time {
+	rl := parseRateLimit(header, now)
+
+	switch {
+	case rl.RetryAfter > 0:
+		return now.Add(rl.RetryAfter)
+	case rl.Remaining == 0:
+		return rl.Reset
+	}
+
+	return time.Time{}
+}
+
+// queueSize returns the maximum number of pending lookups.
+func (d *Dispatcher) queueSize() int {
+	if d.QueueSize > 0 {
+		return d.QueueSize
+	}
+
+	return defaultDispatcherQueueSize
+}
+
+// clock returns the current time.
+func (d *Dispatcher) clock() time.Time {
+	if d.now != nil {
+		return d.now()
+	}
+
+	return time.Now()
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestGetAsync tests that the failed lookups are retried by the client and throttling pauses the dispatcher.
+func TestGetAsync(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 3:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[` +
+				`{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	dispatcher := &Dispatcher{now: func() time.Time { return now }}
+
+	baseURL, _ := url.Parse(server.URL)
+	params := ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		Dispatcher:       dispatcher,
+	}
+
+	noRetry := NewClient(apiKey, params)
+
+	params.Retry = &RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}
+	client := NewClient(apiKey, params)
+
+	result := <-client.GetAsync(context.Background(), "example.com")
+	if result.Err != nil || requests != 2 {
+		t.Fatalf("GetAsync() error = %v after %d requests, want success after 2", result.Err, requests)
+	}
+
+	result = <-noRetry.GetAsync(context.Background(), "example.com")
+	if !errors.Is(result.Err, ErrThrottled) || requests != 3 {
+		t.Fatalf("GetAsync() error = %v after %d requests, want %v after 3", result.Err, requests, ErrThrottled)
+	}
+
+	if !dispatcher.pausedUntil.Equal(now.Add(time.Second)) {
+		t.Errorf("pausedUntil = %v, want %v", dispatcher.pausedUntil, now.Add(time.Second))
+	}
+
+	// the fixed clock keeps the dispatcher paused until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result = <-client.GetAsync(ctx, "example.com")
+	if !errors.Is(result.Err, context.DeadlineExceeded) || requests != 3 {
+		t.Fatalf("GetAsync() while paused error = %v after %d requests, want %v", result.Err, requests,
+			context.DeadlineExceeded)
+	}
+
+	now = now.Add(time.Second)
+
+	result = <-client.GetAsync(context.Background(), "example.com")
+	if result.Err != nil || len(result.Response.DNSRecords.A) != 1 || result.DomainName != "example.com" {
+		t.Errorf("GetAsync() = %+v", result)
+	}
+
+	if _, ok := <-client.GetAsync(ctx, "example.com"); !ok {
+		t.Error("GetAsync() channel is not buffered")
+	}
+
+	if dispatcher.Pending() != 0 {
+		t.Errorf("Pending() = %d", dispatcher.Pending())
+	}
+}
+
+// TestGetAsyncLookupTimeout tests that the lookups are not retried after the lookup timeout is exceeded.
+func TestGetAsyncLookupTimeout(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		Dispatcher:       &Dispatcher{},
+		Retry:            &RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond},
+		LookupTimeout:    20 * time.Millisecond,
+	})
+
+	result := <-client.GetAsync(context.Background(), "example.com")
+
+	var timeout *TimeoutExceeded
+	if !errors.As(result.Err, &timeout) {
+		t.Errorf("GetAsync() error = %v, want TimeoutExceeded", result.Err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("%d requests sent, want 1", n)
+	}
+}
+
+// TestDispatcherQueueFull tests that the lookups above the queue size fail immediately.
+func TestDispatcherQueueFull(t *testing.T) {
+	service := &hostService{}
+	dispatcher := &Dispatcher{QueueSize: 2, Concurrency: 1}
+
+	ctx := context.Background()
+
+	first := dispatcher.Go(ctx, service, "a.example.com")
+	second := dispatcher.Go(ctx, service, "b.example.com")
+	third := dispatcher.Go(ctx, service, "c.example.com")
+
+	if result := <-third; !errors.Is(result.Err, ErrQueueFull) || result.DomainName != "c.example.com" {
+		t.Errorf("Go() above the queue size = %+v, want ErrQueueFull", result)
+	}
+
+	for _, results := range []<-chan LookupResult{first, second} {
+		if result := <-results; result.Err != nil {
+			t.Errorf("Go() error = %v", result.Err)
+		}
+	}
+
+	if service.maxInFlight != 1 {
+		t.Errorf("max in flight = %d, want 1", service.maxInFlight)
+	}
+}
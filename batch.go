@@ -0,0 +1,428 @@
+package dnslookupapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultBatchSize is the number of domain names passed to GetMany at once by RunBatch if not specified.
+const defaultBatchSize = 100
+
+// DomainReader reads domain names from plain text with one name per line or from a CSV column.
+// Leading and trailing spaces are trimmed; empty lines and lines starting with "#" are skipped.
+type DomainReader struct {
+	next func() (string, error)
+}
+
+// NewDomainReader creates DomainReader reading one domain name per line.
+func NewDomainReader(r io.Reader) *DomainReader {
+	scanner := bufio.NewScanner(r)
+
+	return &DomainReader{next: func() (string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+
+			return "", io.EOF
+		}
+
+		return scanner.Text(), nil
+	}}
+}
+
+// NewCSVDomainReader creates DomainReader reading domain names from the column of the CSV input.
+// Columns are numbered from zero. If header is set, the first row is skipped.
+func NewCSVDomainReader(r io.Reader, column int, header bool) *DomainReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	return &DomainReader{next: func() (string, error) {
+		row, err := reader.Read()
+		if err != nil {
+			return "", err
+		}
+
+		if header {
+			header = false
+
+			if row, err = reader.Read(); err != nil {
+				return "", err
+			}
+		}
+
+		if column < 0 || column >= len(row) {
+			line, _ := reader.FieldPos(0)
+			return "", fmt.Errorf("line %d: no column %d", line, column)
+		}
+
+		return row[column], nil
+	}}
+}
+
+// Next returns the next domain name. It returns io.EOF when the input is exhausted.
+func (d *DomainReader) Next() (string, error) {
+	for {
+		line, err := d.next()
+		if err != nil {
+			return "", err
+		}
+
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return line, nil
+		}
+	}
+}
+
+// Exporter writes the results of the lookups made by RunBatch.
+type Exporter interface {
+	// Export writes the result.
+	Export(result LookupResult) error
+
+	// Flush writes any buffered data.
+	Flush() error
+}
+
+// JSONLinesExporter is the Exporter writing one JSON object per result:
+// {"domainName":...,"records":[...]} with the records in the original API format,
+// or {"domainName":...,"error":...} if the lookup failed.
+type JSONLinesExporter struct {
+	w *bufio.Writer
+}
+
+var _ Exporter = &JSONLinesExporter{}
+
+// NewJSONLinesExporter creates JSONLinesExporter writing to w.
+func NewJSONLinesExporter(w io.Writer) *JSONLinesExporter {
+	return &JSONLinesExporter{w: bufio.NewWriter(w)}
+}
+
+// Export writes the result as a JSON line.
+func (e *JSONLinesExporter) Export(result LookupResult) error {
+	line := struct {
+		DomainName string          `json:"domainName"`
+		Records    json.RawMessage `json:"records,omitempty"`
+		Error      string          `json:"error,omitempty"`
+	}{DomainName: result.DomainName}
+
+	switch {
+	case result.Err != nil:
+		line.Error = result.Err.Error()
+	case result.Response != nil:
+		records, err := result.Response.DNSRecords.MarshalAPI()
+		if err != nil {
+			return err
+		}
+
+		line.Records = records
+	}
+
+	raw, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+
+	if _, err = e.w.Write(append(raw, '\n')); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Flush writes the buffered lines.
+func (e *JSONLinesExporter) Flush() error {
+	return e.w.Flush()
+}
+
+// CSVExporter is the Exporter writing one CSV row per DNS record with the columns
+// domainName, dnsType, name, ttl, rawText and error. A failed lookup is written as a single row with the error,
+// a lookup without records as a single row with the domain name only.
+type CSVExporter struct {
+	w      *csv.Writer
+	header bool
+}
+
+var _ Exporter = &CSVExporter{}
+
+// NewCSVExporter creates CSVExporter writing to w. The header row is written before the first result.
+func NewCSVExporter(w io.Writer) *CSVExporter {
+	return &CSVExporter{w: csv.NewWriter(w)}
+}
+
+// Export writes the rows of the result.
+func (e *CSVExporter) Export(result LookupResult) error {
+	if !e.header {
+		e.header = true
+
+		if err := e.w.Write([]string{"domainName", "dnsType", "name", "ttl", "rawText", "error"}); err != nil {
+			return err
+		}
+	}
+
+	if result.Err != nil || result.Response == nil || len(result.Response.DNSRecords.All) == 0 {
+		msg := ""
+		if result.Err != nil {
+			msg = result.Err.Error()
+		}
+
+		return e.w.Write([]string{result.DomainName, "", "", "", "", msg})
+	}
+
+	for _, record := range result.Response.DNSRecords.All {
+		f := record.CommonFields
+
+		err := e.w.Write([]string{result.DomainName, f.DNSType, f.Name, strconv.Itoa(f.TTL), f.RawText, ""})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush writes the buffered rows.
+func (e *CSVExporter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Checkpoint records the domain names which are done, so an interrupted batch can be resumed.
+type Checkpoint interface {
+	// Done reports whether the domain name is already done.
+	Done(domainName string) bool
+
+	// Mark records the domain names as done.
+	Mark(domainNames ...string) error
+}
+
+// FileCheckpoint is the Checkpoint appending the done domain names to a file, one per line.
+type FileCheckpoint struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[string]bool
+}
+
+var _ Checkpoint = &FileCheckpoint{}
+
+// OpenFileCheckpoint opens the checkpoint file, creating it if it doesn't exist,
+// and loads the domain names done by previous runs.
+func OpenFileCheckpoint(name string) (*FileCheckpoint, error) {
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[string]bool)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			done[line] = true
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &FileCheckpoint{file: file, done: done}, nil
+}
+
+// Done reports whether the domain name is in the checkpoint file.
+func (c *FileCheckpoint) Done(domainName string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.done[domainName]
+}
+
+// Mark appends the domain names to the checkpoint file.
+func (c *FileCheckpoint) Mark(domainNames ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var b strings.Builder
+
+	for _, domainName := range domainNames {
+		if !c.done[domainName] {
+			c.done[domainName] = true
+			b.WriteString(domainName + "\n")
+		}
+	}
+
+	if b.Len() == 0 {
+		return nil
+	}
+
+	_, err := c.file.WriteString(b.String())
+
+	return err
+}
+
+// Close closes the checkpoint file.
+func (c *FileCheckpoint) Close() error {
+	return c.file.Close()
+}
+
+// BatchProgress is the progress of RunBatch.
+type BatchProgress struct {
+	// Read is the number of domain names read from the input.
+	Read int
+
+	// Skipped is the number of domain names skipped as done by a previous run.
+	Skipped int
+
+	// Succeeded and Failed are the numbers of finished lookups.
+	Succeeded int
+	Failed    int
+}
+
+// BatchParams are the parameters of RunBatch.
+type BatchParams struct {
+	// Concurrency is the number of concurrent requests, see GetMany.
+	Concurrency int
+
+	// BatchSize is the number of domain names looked up before the results are exported and checkpointed
+	// If it's zero then it's 100
+	BatchSize int
+
+	// Options are applied to every lookup
+	Options []Option
+
+	// Exporter receives the results in the order of the input
+	// If it's nil then the results are discarded
+	Exporter Exporter
+
+	// Checkpoint records the domain names exported with results or permanent failures and skips the ones
+	// done by a previous run. Lookups failed with temporary errors are retried by the next run
+	// If it's nil then the batch is not resumable
+	Checkpoint Checkpoint
+
+	// Progress is called after every exported batch of results
+	Progress func(BatchProgress)
+}
+
+// RunBatch reads the domain names from src, looks them up with GetMany and exports the results
+// in batches of BatchSize. Failed lookups are exported too and don't stop the run.
+// A result is checkpointed only after it's exported, so a run interrupted at any point can be resumed
+// by running it again with the same Checkpoint. Failed lookups are checkpointed only if the failure is
+// permanent, e.g. ErrInvalidDomain, so throttled, server and transport failures are exported again by
+// the resumed run. If the context is done, the lookups not finished yet are neither exported
+// nor checkpointed and the context error is returned.
+func RunBatch(ctx context.Context, service DNSLookupService, src *DomainReader, params BatchParams) (
+	BatchProgress,
+	error,
+) {
+	size := params.BatchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+
+	var progress BatchProgress
+
+	batch := make([]string, 0, size)
+
+	for eof := false; !eof; {
+		batch = batch[:0]
+
+		for len(batch) < size {
+			domainName, err := src.Next()
+			if errors.Is(err, io.EOF) {
+				eof = true
+				break
+			}
+
+			if err != nil {
+				return progress, fmt.Errorf("cannot read domain names: %w", err)
+			}
+
+			progress.Read++
+
+			if params.Checkpoint != nil && params.Checkpoint.Done(domainName) {
+				progress.Skipped++
+				continue
+			}
+
+			batch = append(batch, domainName)
+		}
+
+		if len(batch) == 0 {
+			continue
+		}
+
+		results := GetMany(ctx, service, batch, params.Concurrency, params.Options...)
+
+		if err := exportBatch(ctx, results, params, &progress); err != nil {
+			return progress, err
+		}
+
+		if params.Progress != nil {
+			params.Progress(progress)
+		}
+
+		if err := ctx.Err(); err != nil {
+			return progress, err
+		}
+	}
+
+	return progress, nil
+}
+
+// exportBatch exports and checkpoints the results, stopping at the first result interrupted by the context.
+func exportBatch(ctx context.Context, results []LookupResult, params BatchParams, progress *BatchProgress) error {
+	done := make([]string, 0, len(results))
+
+	for _, result := range results {
+		if result.Err != nil && ctx.Err() != nil && errors.Is(result.Err, ctx.Err()) {
+			break
+		}
+
+		if params.Exporter != nil {
+			if err := params.Exporter.Export(result); err != nil {
+				return fmt.Errorf("cannot export %s: %w", result.DomainName, err)
+			}
+		}
+
+		if result.Err != nil {
+			progress.Failed++
+		} else {
+			progress.Succeeded++
+		}
+
+		if result.Err == nil || permanentError(result.Err) {
+			done = append(done, result.DomainName)
+		}
+	}
+
+	if params.Exporter != nil {
+		if err := params.Exporter.Flush(); err != nil {
+			return fmt.Errorf("cannot export results: %w", err)
+		}
+	}
+
+	if params.Checkpoint != nil {
+		if err := params.Checkpoint.Mark(done...); err != nil {
+			return fmt.Errorf("cannot save checkpoint: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// permanentError reports whether the lookup failure won't go away when the lookup is retried.
+func permanentError(err error) bool {
+	var argErr *ArgError
+
+	return errors.Is(err, ErrInvalidDomain) || errors.As(err, &argErr)
+}
@@ -0,0 +1,210 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readAll returns all domain names of the reader.
+func readAll(t *testing.T, src *DomainReader) []string {
+	t.Helper()
+
+	var names []string
+
+	for {
+		name, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return names
+		}
+
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+
+		names = append(names, name)
+	}
+}
+
+// TestDomainReader tests reading domain names from lines and CSV columns.
+func TestDomainReader(t *testing.T) {
+	want := []string{"a.example.com", "b.example.com", "c.example.com"}
+
+	lines := NewDomainReader(strings.NewReader("a.example.com\n\n# comment\n  b.example.com \r\nc.example.com"))
+	if got := readAll(t, lines); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewDomainReader() = %v, want %v", got, want)
+	}
+
+	input := "id,domain\n1,a.example.com\n2, b.example.com\n# skipped\n3,c.example.com\n"
+
+	columns := NewCSVDomainReader(strings.NewReader(input), 1, true)
+	if got := readAll(t, columns); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCSVDomainReader() = %v, want %v", got, want)
+	}
+
+	missing := NewCSVDomainReader(strings.NewReader("a.example.com\n"), 1, false)
+	if _, err := missing.Next(); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("Next() error = %v, want missing column error", err)
+	}
+}
+
+// TestRunBatch tests that the results are exported in the input order with progress reported.
+func TestRunBatch(t *testing.T) {
+	service := &hostService{records: map[string]string{
+		"a.example.com": `[{"type":1,"dnsType":"A","name":"a.example.com.","ttl":300,` +
+			`"rawText":"a.example.com.\t300\tIN\tA\t192.0.2.1","address":"192.0.2.1"}]`,
+	}}
+
+	var out bytes.Buffer
+
+	var reports []BatchProgress
+
+	src := NewDomainReader(strings.NewReader("a.example.com\nfail.example.com\nb.example.com\n"))
+
+	progress, err := RunBatch(context.Background(), service, src, BatchParams{
+		BatchSize: 2,
+		Exporter:  NewJSONLinesExporter(&out),
+		Progress:  func(p BatchProgress) { reports = append(reports, p) },
+	})
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+
+	if want := (BatchProgress{Read: 3, Succeeded: 2, Failed: 1}); progress != want {
+		t.Errorf("RunBatch() progress = %+v, want %+v", progress, want)
+	}
+
+	if len(reports) != 2 || reports[0].Read != 2 {
+		t.Errorf("RunBatch() reported progress = %+v", reports)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("RunBatch() exported %d lines, want 3", len(lines))
+	}
+
+	var first struct {
+		DomainName string            `json:"domainName"`
+		Records    []json.RawMessage `json:"records"`
+	}
+
+	if err = json.Unmarshal([]byte(lines[0]), &first); err != nil || first.DomainName != "a.example.com" ||
+		len(first.Records) != 1 {
+		t.Errorf("RunBatch() first line = %s, error = %v", lines[0], err)
+	}
+
+	if !strings.Contains(lines[1], `"error":"lookup failed"`) {
+		t.Errorf("RunBatch() second line = %s", lines[1])
+	}
+}
+
+// TestRunBatchResume tests that a run with the same checkpoint skips the domain names already done.
+func TestRunBatchResume(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "checkpoint")
+	input := "a.example.com\nb.example.com\nc.example.com\n"
+
+	checkpoint, err := OpenFileCheckpoint(name)
+	if err != nil {
+		t.Fatalf("OpenFileCheckpoint() error = %v", err)
+	}
+
+	if err = checkpoint.Mark("a.example.com", "b.example.com"); err != nil {
+		t.Fatalf("Mark() error = %v", err)
+	}
+
+	checkpoint.Close()
+
+	if checkpoint, err = OpenFileCheckpoint(name); err != nil {
+		t.Fatalf("OpenFileCheckpoint() error = %v", err)
+	}
+	defer checkpoint.Close()
+
+	service := &hostService{}
+
+	var out bytes.Buffer
+
+	src := NewDomainReader(strings.NewReader(input))
+
+	progress, err := RunBatch(context.Background(), service, src, BatchParams{
+		Exporter:   NewCSVExporter(&out),
+		Checkpoint: checkpoint,
+	})
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+
+	if want := (BatchProgress{Read: 3, Skipped: 2, Succeeded: 1}); progress != want {
+		t.Errorf("RunBatch() progress = %+v, want %+v", progress, want)
+	}
+
+	if !reflect.DeepEqual(service.calls, []string{"c.example.com"}) {
+		t.Errorf("RunBatch() looked up %v", service.calls)
+	}
+
+	if want := "domainName,dnsType,name,ttl,rawText,error\nc.example.com,,,,,\n"; out.String() != want {
+		t.Errorf("RunBatch() exported %q, want %q", out.String(), want)
+	}
+
+	if !checkpoint.Done("c.example.com") {
+		t.Error("Done() = false after the run")
+	}
+}
+
+// TestRunBatchCheckpointFailures tests that only permanent failures are checkpointed.
+func TestRunBatchCheckpointFailures(t *testing.T) {
+	checkpoint, err := OpenFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	if err != nil {
+		t.Fatalf("OpenFileCheckpoint() error = %v", err)
+	}
+	defer checkpoint.Close()
+
+	src := NewDomainReader(strings.NewReader("a.example.com\nfail.example.com\ninvalid.example.com\n"))
+
+	progress, err := RunBatch(context.Background(), &hostService{}, src, BatchParams{Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("RunBatch() error = %v", err)
+	}
+
+	if want := (BatchProgress{Read: 3, Succeeded: 1, Failed: 2}); progress != want {
+		t.Errorf("RunBatch() progress = %+v, want %+v", progress, want)
+	}
+
+	for name, want := range map[string]bool{
+		"a.example.com":       true,
+		"fail.example.com":    false,
+		"invalid.example.com": true,
+	} {
+		if got := checkpoint.Done(name); got != want {
+			t.Errorf("Done(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// TestRunBatchCanceled tests that lookups interrupted by the context are not checkpointed.
+func TestRunBatchCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	checkpoint, err := OpenFileCheckpoint(filepath.Join(t.TempDir(), "checkpoint"))
+	if err != nil {
+		t.Fatalf("OpenFileCheckpoint() error = %v", err)
+	}
+	defer checkpoint.Close()
+
+	src := NewDomainReader(strings.NewReader("a.example.com\n"))
+
+	_, err = RunBatch(ctx, &hostService{}, src, BatchParams{Checkpoint: checkpoint})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunBatch() error = %v, want %v", err, context.Canceled)
+	}
+
+	if checkpoint.Done("a.example.com") {
+		t.Error("Done() = true for the interrupted lookup")
+	}
+}
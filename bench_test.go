@@ -0,0 +1,70 @@
+package dnslookupapi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/dnslookupapitest"
+)
+
+// benchmarkSizes are the fixture sizes the parser is benchmarked with.
+var benchmarkSizes = []struct {
+	name    string
+	records int
+}{
+	{"small", dnslookupapitest.SmallRecords},
+	{"medium", dnslookupapitest.MediumRecords},
+	{"huge", dnslookupapitest.HugeRecords},
+}
+
+// BenchmarkDNSRecordsUnmarshal benchmarks parsing of DNS records.
+func BenchmarkDNSRecordsUnmarshal(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		data := []byte(dnslookupapitest.SizedRecords(size.records))
+
+		b.Run(size.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+
+			for i := 0; i < b.N; i++ {
+				var records dnslookupapi.DNSRecords
+				if err := json.Unmarshal(data, &records); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParseResponse benchmarks parsing of the whole response.
+func BenchmarkParseResponse(b *testing.B) {
+	data := []byte(dnslookupapitest.SizedResponse(dnslookupapitest.MediumRecords))
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+
+	for i := 0; i < b.N; i++ {
+		var resp struct {
+			DNSData dnslookupapi.DNSLookupResponse `json:"DNSData"`
+		}
+
+		if err := json.Unmarshal(data, &resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkTimeUnmarshal benchmarks parsing of the audit dates.
+func BenchmarkTimeUnmarshal(b *testing.B) {
+	data := []byte(`"2022-07-12 11:46:25 UTC"`)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		var t dnslookupapi.Time
+		if err := t.UnmarshalJSON(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of the circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed means that requests are sent normally.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen means that requests fail fast with ErrCircuitOpen until the cooldown elapses.
+	BreakerOpen
+
+	// BreakerHalfOpen means that a single trial request is sent to check whether the API has recovered.
+	BreakerHalfOpen
+)
+
+// String returns the state name.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// CircuitBreaker stops sending requests after a number of consecutive failures.
+// Transport errors, 5xx and 429 responses are failures; other responses are successes.
+// It is safe for concurrent use and can be shared by several clients.
+type CircuitBreaker struct {
+	// Threshold is the number of consecutive failures which opens the breaker
+	// If it's zero then 5 is used
+	Threshold int
+
+	// Cooldown is the time the breaker stays open before a trial request is allowed
+	// If it's zero then 30 seconds is used
+	Cooldown time.Duration
+
+	// OnStateChange is called on every state transition, e.g. to export the state as a metric
+	// It's called synchronously and must not use the breaker
+	OnStateChange func(from, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	trial    bool
+
+	// now is used for testing
+	now func() time.Time
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.clock().Sub(b.openedAt) >= b.cooldown() {
+		return BreakerHalfOpen
+	}
+
+	return b.state
+}
+
+// allow returns ErrCircuitOpen if the request must not be sent.
+// trial reports whether the allowed request is the half-open trial request.
+func (b *CircuitBreaker) allow() (trial bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.clock().Sub(b.openedAt) < b.cooldown() {
+			return false, ErrCircuitOpen
+		}
+
+		b.setState(BreakerHalfOpen)
+		b.trial = true
+
+		return true, nil
+	case BreakerHalfOpen:
+		if b.trial {
+			return false, ErrCircuitOpen
+		}
+
+		b.trial = true
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// record records the outcome of the allowed request.
+// Outcomes of requests allowed before the breaker opened are ignored unless it's closed.
+func (b *CircuitBreaker) record(trial, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if trial {
+		b.trial = false
+	} else if b.state != BreakerClosed {
+		return
+	}
+
+	if success {
+		b.failures = 0
+		b.setState(BreakerClosed)
+
+		return
+	}
+
+	b.failures++
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold() {
+		b.openedAt = b.clock()
+		b.setState(BreakerOpen)
+	}
+}
+
+// release releases the allowed request without recording its outcome, e.g. if it was canceled.
+func (b *CircuitBreaker) release(trial bool) {
+	if !trial {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// done records the outcome of the allowed request made with the context.
+func (b *CircuitBreaker) done(ctx context.Context, trial bool, resp *http.Response, err error) {
+	if err != nil && ctx.Err() != nil {
+		b.release(trial)
+		return
+	}
+
+	b.record(trial, !isFailure(resp, err))
+}
+
+// setState changes the state and calls OnStateChange.
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+
+	if b.OnStateChange != nil {
+		b.OnStateChange(from, state)
+	}
+}
+
+// threshold returns the failure threshold.
+func (b *CircuitBreaker) threshold() int {
+	if b.Threshold <= 0 {
+		return 5
+	}
+
+	return b.Threshold
+}
+
+// cooldown returns the cooldown.
+func (b *CircuitBreaker) cooldown() time.Duration {
+	if b.Cooldown <= 0 {
+		return 30 * time.Second
+	}
+
+	return b.Cooldown
+}
+
+// clock returns the current time.
+func (b *CircuitBreaker) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+
+	return time.Now()
+}
+
+// isFailure reports whether the request outcome counts as a failure of the API.
+func isFailure(resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil
+	}
+
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
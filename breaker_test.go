@@ -0,0 +1,188 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker tests the circuit breaker state transitions.
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+
+	var transitions []string
+
+	b := &CircuitBreaker{
+		Threshold: 2,
+		Cooldown:  time.Minute,
+		OnStateChange: func(from, to BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+		now: func() time.Time { return now },
+	}
+
+	steps := []struct {
+		success   bool
+		wantState BreakerState
+	}{
+		{success: false, wantState: BreakerClosed},
+		{success: true, wantState: BreakerClosed},
+		{success: false, wantState: BreakerClosed},
+		{success: false, wantState: BreakerOpen},
+	}
+
+	for i, step := range steps {
+		trial, err := b.allow()
+		if err != nil {
+			t.Fatalf("step %d: allow() error = %v", i, err)
+		}
+
+		b.record(trial, step.success)
+
+		if got := b.State(); got != step.wantState {
+			t.Errorf("step %d: State() = %v, want %v", i, got, step.wantState)
+		}
+	}
+
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() while open error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	now = now.Add(time.Minute)
+
+	if got := b.State(); got != BreakerHalfOpen {
+		t.Errorf("State() after cooldown = %v, want %v", got, BreakerHalfOpen)
+	}
+
+	trial, err := b.allow()
+	if err != nil || !trial {
+		t.Fatalf("trial allow() = %v, %v, want true, nil", trial, err)
+	}
+
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("concurrent allow() during trial error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	b.record(trial, false)
+
+	if got := b.State(); got != BreakerOpen {
+		t.Errorf("State() after failed trial = %v, want %v", got, BreakerOpen)
+	}
+
+	now = now.Add(time.Minute)
+
+	if trial, err = b.allow(); err != nil {
+		t.Fatalf("second trial allow() error = %v", err)
+	}
+
+	b.record(trial, true)
+
+	want := []string{"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", transitions, want)
+			break
+		}
+	}
+}
+
+// TestCircuitBreakerStaleRequest tests that a request allowed before the breaker opened
+// doesn't end the half-open trial when it completes.
+func TestCircuitBreakerStaleRequest(t *testing.T) {
+	now := time.Now()
+	b := &CircuitBreaker{Threshold: 1, Cooldown: time.Minute, now: func() time.Time { return now }}
+
+	stale, err := b.allow()
+	if err != nil {
+		t.Fatalf("allow() error = %v", err)
+	}
+
+	failed, _ := b.allow()
+	b.record(failed, false)
+
+	now = now.Add(time.Minute)
+
+	trial, err := b.allow()
+	if err != nil || !trial {
+		t.Fatalf("trial allow() = %v, %v, want true, nil", trial, err)
+	}
+
+	b.record(stale, true)
+
+	if got := b.State(); got != BreakerHalfOpen {
+		t.Errorf("State() after stale success = %v, want %v", got, BreakerHalfOpen)
+	}
+
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() after stale success error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	b.release(stale)
+
+	if _, err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() after stale release error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	b.record(trial, true)
+
+	if got := b.State(); got != BreakerClosed {
+		t.Errorf("State() after successful trial = %v, want %v", got, BreakerClosed)
+	}
+}
+
+// TestClientCircuitBreaker tests that the client fails fast while the breaker is open.
+func TestClientCircuitBreaker(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	breaker := &CircuitBreaker{Threshold: 3, Cooldown: time.Hour}
+
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		CircuitBreaker:   breaker,
+	})
+
+	for i := 0; i < 5; i++ {
+		_, err := client.GetRaw(context.Background(), "whoisxmlapi.com")
+		if i >= 3 && !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("request %d error = %v, want %v", i, err, ErrCircuitOpen)
+		}
+	}
+
+	_, err := client.GetStream(context.Background(), "whoisxmlapi.com", func(DNSRecord) error { return nil })
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetStream() error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	if requests != 3 {
+		t.Errorf("%d requests sent, want 3", requests)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	breaker.now = func() time.Time { return time.Now().Add(time.Hour) }
+
+	if _, err = client.GetRaw(ctx, "whoisxmlapi.com"); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("canceled trial error = %v", err)
+	}
+
+	if got := breaker.State(); got != BreakerHalfOpen {
+		t.Errorf("State() after canceled trial = %v, want %v", got, BreakerHalfOpen)
+	}
+}
@@ -0,0 +1,78 @@
+package dnslookupapi
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultConcurrency is the number of concurrent requests made by GetMany if not specified.
+const defaultConcurrency = 4
+
+// LookupResult is the result of a single lookup made by GetMany or GetAsync.
+type LookupResult struct {
+	// DomainName is the requested domain name.
+	DomainName string
+
+	// Response is the parsed response, nil if the lookup failed.
+	Response *DNSLookupResponse
+
+	// Raw is the raw response, if any.
+	Raw *Response
+
+	// Err is the error of the lookup.
+	Err error
+}
+
+// GetMany looks up the domain names concurrently with at most concurrency requests in flight
+// and returns the results in the order of domainNames. If concurrency is not positive, 4 is used.
+// Lookups not started before the context is done fail with the context error.
+// If the client has ClientParams.Scheduler, tag ctx with the tenant using TenantTag and with PriorityLow
+// to share the client concurrency fairly with the interactive lookups.
+// If the client has ClientParams.AdaptiveConcurrency, concurrency only caps the limit tuned by the controller.
+func GetMany(
+	ctx context.Context,
+	service DNSLookupService,
+	domainNames []string,
+	concurrency int,
+	opts ...Option,
+) []LookupResult {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	results := make([]LookupResult, len(domainNames))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, domainName := range domainNames {
+		results[i].DomainName = domainName
+
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func(result *LookupResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			result.Response, result.Raw, result.Err = service.Get(ctx, result.DomainName, opts...)
+		}(&results[i])
+	}
+
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,115 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// hostService is the DNSLookupService returning prepared DNS records per domain name.
+type hostService struct {
+	records map[string]string
+
+	// probe are the records returned for wildcard probe labels
+	probe string
+
+	inFlight    int32
+	maxInFlight int32
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// Get returns the records prepared for the domain name or empty records.
+func (s *hostService) Get(_ context.Context, domainName string, _ ...Option) (*DNSLookupResponse, *Response, error) {
+	n := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+
+	for {
+		max := atomic.LoadInt32(&s.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&s.maxInFlight, max, n) {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, domainName)
+	s.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	if domainName == "fail.example.com" {
+		return nil, nil, errors.New("lookup failed")
+	}
+
+	if domainName == "invalid.example.com" {
+		return nil, nil, &ErrorMessage{Code: "422", Message: "Invalid domain name"}
+	}
+
+	records, ok := s.records[domainName]
+	if !ok {
+		records = "[]"
+	}
+
+	if strings.HasPrefix(domainName, "wildcard-probe-") && s.probe != "" {
+		records = s.probe
+	}
+
+	resp := &DNSLookupResponse{DomainName: domainName}
+	if err := json.Unmarshal([]byte(records), &resp.DNSRecords); err != nil {
+		return nil, nil, err
+	}
+
+	return resp, &Response{}, nil
+}
+
+// GetRaw is not used.
+func (s *hostService) GetRaw(context.Context, string, ...Option) (*Response, error) {
+	return nil, errors.New("not implemented")
+}
+
+// TestGetMany tests concurrent lookups.
+func TestGetMany(t *testing.T) {
+	service := &hostService{records: map[string]string{
+		"a.example.com": `[{"type":1,"dnsType":"A","name":"a.example.com.","address":"1.1.1.1"}]`,
+	}}
+
+	domains := []string{"a.example.com", "b.example.com", "fail.example.com", "c.example.com", "d.example.com"}
+
+	results := GetMany(context.Background(), service, domains, 2)
+	if len(results) != len(domains) {
+		t.Fatalf("GetMany() returned %d results", len(results))
+	}
+
+	for i, result := range results {
+		if result.DomainName != domains[i] {
+			t.Errorf("result %d domain = %v, want %v", i, result.DomainName, domains[i])
+		}
+
+		if (result.Err != nil) != (domains[i] == "fail.example.com") {
+			t.Errorf("result %d error = %v", i, result.Err)
+		}
+	}
+
+	if len(results[0].Response.DNSRecords.A) != 1 {
+		t.Errorf("result 0 records = %v", results[0].Response.DNSRecords.All)
+	}
+
+	if max := atomic.LoadInt32(&service.maxInFlight); max > 2 {
+		t.Errorf("max in flight = %d, want at most 2", max)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, result := range GetMany(ctx, service, domains, 1) {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("canceled GetMany() error = %v", result.Err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package dnslookupapi
+
+import (
+	"strings"
+)
+
+// caaFlagCritical is the Issuer Critical flag of the CAA record.
+const caaFlagCritical = 128
+
+// CAA property tags (RFC 8659).
+const (
+	CAATagIssue     = "issue"
+	CAATagIssueWild = "issuewild"
+	CAATagIodef     = "iodef"
+)
+
+// knownCAATags are the property tags which are understood by CAAAllowsIssuer.
+var knownCAATags = map[string]bool{
+	CAATagIssue:     true,
+	CAATagIssueWild: true,
+	CAATagIodef:     true,
+}
+
+// IsCritical reports whether the Issuer Critical flag is set.
+func (r CAARecord) IsCritical() bool {
+	return r.Flags&caaFlagCritical != 0
+}
+
+// Issuer returns the issuer domain name of the issue or issuewild property.
+// An empty string means that no CA is authorized.
+func (r CAARecord) Issuer() string {
+	value := strings.Trim(strings.TrimSpace(r.Value), `"`)
+	if i := strings.IndexByte(value, ';'); i >= 0 {
+		value = value[:i]
+	}
+
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(value), "."))
+}
+
+// CAAAllowsIssuer reports whether the CA identified by its issuer domain name may issue
+// a certificate for the domain as defined in RFC 8659.
+// If wildcard is true, the check is made for a wildcard certificate.
+func (r *DNSRecords) CAAAllowsIssuer(ca string, wildcard bool) bool {
+	if len(r.CAA) == 0 {
+		return true
+	}
+
+	var issue, issueWild []CAARecord
+
+	for _, record := range r.CAA {
+		tag := strings.ToLower(record.Tag)
+
+		if record.IsCritical() && !knownCAATags[tag] {
+			return false
+		}
+
+		switch tag {
+		case CAATagIssue:
+			issue = append(issue, record)
+		case CAATagIssueWild:
+			issueWild = append(issueWild, record)
+		}
+	}
+
+	relevant := issue
+	if wildcard && len(issueWild) != 0 {
+		relevant = issueWild
+	}
+
+	if len(relevant) == 0 {
+		return true
+	}
+
+	ca = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(ca), "."))
+
+	for _, record := range relevant {
+		if issuer := record.Issuer(); issuer != "" && issuer == ca {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CAAIodef returns URLs from the iodef properties where CAs report invalid certificate requests.
+func (r *DNSRecords) CAAIodef() []string {
+	var urls []string
+
+	for _, record := range r.CAA {
+		if strings.EqualFold(record.Tag, CAATagIodef) {
+			urls = append(urls, strings.Trim(strings.TrimSpace(record.Value), `"`))
+		}
+	}
+
+	return urls
+}
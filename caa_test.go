@@ -0,0 +1,106 @@
+package dnslookupapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCAAAllowsIssuer tests the CAAAllowsIssuer function.
+func TestCAAAllowsIssuer(t *testing.T) {
+	tests := []struct {
+		name     string
+		records  []CAARecord
+		ca       string
+		wildcard bool
+		want     bool
+	}{
+		{
+			name: "no records",
+			ca:   "letsencrypt.org",
+			want: true,
+		},
+		{
+			name:    "issuer allowed",
+			records: []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}, {Tag: "issue", Value: "digicert.com"}},
+			ca:      "LetsEncrypt.org.",
+			want:    true,
+		},
+		{
+			name:    "issuer with parameters",
+			records: []CAARecord{{Tag: "ISSUE", Value: `"letsencrypt.org; validationmethods=dns-01"`}},
+			ca:      "letsencrypt.org",
+			want:    true,
+		},
+		{
+			name:    "issuer not allowed",
+			records: []CAARecord{{Tag: "issue", Value: "digicert.com"}},
+			ca:      "letsencrypt.org",
+			want:    false,
+		},
+		{
+			name:    "no CA allowed",
+			records: []CAARecord{{Tag: "issue", Value: ";"}},
+			ca:      "letsencrypt.org",
+			want:    false,
+		},
+		{
+			name:    "iodef only",
+			records: []CAARecord{{Tag: "iodef", Value: "mailto:security@example.com"}},
+			ca:      "letsencrypt.org",
+			want:    true,
+		},
+		{
+			name:     "wildcard uses issuewild",
+			records:  []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}, {Tag: "issuewild", Value: "digicert.com"}},
+			ca:       "letsencrypt.org",
+			wildcard: true,
+			want:     false,
+		},
+		{
+			name:     "wildcard falls back to issue",
+			records:  []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}},
+			ca:       "letsencrypt.org",
+			wildcard: true,
+			want:     true,
+		},
+		{
+			name:    "issuewild is ignored for non-wildcard",
+			records: []CAARecord{{Tag: "issuewild", Value: ";"}},
+			ca:      "letsencrypt.org",
+			want:    true,
+		},
+		{
+			name:    "unknown critical tag",
+			records: []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}, {Flags: 128, Tag: "tbs", Value: "x"}},
+			ca:      "letsencrypt.org",
+			want:    false,
+		},
+		{
+			name:    "unknown non-critical tag",
+			records: []CAARecord{{Tag: "issue", Value: "letsencrypt.org"}, {Tag: "tbs", Value: "x"}},
+			ca:      "letsencrypt.org",
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &DNSRecords{CAA: tt.records}
+			if got := r.CAAAllowsIssuer(tt.ca, tt.wildcard); got != tt.want {
+				t.Errorf("CAAAllowsIssuer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCAAIodef tests the CAAIodef function.
+func TestCAAIodef(t *testing.T) {
+	r := &DNSRecords{CAA: []CAARecord{
+		{Tag: "issue", Value: "letsencrypt.org"},
+		{Tag: "iodef", Value: `"mailto:security@example.com"`},
+	}}
+
+	want := []string{"mailto:security@example.com"}
+	if got := r.CAAIodef(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CAAIodef() = %v, want %v", got, want)
+	}
+}
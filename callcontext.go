@@ -0,0 +1,101 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"sort"
+)
+
+// RequestIDHeader is the header the request ID set by WithRequestID is sent in.
+const RequestIDHeader = "X-Request-ID"
+
+// Priority is the caller-defined priority of the call. It's reported in LogEvent.
+type Priority string
+
+// Common priorities.
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+)
+
+// requestIDKey is the context key of the request ID.
+type requestIDKey struct{}
+
+// priorityKey is the context key of the call priority.
+type priorityKey struct{}
+
+// tagsKey is the context key of the call tags.
+type tagsKey struct{}
+
+// WithRequestID returns the context making requests carry the ID in RequestIDHeader.
+// The ID is also reported in LogEvent, so API calls can be correlated with the caller's own requests.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set by WithRequestID or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithPriority returns the context tagging the calls with the priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority set by WithPriority or an empty string.
+func PriorityFromContext(ctx context.Context) Priority {
+	priority, _ := ctx.Value(priorityKey{}).(Priority)
+	return priority
+}
+
+// WithTag returns the context tagging the calls with the key and value, e.g. the tenant ID.
+// Tags are added to the tags of the parent context and reported in LogEvent.
+func WithTag(ctx context.Context, key, value string) context.Context {
+	parent := TagsFromContext(ctx)
+
+	tags := make(map[string]string, len(parent)+1)
+	for k, v := range parent {
+		tags[k] = v
+	}
+
+	tags[key] = value
+
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+// TagsFromContext returns the tags set by WithTag or nil. The returned map must not be modified.
+func TagsFromContext(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
+
+// setCallHeaders sets the correlation headers of the request context.
+func setCallHeaders(req *http.Request) {
+	if id := RequestIDFromContext(req.Context()); id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
+
+// withCallContext returns the event with the per-call attribution of the context.
+func withCallContext(ctx context.Context, event LogEvent) LogEvent {
+	event.RequestID = RequestIDFromContext(ctx)
+	event.Priority = PriorityFromContext(ctx)
+	event.Tags = TagsFromContext(ctx)
+
+	return event
+}
+
+// sortedTags returns the keys of the tags in ascending order.
+func sortedTags(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,72 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestCallContext tests per-call attribution read from the context.
+func TestCallContext(t *testing.T) {
+	requestIDs := make(chan string, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requestIDs <- req.Header.Get(RequestIDHeader)
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	recorder := &eventRecorder{}
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_key", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		Logger:           recorder,
+	})
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	ctx = WithPriority(ctx, PriorityHigh)
+	ctx = WithTag(WithTag(ctx, "tenant", "acme"), "team", "mail")
+
+	if _, _, err := client.Get(ctx, "whoisxmlapi.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if id := <-requestIDs; id != "req-42" {
+		t.Errorf("%s = %q, want req-42", RequestIDHeader, id)
+	}
+
+	wantTags := map[string]string{"tenant": "acme", "team": "mail"}
+
+	for _, event := range recorder.events {
+		if event.RequestID != "req-42" || event.Priority != PriorityHigh || !reflect.DeepEqual(event.Tags, wantTags) {
+			t.Errorf("event = %+v", event)
+		}
+	}
+
+	line := recorder.events[0].String()
+	if !strings.Contains(line, `request_id="req-42" priority=high tag.team="mail" tag.tenant="acme"`) {
+		t.Errorf("String() = %s", line)
+	}
+
+	if _, _, err := client.Get(context.Background(), "whoisxmlapi.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if id := <-requestIDs; id != "" {
+		t.Errorf("%s = %q, want none", RequestIDHeader, id)
+	}
+
+	if tags := TagsFromContext(WithTag(ctx, "tenant", "other")); tags["tenant"] != "other" || wantTags["tenant"] != "acme" {
+		t.Errorf("WithTag() override = %v", tags)
+	}
+
+	if tags := TagsFromContext(ctx); tags["tenant"] != "acme" {
+		t.Errorf("parent tags modified: %v", tags)
+	}
+}
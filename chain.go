@@ -0,0 +1,200 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// DefaultMaxChainLength is the maximum number of CNAME and DNAME links ResolveChain follows by default.
+const DefaultMaxChainLength = 16
+
+var (
+	// ErrChainLoop is returned by ResolveChain when the chain leads back to a name already in it.
+	ErrChainLoop = errors.New("CNAME chain loop")
+
+	// ErrChainTooLong is returned by ResolveChain when the chain exceeds the maximum length.
+	ErrChainTooLong = errors.New("CNAME chain too long")
+)
+
+// ChainLink is a single alias in the chain.
+type ChainLink struct {
+	// Name is the aliased name.
+	Name string
+
+	// DNSType is "CNAME" or "DNAME".
+	DNSType string
+
+	// Owner is the owner name of the record. It differs from Name for DNAME records only.
+	Owner string
+
+	// Target is the name the alias leads to.
+	Target string
+}
+
+// Chain is the result of ResolveChain.
+type Chain struct {
+	// Name is the normalized requested name.
+	Name string
+
+	// Links are the aliases followed in order.
+	Links []ChainLink
+
+	// Canonical is the final name of the chain. It's equal to Name if the name is not an alias.
+	Canonical string
+
+	// A and AAAA are the address records of the canonical name.
+	A    []ARecord
+	AAAA []AAAARecord
+
+	// Lookups is the number of API lookups made.
+	Lookups int
+}
+
+// Addresses returns the addresses of the canonical name, IPv4 first.
+func (c *Chain) Addresses() []string {
+	addresses := make([]string, 0, len(c.A)+len(c.AAAA))
+
+	for _, a := range c.A {
+		addresses = append(addresses, a.Address)
+	}
+
+	for _, aaaa := range c.AAAA {
+		addresses = append(addresses, aaaa.Address)
+	}
+
+	return addresses
+}
+
+// ResolveChain follows the CNAME and DNAME records of the name up to the canonical name and returns its
+// address records with the full chain. Records of the names already looked up are reused, so names
+// are looked up again only when the chain leaves the data already returned, e.g. for out-of-zone targets.
+// If maxLength is zero, DefaultMaxChainLength is used. On ErrChainLoop and ErrChainTooLong
+// the partial chain is returned with the error.
+func ResolveChain(
+	ctx context.Context,
+	service DNSLookupService,
+	domainName string,
+	maxLength int,
+	opts ...Option,
+) (*Chain, error) {
+	if err := validateDomainName(domainName); err != nil {
+		return nil, err
+	}
+
+	if maxLength <= 0 {
+		maxLength = DefaultMaxChainLength
+	}
+
+	name := NormalizeName(domainName)
+	chain := &Chain{Name: name, Canonical: name}
+
+	var records []*DNSRecords
+
+	looked := make(map[string]bool)
+	visited := map[string]bool{name: true}
+
+	lookupOpts := append(append([]Option(nil), opts...), OptionType("A,AAAA,CNAME,DNAME"))
+
+	for {
+		link, found := findAlias(records, chain.Canonical)
+
+		if !found && !hasAddresses(records, chain.Canonical) && !looked[chain.Canonical] {
+			resp, _, err := service.Get(ctx, chain.Canonical, lookupOpts...)
+
+			chain.Lookups++
+			looked[chain.Canonical] = true
+
+			if err != nil {
+				return chain, err
+			}
+
+			records = append(records, resp.ParsedRecords())
+
+			continue
+		}
+
+		if !found {
+			break
+		}
+
+		if len(chain.Links) >= maxLength {
+			return chain, ErrChainTooLong
+		}
+
+		chain.Links = append(chain.Links, link)
+		chain.Canonical = link.Target
+
+		if visited[link.Target] {
+			return chain, ErrChainLoop
+		}
+
+		visited[link.Target] = true
+	}
+
+	for _, r := range records {
+		for _, a := range r.A {
+			if a.Owner() == chain.Canonical {
+				chain.A = append(chain.A, a)
+			}
+		}
+
+		for _, aaaa := range r.AAAA {
+			if aaaa.Owner() == chain.Canonical {
+				chain.AAAA = append(chain.AAAA, aaaa)
+			}
+		}
+	}
+
+	return chain, nil
+}
+
+// findAlias returns the CNAME of the name or the DNAME of its closest ancestor in the records.
+func findAlias(records []*DNSRecords, name string) (ChainLink, bool) {
+	for _, r := range records {
+		for _, cname := range r.CNAME {
+			if cname.Owner() == name {
+				return ChainLink{Name: name, DNSType: "CNAME", Owner: name, Target: NormalizeName(cname.Target)}, true
+			}
+		}
+	}
+
+	var (
+		best  ChainLink
+		found bool
+	)
+
+	for _, r := range records {
+		for _, dname := range r.DNAME {
+			owner := dname.Owner()
+			if !strings.HasSuffix(name, "."+owner) || found && len(owner) <= len(best.Owner) {
+				continue
+			}
+
+			// DNAME substitutes the owner suffix of the names below it, but not the owner itself
+			target := strings.TrimSuffix(name, owner) + NormalizeName(dname.Target)
+			best, found = ChainLink{Name: name, DNSType: "DNAME", Owner: owner, Target: target}, true
+		}
+	}
+
+	return best, found
+}
+
+// hasAddresses reports whether the records have A or AAAA records of the name.
+func hasAddresses(records []*DNSRecords, name string) bool {
+	for _, r := range records {
+		for _, a := range r.A {
+			if a.Owner() == name {
+				return true
+			}
+		}
+
+		for _, aaaa := range r.AAAA {
+			if aaaa.Owner() == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestResolveChain tests following of CNAME and DNAME chains.
+func TestResolveChain(t *testing.T) {
+	service := &hostService{records: map[string]string{
+		"www.example.com": `[
+			{"type":5,"dnsType":"CNAME","name":"www.example.com.","target":"web.example.com."},
+			{"type":5,"dnsType":"CNAME","name":"web.example.com.","target":"example.cdn.net."}
+		]`,
+		"example.cdn.net": `[
+			{"type":1,"dnsType":"A","name":"example.cdn.net.","address":"192.0.2.1"},
+			{"type":28,"dnsType":"AAAA","name":"example.cdn.net.","address":"2001:db8::1"}
+		]`,
+		"a.old.example.org": `[
+			{"type":39,"dnsType":"DNAME","name":"old.example.org.","target":"new.example.org."}
+		]`,
+		"a.new.example.org":    `[{"type":1,"dnsType":"A","name":"a.new.example.org.","address":"192.0.2.2"}]`,
+		"apex.example.com":     `[{"type":1,"dnsType":"A","name":"apex.example.com.","address":"192.0.2.3"}]`,
+		"loop1.example.com":    `[{"type":5,"dnsType":"CNAME","name":"loop1.example.com.","target":"loop2.example.com."}]`,
+		"loop2.example.com":    `[{"type":5,"dnsType":"CNAME","name":"loop2.example.com.","target":"loop1.example.com."}]`,
+		"long.example.com":     `[{"type":5,"dnsType":"CNAME","name":"long.example.com.","target":"www.example.com."}]`,
+		"dangling.example.com": `[{"type":5,"dnsType":"CNAME","name":"dangling.example.com.","target":"gone.example.net."}]`,
+	}}
+
+	tests := []struct {
+		name      string
+		maxLength int
+		canonical string
+		links     []string
+		addresses []string
+		lookups   int
+		err       error
+	}{
+		{
+			name:      "WWW.example.com.",
+			canonical: "example.cdn.net",
+			links:     []string{"CNAME web.example.com", "CNAME example.cdn.net"},
+			addresses: []string{"192.0.2.1", "2001:db8::1"},
+			lookups:   2,
+		},
+		{
+			name:      "a.old.example.org",
+			canonical: "a.new.example.org",
+			links:     []string{"DNAME a.new.example.org"},
+			addresses: []string{"192.0.2.2"},
+			lookups:   2,
+		},
+		{name: "apex.example.com", canonical: "apex.example.com", addresses: []string{"192.0.2.3"}, lookups: 1},
+		{
+			name:      "loop1.example.com",
+			canonical: "loop1.example.com",
+			links:     []string{"CNAME loop2.example.com", "CNAME loop1.example.com"},
+			lookups:   2,
+			err:       ErrChainLoop,
+		},
+		{
+			name:      "long.example.com",
+			maxLength: 2,
+			canonical: "web.example.com",
+			links:     []string{"CNAME www.example.com", "CNAME web.example.com"},
+			lookups:   2,
+			err:       ErrChainTooLong,
+		},
+		{
+			name:      "dangling.example.com",
+			canonical: "gone.example.net",
+			links:     []string{"CNAME gone.example.net"},
+			lookups:   2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := ResolveChain(context.Background(), service, tt.name, tt.maxLength)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ResolveChain() error = %v, want %v", err, tt.err)
+			}
+
+			var links []string
+			for _, link := range chain.Links {
+				links = append(links, link.DNSType+" "+link.Target)
+			}
+
+			if chain.Canonical != tt.canonical || !reflect.DeepEqual(links, tt.links) || chain.Lookups != tt.lookups {
+				t.Errorf("ResolveChain() = %s via %v in %d lookups", chain.Canonical, links, chain.Lookups)
+			}
+
+			if addresses := chain.Addresses(); len(addresses) != len(tt.addresses) ||
+				len(addresses) != 0 && !reflect.DeepEqual(addresses, tt.addresses) {
+				t.Errorf("Addresses() = %v, want %v", addresses, tt.addresses)
+			}
+		})
+	}
+
+	if _, err := ResolveChain(context.Background(), service, "fail.example.com", 0); err == nil {
+		t.Error("ResolveChain() error = nil for the failed lookup")
+	}
+
+	if _, err := ResolveChain(context.Background(), service, "", 0); err == nil {
+		t.Error("ResolveChain() error = nil for the empty name")
+	}
+}
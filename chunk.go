@@ -0,0 +1,163 @@
+package dnslookupapi
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultTypeChunks are the groups of DNS record types which cover all the types modeled by DNSRecords.
+// They can be used as ClientParams.TypeChunks. The other types are requested by the catch-all chunk.
+var DefaultTypeChunks = [][]string{
+	{"A", "AAAA", "CNAME", "DNAME", "PTR"},
+	{"NS", "SOA", "MX", "TXT", "CAA", "SRV", "NAPTR", "URI"},
+	{"DNSKEY", "DS", "DLV", "NSEC", "NSEC3PARAM", "TLSA", "SSHFP", "SMIMEA", "CERT", "OPENPGPKEY"},
+	{"MD", "MF", "MB", "LOC", "HINFO", "RP", "DHCID", "NSAP", "NULL"},
+}
+
+// typeChunksKey is the context key of the chunking switch.
+type typeChunksKey struct{}
+
+// WithoutTypeChunks returns a copy of ctx making Get request all the types at once
+// even if ClientParams.TypeChunks is set.
+func WithoutTypeChunks(ctx context.Context) context.Context {
+	return context.WithValue(ctx, typeChunksKey{}, true)
+}
+
+// typeChunksDisabled reports whether chunking is disabled for the context.
+func typeChunksDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(typeChunksKey{}).(bool)
+	return disabled
+}
+
+// lookup makes a single lookup or, if the chunking applies to the request, a lookup per chunk of types.
+func (service dnsLookupServiceOp) lookup(
+	ctx context.Context,
+	domainName string,
+	opts ...Option,
+) (*DNSLookupResponse, *Response, error) {
+	chunks := service.client.typeChunks
+	if len(chunks) < 2 || typeChunksDisabled(ctx) || typesOf(opts) != nil {
+		return service.get(ctx, domainName, opts...)
+	}
+
+	if rest := catchAllChunk(chunks); len(rest) != 0 {
+		chunks = append(chunks[:len(chunks):len(chunks)], rest)
+	}
+
+	return service.getChunked(ctx, domainName, chunks, opts)
+}
+
+// metaTypes are the types used in queries only, which never hold records.
+var metaTypes = map[string]bool{
+	"OPT": true, "TKEY": true, "TSIG": true, "IXFR": true, "AXFR": true, "MAILB": true, "MAILA": true, "ANY": true,
+}
+
+// catchAllChunk returns the registered DNS record types not in any of the chunks ordered by the type code,
+// so the records of the types missing from the chunks, e.g. HTTPS or legacy types, are returned too.
+func catchAllChunk(chunks [][]string) []string {
+	covered := make(map[string]bool)
+
+	for _, chunk := range chunks {
+		for _, dnsType := range chunk {
+			covered[strings.ToUpper(strings.TrimSpace(dnsType))] = true
+		}
+	}
+
+	codes := make([]int, 0, len(rrTypeNames))
+	for code, name := range rrTypeNames {
+		if !covered[name] && !metaTypes[name] {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Ints(codes)
+
+	rest := make([]string, 0, len(codes))
+	for _, code := range codes {
+		rest = append(rest, rrTypeNames[code])
+	}
+
+	return rest
+}
+
+// getChunked looks up every chunk of types concurrently and merges the responses in the order of the chunks.
+// DNSTypes of the merged response lists the types of all chunks.
+// The first failed lookup cancels the others and its error is returned. The returned Response is the one
+// of the first chunk.
+func (service dnsLookupServiceOp) getChunked(
+	ctx context.Context,
+	domainName string,
+	chunks [][]string,
+	opts []Option,
+) (*DNSLookupResponse, *Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type chunkResult struct {
+		dnsLookupResponse *DNSLookupResponse
+		resp              *Response
+		err               error
+	}
+
+	results := make([]chunkResult, len(chunks))
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr = -1
+	)
+
+	for i, chunk := range chunks {
+		chunkOpts := make([]Option, 0, len(opts)+1)
+		chunkOpts = append(chunkOpts, opts...)
+		chunkOpts = append(chunkOpts, OptionType(strings.Join(chunk, ",")))
+
+		wg.Add(1)
+
+		go func(i int, chunkOpts []Option) {
+			defer wg.Done()
+
+			r := &results[i]
+			if r.dnsLookupResponse, r.resp, r.err = service.get(ctx, domainName, chunkOpts...); r.err != nil {
+				once.Do(func() {
+					firstErr = i
+					cancel()
+				})
+			}
+		}(i, chunkOpts)
+	}
+
+	wg.Wait()
+
+	if firstErr >= 0 {
+		return nil, results[firstErr].resp, results[firstErr].err
+	}
+
+	merged := *results[0].dnsLookupResponse
+
+	var (
+		records = &merged.DNSRecords
+		types   []int
+		names   []string
+	)
+
+	for i, r := range results {
+		if i > 0 {
+			records = Merge(records, &r.dnsLookupResponse.DNSRecords)
+		}
+
+		types = append(types, r.dnsLookupResponse.Types...)
+
+		if r.dnsLookupResponse.DNSTypes != "" {
+			names = append(names, r.dnsLookupResponse.DNSTypes)
+		}
+	}
+
+	merged.DNSRecords = *records
+	merged.Types = types
+	merged.DNSTypes = strings.Join(names, ",")
+
+	return &merged, results[0].resp, nil
+}
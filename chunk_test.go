@@ -0,0 +1,138 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// chunkServer returns the server answering every type with an A or MX record and recording the requested types.
+func chunkServer(t *testing.T, failType string) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		types []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		typ := req.URL.Query().Get("type")
+
+		mu.Lock()
+		types = append(types, typ)
+		mu.Unlock()
+
+		if failType != "" && strings.Contains(typ, failType) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var records []string
+
+		requested := make(map[string]bool)
+		for _, name := range strings.Split(typ, ",") {
+			requested[name] = true
+		}
+
+		if requested["A"] || typ == "_all" {
+			records = append(records, `{"type":1,"dnsType":"A","name":"example.com.","ttl":300,`+
+				`"rawText":"example.com. 300 IN A 192.0.2.1","address":"192.0.2.1"}`)
+		}
+
+		if requested["MX"] || typ == "_all" {
+			records = append(records, `{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,`+
+				`"rawText":"example.com. 300 IN MX 10 mx.example.com.","target":"mx.example.com.","priority":10}`)
+		}
+
+		if requested["HTTPS"] || typ == "_all" {
+			records = append(records, `{"type":65,"dnsType":"HTTPS","name":"example.com.","ttl":300,`+
+				`"rawText":"example.com. 300 IN HTTPS 1 . alpn=h2"}`)
+		}
+
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsTypes":"` + typ +
+			`","dnsRecords":[` + strings.Join(records, ",") + `]}}`))
+	}))
+
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sorted := append([]string(nil), types...)
+		sort.Strings(sorted)
+
+		return sorted
+	}
+}
+
+// TestTypeChunks tests that lookups of all types are split into the chunks and merged.
+func TestTypeChunks(t *testing.T) {
+	server, requested := chunkServer(t, "")
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		TypeChunks:       [][]string{{"A", "AAAA"}, {"MX", "TXT"}},
+	})
+
+	resp, _, err := client.Get(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	rest := strings.Join(catchAllChunk([][]string{{"A", "AAAA"}, {"MX", "TXT"}}), ",")
+
+	if got := strings.Join(requested(), ";"); got != "A,AAAA;MX,TXT;"+rest {
+		t.Errorf("Get() requested types %q", got)
+	}
+
+	if !strings.HasPrefix(rest, "NS,MD,") || !strings.Contains(rest, ",HTTPS,") ||
+		strings.Contains(rest, "ANY") || strings.Contains(rest, ",MX,") {
+		t.Errorf("catchAllChunk() = %q", rest)
+	}
+
+	if len(resp.DNSRecords.All) != 3 || len(resp.DNSRecords.A) != 1 || len(resp.DNSRecords.MX) != 1 ||
+		resp.DNSRecords.All[0].CommonFields.DNSType != "A" || resp.DNSRecords.All[2].CommonFields.DNSType != "HTTPS" {
+		t.Errorf("Get() records = %+v", resp.DNSRecords.All)
+	}
+
+	if resp.DNSTypes != "A,AAAA,MX,TXT,"+rest {
+		t.Errorf("Get() DNSTypes = %q", resp.DNSTypes)
+	}
+
+	// explicit types and the disabled chunking are requested at once
+	if _, _, err = client.Get(context.Background(), "example.com", OptionType("MX")); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if _, _, err = client.Get(WithoutTypeChunks(context.Background()), "example.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got := strings.Join(requested(), ";"); got != "A,AAAA;MX;MX,TXT;"+rest+";_all" {
+		t.Errorf("Get() requested types %q", got)
+	}
+}
+
+// TestTypeChunksError tests that a failed chunk fails the lookup.
+func TestTypeChunksError(t *testing.T) {
+	server, _ := chunkServer(t, "TXT")
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		TypeChunks:       [][]string{{"A", "AAAA"}, {"MX", "TXT"}},
+	})
+
+	if resp, _, err := client.Get(context.Background(), "example.com"); err == nil || resp != nil {
+		t.Errorf("Get() = %v, %v, want error", resp, err)
+	}
+}
@@ -2,11 +2,15 @@ package dnslookupapi
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 const (
@@ -27,8 +31,163 @@ type ClientParams struct {
 
 	// DNSLookupBaseURL is the endpoint for 'DNS Lookup API' service
 	DNSLookupBaseURL *url.URL
+
+	// DNSLookupMirrorURLs are the mirror or regional endpoints tried in order when the previous endpoint
+	// fails or doesn't answer within HedgeDelay. The first successful answer is used
+	DNSLookupMirrorURLs []*url.URL
+
+	// HedgeDelay is the time to wait for an endpoint before sending the request to the next mirror
+	// If it's zero then mirrors are only tried after failures
+	HedgeDelay time.Duration
+
+	// APIKeys are the fallback API keys used when the API key passed to NewClient fails
+	// with an authentication or insufficient credits error
+	APIKeys []string
+
+	// KeyProvider provides the API keys tried in order on authentication or insufficient credits errors
+	// If it's set then the API key passed to NewClient and APIKeys are ignored
+	KeyProvider KeyProvider
+
+	// MaxResponseBytes is the maximum size of the decompressed response body. Get returns TooLargeError
+	// for larger responses, other methods return ErrResponseTooLarge
+	// If it's zero then the size is not limited
+	MaxResponseBytes int64
+
+	// UserAgentSuffix is appended to the User-Agent header, e.g. a product identifier
+	UserAgentSuffix string
+
+	// Headers are added to every request
+	Headers http.Header
+
+	// Timeout is the time limit for requests made by the default client
+	// It's ignored if HTTPClient is set
+	Timeout time.Duration
+
+	// DialTimeout is the time limit for establishing connections by the default client
+	// It's ignored if HTTPClient is set
+	DialTimeout time.Duration
+
+	// ProxyURL is the proxy used by the default client
+	// If it's nil then the proxy is taken from the environment. It's ignored if HTTPClient is set
+	ProxyURL *url.URL
+
+	// TLSConfig is the TLS configuration used by the default client
+	// It's ignored if HTTPClient is set
+	TLSConfig *tls.Config
+
+	// MaxRedirects is the maximum number of redirects followed by the client
+	// If it's zero then up to 10 redirects are followed, if it's negative then redirects are not followed
+	MaxRedirects int
+
+	// AllowCrossHostRedirects allows following redirects to hosts other than the API endpoint
+	AllowCrossHostRedirects bool
+
+	// StrictParsing makes Get return ParseErrors if any of the records failed to parse
+	StrictParsing bool
+
+	// PartialParsing makes Get return the parsed response along with PartialParseError if some of the records
+	// or response fields failed to parse, instead of ignoring the failed records or failing the whole response
+	// StrictParsing takes precedence over it
+	PartialParsing bool
+
+	// LazyRecords makes Get decode only the common fields of the records. DNSRecords.All of the response is set
+	// and the typed records are decoded on first access through DNSLookupResponse.LazyRecords or ParsedRecords.
+	// Callers reading All or a few types skip most of the decoding cost of large responses
+	// It's ignored if StrictParsing, PartialParsing or decode hooks are used, as they need the typed records
+	LazyRecords bool
+
+	// ValidateSchema makes Get return SchemaError if the response has fields or record types
+	// unknown to the library. It's intended to detect API schema drift
+	ValidateSchema bool
+
+	// ValidateJSONSchema makes Get validate the response against the embedded API JSON Schema
+	// and return SchemaError with Violations if it deviates, see ValidateResponse
+	ValidateJSONSchema bool
+
+	// DecodeHooks are applied in order to every response parsed by Get
+	// They can be used to normalize names, drop record types or compute additional fields
+	DecodeHooks []DecodeHook
+
+	// Accounting accumulates per-type usage of the records parsed by Get
+	// If it's nil then usage is not accounted
+	Accounting *Accounting
+
+	// CircuitBreaker makes requests fail fast with ErrCircuitOpen after consecutive failures
+	// If it's nil then requests are always sent
+	CircuitBreaker *CircuitBreaker
+
+	// Logger receives request and response lifecycle events with the API key redacted
+	// If it's nil then nothing is logged
+	Logger Logger
+
+	// PostForm makes the client send the API parameters as a form-encoded POST body instead of
+	// the query string, e.g. for gateways restricting the URL length
+	PostForm bool
+
+	// LookupTimeout caps the total time of a lookup including the retries with other API keys and mirrors,
+	// separately from the caller's context. Lookups exceeding it fail with TimeoutExceeded
+	// If it's zero then only the caller's context limits the lookup
+	LookupTimeout time.Duration
+
+	// RegistrableDomain makes the client query the registrable domain of the requested host name,
+	// e.g. "example.co.uk" for "app.eu.example.co.uk", using the Public Suffix List of golang.org/x/net/publicsuffix
+	// The requested and queried names are reported in DNSLookupResponse
+	RegistrableDomain bool
+
+	// TypeChunks splits the lookups of all DNS record types into concurrent lookups of the groups of types,
+	// merging the responses, which keeps the responses for large zones manageable, e.g. DefaultTypeChunks
+	// Records of the registered types not in any group are requested by a final catch-all chunk, records of
+	// unregistered types, e.g. private use ones, are requested only by lookups without chunks
+	// WithoutTypeChunks disables it per call
+	// If it's empty then all the types are requested at once
+	TypeChunks [][]string
+
+	// Retry repeats the requests failed with transport errors, 5xx and 429 responses
+	// If it's nil then the requests are not repeated
+	Retry *RetryPolicy
+
+	// MaxRecords is the maximum number of DNS records in the response. Get returns TooLargeError
+	// without decoding the records of larger responses
+	// If it's zero then the number of records is not limited
+	MaxRecords int
+
+	// TTLCache caches the results of the typed getters until the smallest TTL of the records elapses
+	// If it's nil then the typed getters always query the API
+	TTLCache *TTLCache
+
+	// Scheduler limits the concurrent lookups and serves the waiting ones fairly across tenants and priorities
+	// If it's nil then the lookups are not limited
+	Scheduler *FairScheduler
+
+	// Dispatcher runs the lookups of GetAsync with limited concurrency, retries and rate limit pauses
+	// If it's nil then every client uses its own dispatcher with the default settings
+	Dispatcher *Dispatcher
+
+	// AdaptiveConcurrency limits the concurrent requests and tunes the limit by the throttling and latency
+	// of the API responses
+	// If it's nil then the requests are not limited
+	AdaptiveConcurrency *AdaptiveConcurrency
+
+	// VerifyIntegrity makes Get return MismatchError if the domain name echoed in the response
+	// doesn't match the requested one or the response has records of types which were not requested
+	VerifyIntegrity bool
+
+	// KeepPartialResponse attaches the response decoded up to MaxRecords or MaxResponseBytes to TooLargeError
+	KeepPartialResponse bool
+
+	// MaxDataAge is the maximum age of the data reported in Audit. Get handles older data according to StalePolicy
+	// If it's zero then the age is not checked
+	MaxDataAge time.Duration
+
+	// StalePolicy is the action taken by Get when the data is older than MaxDataAge
+	// By default StaleDataError is returned
+	StalePolicy StalePolicy
 }
 
+// DecodeHook is a function applied to every response parsed by Get.
+// The returned error aborts the Get call.
+type DecodeHook func(*DNSLookupResponse) error
+
 // NewBasicClient creates Client with recommended parameters.
 func NewBasicClient(apiKey string) *Client {
 	return NewClient(apiKey, ClientParams{})
@@ -46,28 +205,138 @@ func NewClient(apiKey string, params ClientParams) *Client {
 		}
 	}
 
-	httpClient := http.DefaultClient
+	httpClient := defaultHTTPClient(params)
 	if params.HTTPClient != nil {
-		httpClient = params.HTTPClient
+		httpClient = *params.HTTPClient
+	}
+
+	httpClient.CheckRedirect = newRedirectPolicy(params, httpClient.CheckRedirect).checkRedirect
+
+	ua := userAgent
+	if params.UserAgentSuffix != "" {
+		ua += " " + params.UserAgentSuffix
+	}
+
+	keys := params.KeyProvider
+	if keys == nil {
+		keys = append(StaticKeys{apiKey}, params.APIKeys...)
 	}
 
 	client := &Client{
-		client:    httpClient,
-		userAgent: userAgent,
-		apiKey:    apiKey,
+		client:    &httpClient,
+		redirects: newRedirectPolicy(params, nil),
+		userAgent: ua,
+		headers:   params.Headers.Clone(),
+		keys:      &keyRing{provider: keys},
+
+		maxResponseBytes: params.MaxResponseBytes,
+
+		strictParsing:  params.StrictParsing,
+		partialParsing: params.PartialParsing,
+		lazyRecords:    params.LazyRecords,
+		validateSchema: params.ValidateSchema,
+		validateJSON:   params.ValidateJSONSchema,
+		decodeHooks:    append([]DecodeHook(nil), params.DecodeHooks...),
+		accounting:     params.Accounting,
+		logger:         params.Logger,
+		breaker:        params.CircuitBreaker,
+		retry:          params.Retry,
+		lookupTimeout:  params.LookupTimeout,
+		postForm:       params.PostForm,
+		maxDataAge:     params.MaxDataAge,
+		stalePolicy:    params.StalePolicy,
+
+		registrableDomain: params.RegistrableDomain,
+		typeChunks:        params.TypeChunks,
+		maxRecords:        params.MaxRecords,
+		keepPartial:       params.KeepPartialResponse,
+		verifyIntegrity:   params.VerifyIntegrity,
+		ttlCache:          params.TTLCache,
+		scheduler:         params.Scheduler,
+		dispatcher:        params.Dispatcher,
+		adaptive:          params.AdaptiveConcurrency,
 	}
 
-	client.DNSLookupService = &dnsLookupServiceOp{client: client, baseURL: apiBaseURL}
+	if client.dispatcher == nil {
+		client.dispatcher = &Dispatcher{}
+	}
+
+	client.DNSLookupService = &dnsLookupServiceOp{
+		client:     client,
+		baseURL:    apiBaseURL,
+		mirrors:    append([]*url.URL(nil), params.DNSLookupMirrorURLs...),
+		hedgeDelay: params.HedgeDelay,
+	}
 
 	return client
 }
 
+// defaultHTTPClient creates the http.Client from the transport settings of the parameters.
+// It returns http.DefaultClient if none of the settings is specified.
+func defaultHTTPClient(params ClientParams) http.Client {
+	if params.Timeout == 0 && params.DialTimeout == 0 && params.ProxyURL == nil && params.TLSConfig == nil {
+		return *http.DefaultClient
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if params.DialTimeout != 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   params.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+	}
+
+	if params.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(params.ProxyURL)
+	}
+
+	if params.TLSConfig != nil {
+		transport.TLSClientConfig = params.TLSConfig
+	}
+
+	return http.Client{
+		Transport: transport,
+		Timeout:   params.Timeout,
+	}
+}
+
 // Client is the client for DNS Lookup API services.
 type Client struct {
-	client *http.Client
+	client    *http.Client
+	redirects redirectPolicy
 
 	userAgent string
-	apiKey    string
+	headers   http.Header
+	keys      *keyRing
+
+	maxResponseBytes int64
+
+	strictParsing  bool
+	partialParsing bool
+	lazyRecords    bool
+	validateSchema bool
+	validateJSON   bool
+	decodeHooks    []DecodeHook
+	accounting     *Accounting
+	logger         Logger
+	breaker        *CircuitBreaker
+	retry          *RetryPolicy
+	lookupTimeout  time.Duration
+	postForm       bool
+	maxDataAge     time.Duration
+	stalePolicy    StalePolicy
+	now            func() time.Time
+
+	registrableDomain bool
+	typeChunks        [][]string
+	maxRecords        int
+	keepPartial       bool
+	verifyIntegrity   bool
+	ttlCache          *TTLCache
+	scheduler         *FairScheduler
+	dispatcher        *Dispatcher
+	adaptive          *AdaptiveConcurrency
 
 	// DNSLookupService is an interface for DNS Lookup API
 	DNSLookupService
@@ -86,16 +355,49 @@ func (c *Client) NewRequest(method string, u *url.URL, body io.Reader) (*http.Re
 
 	req.Header.Add("Content-Type", mediaType)
 	req.Header.Add("Accept", mediaType)
+	req.Header.Add("Accept-Encoding", acceptEncoding)
 	req.Header.Add("User-Agent", c.userAgent)
 
+	for name, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
 	return req, nil
 }
 
+// BuildRequest creates the API request exactly as Get and GetRaw would send it, without executing it.
+// Use RedactURL to log the request URL without the API key.
+func (c *Client) BuildRequest(domainName string, opts ...Option) (*http.Request, error) {
+	service, ok := c.DNSLookupService.(*dnsLookupServiceOp)
+	if !ok {
+		return nil, errors.New("cannot build request: DNSLookupService is not the default implementation")
+	}
+
+	return service.buildRequest(c.keys.first(), domainName, opts...)
+}
+
+// RedactURL returns the URL as a string with the API key replaced by "REDACTED".
+// The parameters of POST requests made with ClientParams.PostForm are not a part of the URL.
+func RedactURL(u *url.URL) string {
+	q := u.Query()
+	if q.Get("apiKey") == "" {
+		return u.String()
+	}
+
+	redacted := *u
+	q.Set("apiKey", "REDACTED")
+	redacted.RawQuery = q.Encode()
+
+	return redacted.String()
+}
+
 // Do sends the API request and returns the API response.
 func (c *Client) Do(ctx context.Context, req *http.Request, v io.Writer) (response *http.Response, err error) {
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, fmt.Errorf("cannot execute request: %w", err)
 	}
@@ -106,7 +408,12 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v io.Writer) (respon
 		}
 	}()
 
-	_, err = io.Copy(v, resp.Body)
+	body, err := decodeBody(resp)
+	if err != nil {
+		return resp, fmt.Errorf("cannot decompress response: %w", err)
+	}
+
+	err = copyBody(v, body, c.maxResponseBytes)
 	if err != nil {
 		return resp, fmt.Errorf("cannot read response: %w", err)
 	}
@@ -114,10 +421,58 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v io.Writer) (respon
 	return resp, err
 }
 
+// send sends the request through the circuit breaker if it's set.
+// Correlation headers of the request context are set.
+// The API key is redacted from the URL of the returned transport error.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	httpClient := c.httpClient(req.Context())
+
+	setCallHeaders(req)
+
+	if c.breaker == nil {
+		resp, err := httpClient.Do(req)
+		return resp, redactURLError(err)
+	}
+
+	trial, err := c.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	c.breaker.done(req.Context(), trial, resp, err)
+
+	return resp, redactURLError(err)
+}
+
+// redactURLError replaces the URL of the *url.Error returned by http.Client with its redacted form.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	if u, perr := url.Parse(urlErr.URL); perr == nil {
+		urlErr.URL = RedactURL(u)
+	} else {
+		urlErr.URL = "REDACTED"
+	}
+
+	return err
+}
+
 // ErrorResponse is returned when the response status code is not 2xx.
 type ErrorResponse struct {
 	Response *http.Response
-	Message  string
+
+	// Code is the error code parsed from the response body
+	Code string
+
+	// Message is the error message parsed from the JSON, XML or plain text response body
+	Message string
+
+	// Body is the response body with collapsed whitespace, truncated to 512 bytes
+	Body string
 }
 
 // Error returns error message as a string.
@@ -130,7 +485,7 @@ func (e *ErrorResponse) Error() string {
 }
 
 // checkResponse checks if the response status code is not 2xx.
-func checkResponse(r *http.Response) error {
+func checkResponse(r *http.Response, body []byte) error {
 	if c := r.StatusCode; c >= 200 && c <= 299 {
 		return nil
 	}
@@ -139,5 +494,14 @@ func checkResponse(r *http.Response) error {
 		Response: r,
 	}
 
+	errorResponse.Code, errorResponse.Message = parseErrorBody(body)
+	errorResponse.Body = errorBodyExcerpt(body)
+
 	return &errorResponse
 }
+
+// lazy reports whether the records of the lookups made with the context are decoded lazily.
+func (c *Client) lazy(ctx context.Context) bool {
+	return c.lazyRecords && !c.strictParsing && !c.partialParsing &&
+		len(c.decodeHooks) == 0 && len(decodeHooksFromContext(ctx)) == 0
+}
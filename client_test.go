@@ -2,11 +2,17 @@ package dnslookupapi
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/whois-api-llc/dns-lookup-go/fixtures"
 )
 
 const (
@@ -79,23 +85,7 @@ func TestDNSLookupGet(t *testing.T) {
 
 	ctx := context.Background()
 
-	const resp = ` {"DNSData": {
-  "domainName": "whoisxmlapi.com",
-  "types": [1],
-  "dnsTypes": "A",
-  "audit": {"createdDate": "2022-07-12 11:46:25 UTC","updatedDate": "2022-07-12 11:46:25 UTC"},
-  "dnsRecords": [
-    {
-      "type": 1,
-      "dnsType": "A",
-      "name": "whoisxmlapi.com.",
-      "ttl": 300,
-      "rRsetType": 1,
-      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009A\u0009104.26.13.210",
-      "address": "104.26.13.210"
-    }
-]
-}}`
+	resp := string(fixtures.Response("A"))
 
 	const respUnparsable = `<?xml version="1.0" encoding="utf-8"?><>`
 
@@ -134,7 +124,7 @@ func TestDNSLookupGet(t *testing.T) {
 				options: "whoisxmlapi.com",
 			},
 			want:    false,
-			wantErr: "cannot parse response: invalid character '<' looking for beginning of value",
+			wantErr: "API failed with status code: 500",
 		},
 		{
 			name: "partial response 1",
@@ -164,7 +154,7 @@ func TestDNSLookupGet(t *testing.T) {
 				options: "whoisxmlapi.com",
 			},
 			want:    false,
-			wantErr: "API error: [TEST_CODE] test error message",
+			wantErr: "API failed with status code: 499 (test error message)",
 		},
 		{
 			name: "unparsable response",
@@ -201,6 +191,59 @@ func TestDNSLookupGet(t *testing.T) {
 	}
 }
 
+// TestDNSLookupGetErrorStatus tests that Get returns the known errors for non-2xx status codes.
+func TestDNSLookupGetErrorStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{
+			name:   "unauthorized",
+			status: http.StatusUnauthorized,
+			body:   `{"code":401,"messages":"Authentication failed"}`,
+			want:   ErrAuthentication,
+		},
+		{
+			name:   "no credits",
+			status: http.StatusForbidden,
+			body:   `{"code":403,"messages":"Access restricted. Check credits balance or enter the correct API key."}`,
+			want:   ErrInsufficientCredits,
+		},
+		{
+			name:   "throttled",
+			status: http.StatusTooManyRequests,
+			body:   `{"code":429,"messages":"Too many requests"}`,
+			want:   ErrThrottled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			got, _, err := newAPI(server, "/").Get(context.Background(), "whoisxmlapi.com")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("DNSLookup.Get() error = %v, want %v", err, tt.want)
+			}
+
+			var errorResponse *ErrorResponse
+			if !errors.As(err, &errorResponse) || errorResponse.Response.StatusCode != tt.status {
+				t.Errorf("DNSLookup.Get() error = %#v, want ErrorResponse with status %d", err, tt.status)
+			}
+
+			if got != nil {
+				t.Errorf("DNSLookup.Get() got = %v, expected nil", got)
+			}
+		})
+	}
+}
+
 // TestDNSLookupGetRaw tests the GetRaw function.
 func TestDNSLookupGetRaw(t *testing.T) {
 	checkResultRaw := func(res []byte) bool {
@@ -209,23 +252,7 @@ func TestDNSLookupGetRaw(t *testing.T) {
 
 	ctx := context.Background()
 
-	const resp = ` {"DNSData": {
-  "domainName": "whoisxmlapi.com",
-  "types": [1],
-  "dnsTypes": "A",
-  "audit": {"createdDate": "2022-07-12 11:46:25 UTC","updatedDate": "2022-07-12 11:46:25 UTC"},
-  "dnsRecords": [
-    {
-      "type": 1,
-      "dnsType": "A",
-      "name": "whoisxmlapi.com.",
-      "ttl": 300,
-      "rRsetType": 1,
-      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009A\u0009104.26.13.210",
-      "address": "104.26.13.210"
-    }
-]
-}}`
+	resp := string(fixtures.Response("A"))
 
 	const respUnparsable = `<?xml version="1.0" encoding="utf-8"?><>`
 
@@ -297,7 +324,7 @@ func TestDNSLookupGetRaw(t *testing.T) {
 				ctx:     ctx,
 				options: "whoisxmlapi.com",
 			},
-			wantErr: "API failed with status code: 499",
+			wantErr: "API failed with status code: 499 (test error message)",
 		},
 	}
 	for _, tt := range tests {
@@ -317,3 +344,214 @@ func TestDNSLookupGetRaw(t *testing.T) {
 		})
 	}
 }
+
+// TestDNSLookupGetDecodeHooks tests that decode hooks are applied by the Get function.
+func TestDNSLookupGetDecodeHooks(t *testing.T) {
+	const resp = `{"DNSData":{"domainName":"WhoisXMLAPI.com","dnsRecords":[]}}`
+
+	server := dummyServer(resp, resp, resp)
+	defer server.Close()
+
+	apiURL, err := url.Parse(server.URL + pathDNSLookupResponseOK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lower := func(r *DNSLookupResponse) error {
+		r.DomainName = strings.ToLower(r.DomainName)
+		return nil
+	}
+
+	fail := func(r *DNSLookupResponse) error {
+		return errors.New("rejected " + r.DomainName)
+	}
+
+	tests := []struct {
+		name    string
+		hooks   []DecodeHook
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no hooks",
+			hooks: nil,
+			want:  "WhoisXMLAPI.com",
+		},
+		{
+			name:  "normalize",
+			hooks: []DecodeHook{lower},
+			want:  "whoisxmlapi.com",
+		},
+		{
+			name:    "error",
+			hooks:   []DecodeHook{lower, fail},
+			wantErr: "decode hook failed: rejected whoisxmlapi.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewClient(apiKey, ClientParams{
+				HTTPClient:       server.Client(),
+				DNSLookupBaseURL: apiURL,
+				DecodeHooks:      tt.hooks,
+			})
+
+			got, _, err := api.Get(context.Background(), "whoisxmlapi.com")
+			checkErr(t, err, tt.wantErr)
+
+			if err == nil && got.DomainName != tt.want {
+				t.Errorf("DNSLookup.Get() DomainName = %v, want %v", got.DomainName, tt.want)
+			}
+		})
+	}
+}
+
+// TestBuildRequest tests the BuildRequest and RedactURL functions.
+func TestBuildRequest(t *testing.T) {
+	api := NewBasicClient(apiKey)
+
+	req, err := api.BuildRequest("whoisxmlapi.com", OptionType("a,mx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = "https://www.whoisxmlapi.com/whoisserver/DNSService?" +
+		"apiKey=" + apiKey + "&domainName=whoisxmlapi.com&type=A%2CMX"
+	if got := req.URL.String(); got != want {
+		t.Errorf("BuildRequest() = %v, want %v", got, want)
+	}
+
+	const wantRedacted = "https://www.whoisxmlapi.com/whoisserver/DNSService?" +
+		"apiKey=REDACTED&domainName=whoisxmlapi.com&type=A%2CMX"
+	if got := RedactURL(req.URL); got != wantRedacted {
+		t.Errorf("RedactURL() = %v, want %v", got, wantRedacted)
+	}
+
+	if req.URL.Query().Get("apiKey") != apiKey {
+		t.Error("RedactURL() modified the request URL")
+	}
+}
+
+// TestDefaultHTTPClient tests that transport settings are applied to the default client.
+func TestDefaultHTTPClient(t *testing.T) {
+	if c := defaultHTTPClient(ClientParams{}); c.Transport != nil || c.Timeout != 0 {
+		t.Errorf("defaultHTTPClient() = %+v, want http.DefaultClient", c)
+	}
+
+	proxyURL, err := url.Parse("http://proxy.example.com:3128")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig := &tls.Config{ServerName: "example.com"}
+
+	c := defaultHTTPClient(ClientParams{
+		Timeout:     20 * time.Second,
+		DialTimeout: 5 * time.Second,
+		ProxyURL:    proxyURL,
+		TLSConfig:   tlsConfig,
+	})
+
+	if c.Timeout != 20*time.Second {
+		t.Errorf("Timeout = %v", c.Timeout)
+	}
+
+	transport, ok := c.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T", c.Transport)
+	}
+
+	if transport.TLSClientConfig != tlsConfig || transport.DialContext == nil {
+		t.Errorf("Transport = %+v", transport)
+	}
+
+	got, err := transport.Proxy(httptest.NewRequest(http.MethodGet, "https://example.com", nil))
+	if err != nil || got.String() != proxyURL.String() {
+		t.Errorf("Proxy() = %v, %v", got, err)
+	}
+}
+
+// TestRequestHeaders tests the User-Agent suffix and extra headers.
+func TestRequestHeaders(t *testing.T) {
+	api := NewClient(apiKey, ClientParams{
+		UserAgentSuffix: "acme-scanner/2.1",
+		Headers:         http.Header{"X-Correlation-Id": {"42"}},
+	})
+
+	req, err := api.BuildRequest("whoisxmlapi.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := req.Header.Get("User-Agent"), userAgent+" acme-scanner/2.1"; got != want {
+		t.Errorf("User-Agent = %v, want %v", got, want)
+	}
+
+	if got := req.Header.Get("X-Correlation-Id"); got != "42" {
+		t.Errorf("X-Correlation-Id = %v, want 42", got)
+	}
+}
+
+// TestDNSLookupInvalidDomainName tests that invalid domain names are rejected without requests.
+func TestDNSLookupInvalidDomainName(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+	}))
+	defer server.Close()
+
+	api := newAPI(server, pathDNSLookupResponseOK)
+
+	tests := []struct {
+		domainName string
+		wantErr    string
+	}{
+		{
+			domainName: "",
+			wantErr:    `invalid argument: "domainName" is empty`,
+		},
+		{
+			domainName: "  ",
+			wantErr:    `invalid argument: "domainName" is empty`,
+		},
+		{
+			domainName: "https://whoisxmlapi.com/",
+			wantErr:    `invalid argument: "domainName" must be a domain name, not a URL: "https://whoisxmlapi.com/"`,
+		},
+		{
+			domainName: "whoisxmlapi.com/path",
+			wantErr:    `invalid argument: "domainName" must be a domain name without a port, path or query: "whoisxmlapi.com/path"`,
+		},
+		{
+			domainName: "whoisxmlapi.com:443",
+			wantErr:    `invalid argument: "domainName" must be a domain name without a port, path or query: "whoisxmlapi.com:443"`,
+		},
+		{
+			domainName: "whoisxml api.com",
+			wantErr:    `invalid argument: "domainName" must not contain spaces: "whoisxml api.com"`,
+		},
+		{
+			domainName: strings.Repeat("a", 64) + ".com",
+			wantErr:    `invalid argument: "domainName" has a label longer than 63 characters: "` + strings.Repeat("a", 64) + `"`,
+		},
+	}
+
+	for _, tt := range tests {
+		_, _, err := api.Get(context.Background(), tt.domainName)
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("Get(%q) error = %v, wantErr %v", tt.domainName, err, tt.wantErr)
+		}
+
+		_, err = api.GetRaw(context.Background(), tt.domainName)
+
+		var argErr *ArgError
+		if !errors.As(err, &argErr) || argErr.Name != "domainName" {
+			t.Errorf("GetRaw(%q) error = %v, want ArgError", tt.domainName, err)
+		}
+	}
+
+	if requests != 0 {
+		t.Errorf("%d requests sent, want 0", requests)
+	}
+}
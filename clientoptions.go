@@ -0,0 +1,245 @@
+package dnslookupapi
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClientOption configures the client created by New.
+// The options are named ClientOptionX after the request options named OptionX; the WithX names are taken
+// by the context helpers overriding the client per call, e.g. WithHTTPClient and WithBaseURL.
+type ClientOption func(*ClientParams)
+
+// New creates Client with the options applied in order to empty ClientParams.
+// It's equivalent to NewClient, which remains supported.
+func New(apiKey string, opts ...ClientOption) *Client {
+	var params ClientParams
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	return NewClient(apiKey, params)
+}
+
+// ClientOptionParams replaces all parameters with params, e.g. to start from an existing configuration.
+// The options following it modify the copy.
+func ClientOptionParams(params ClientParams) ClientOption {
+	return func(p *ClientParams) {
+		*p = params
+		p.APIKeys = append([]string(nil), params.APIKeys...)
+		p.DNSLookupMirrorURLs = append([]*url.URL(nil), params.DNSLookupMirrorURLs...)
+		p.DecodeHooks = append([]DecodeHook(nil), params.DecodeHooks...)
+		p.Headers = params.Headers.Clone()
+	}
+}
+
+// ClientOptionHTTPClient sets the client used to access the API endpoint, see ClientParams.HTTPClient.
+func ClientOptionHTTPClient(httpClient *http.Client) ClientOption {
+	return func(p *ClientParams) {
+		p.HTTPClient = httpClient
+	}
+}
+
+// ClientOptionBaseURL sets the API endpoint, see ClientParams.DNSLookupBaseURL.
+func ClientOptionBaseURL(baseURL *url.URL) ClientOption {
+	return func(p *ClientParams) {
+		p.DNSLookupBaseURL = baseURL
+	}
+}
+
+// ClientOptionMirrors adds the mirror endpoints tried after hedgeDelay or failures,
+// see ClientParams.DNSLookupMirrorURLs.
+func ClientOptionMirrors(hedgeDelay time.Duration, mirrors ...*url.URL) ClientOption {
+	return func(p *ClientParams) {
+		p.DNSLookupMirrorURLs = append(p.DNSLookupMirrorURLs, mirrors...)
+		p.HedgeDelay = hedgeDelay
+	}
+}
+
+// ClientOptionAPIKeys adds the fallback API keys, see ClientParams.APIKeys.
+func ClientOptionAPIKeys(keys ...string) ClientOption {
+	return func(p *ClientParams) {
+		p.APIKeys = append(p.APIKeys, keys...)
+	}
+}
+
+// ClientOptionKeyProvider sets the provider of the API keys, see ClientParams.KeyProvider.
+func ClientOptionKeyProvider(provider KeyProvider) ClientOption {
+	return func(p *ClientParams) {
+		p.KeyProvider = provider
+	}
+}
+
+// ClientOptionTimeout sets the time limit for requests made by the default client, see ClientParams.Timeout.
+func ClientOptionTimeout(timeout time.Duration) ClientOption {
+	return func(p *ClientParams) {
+		p.Timeout = timeout
+	}
+}
+
+// ClientOptionLookupTimeout caps the total time of a lookup, see ClientParams.LookupTimeout.
+func ClientOptionLookupTimeout(timeout time.Duration) ClientOption {
+	return func(p *ClientParams) {
+		p.LookupTimeout = timeout
+	}
+}
+
+// ClientOptionProxy sets the proxy used by the default client, see ClientParams.ProxyURL.
+func ClientOptionProxy(proxyURL *url.URL) ClientOption {
+	return func(p *ClientParams) {
+		p.ProxyURL = proxyURL
+	}
+}
+
+// ClientOptionTLSConfig sets the TLS configuration used by the default client, see ClientParams.TLSConfig.
+func ClientOptionTLSConfig(config *tls.Config) ClientOption {
+	return func(p *ClientParams) {
+		p.TLSConfig = config
+	}
+}
+
+// ClientOptionHeader adds the header to every request, see ClientParams.Headers.
+func ClientOptionHeader(name, value string) ClientOption {
+	return func(p *ClientParams) {
+		if p.Headers == nil {
+			p.Headers = http.Header{}
+		}
+
+		p.Headers.Add(name, value)
+	}
+}
+
+// ClientOptionUserAgentSuffix sets the suffix of the User-Agent header, see ClientParams.UserAgentSuffix.
+func ClientOptionUserAgentSuffix(suffix string) ClientOption {
+	return func(p *ClientParams) {
+		p.UserAgentSuffix = suffix
+	}
+}
+
+// ClientOptionLogger sets the logger of the request lifecycle events, see ClientParams.Logger.
+func ClientOptionLogger(logger Logger) ClientOption {
+	return func(p *ClientParams) {
+		p.Logger = logger
+	}
+}
+
+// ClientOptionCircuitBreaker sets the circuit breaker, see ClientParams.CircuitBreaker.
+func ClientOptionCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(p *ClientParams) {
+		p.CircuitBreaker = breaker
+	}
+}
+
+// ClientOptionRetry repeats the requests failed with transient errors, see ClientParams.Retry.
+func ClientOptionRetry(policy *RetryPolicy) ClientOption {
+	return func(p *ClientParams) {
+		p.Retry = policy
+	}
+}
+
+// ClientOptionAccounting sets the usage accounting, see ClientParams.Accounting.
+func ClientOptionAccounting(accounting *Accounting) ClientOption {
+	return func(p *ClientParams) {
+		p.Accounting = accounting
+	}
+}
+
+// ClientOptionDecodeHooks adds the decode hooks, see ClientParams.DecodeHooks.
+func ClientOptionDecodeHooks(hooks ...DecodeHook) ClientOption {
+	return func(p *ClientParams) {
+		p.DecodeHooks = append(p.DecodeHooks, hooks...)
+	}
+}
+
+// ClientOptionStrictParsing makes Get fail if any of the records failed to parse, see ClientParams.StrictParsing.
+func ClientOptionStrictParsing() ClientOption {
+	return func(p *ClientParams) {
+		p.StrictParsing = true
+	}
+}
+
+// ClientOptionLazyRecords makes Get decode the typed records on first access, see ClientParams.LazyRecords.
+func ClientOptionLazyRecords() ClientOption {
+	return func(p *ClientParams) {
+		p.LazyRecords = true
+	}
+}
+
+// ClientOptionPostForm makes the client send the API parameters as a POST body, see ClientParams.PostForm.
+func ClientOptionPostForm() ClientOption {
+	return func(p *ClientParams) {
+		p.PostForm = true
+	}
+}
+
+// ClientOptionLimits sets the maximum size of the response body and the maximum number of records,
+// see ClientParams.MaxResponseBytes and ClientParams.MaxRecords.
+func ClientOptionLimits(maxResponseBytes int64, maxRecords int) ClientOption {
+	return func(p *ClientParams) {
+		p.MaxResponseBytes = maxResponseBytes
+		p.MaxRecords = maxRecords
+	}
+}
+
+// ClientOptionTypeChunks splits the lookups of all types into the chunks, see ClientParams.TypeChunks.
+func ClientOptionTypeChunks(chunks ...[]string) ClientOption {
+	return func(p *ClientParams) {
+		p.TypeChunks = chunks
+	}
+}
+
+// ClientOptionMaxDataAge sets the maximum age of the data and the action taken for older data,
+// see ClientParams.MaxDataAge.
+func ClientOptionMaxDataAge(maxAge time.Duration, policy StalePolicy) ClientOption {
+	return func(p *ClientParams) {
+		p.MaxDataAge = maxAge
+		p.StalePolicy = policy
+	}
+}
+
+// ClientOptionVerifyIntegrity makes Get check the echoed domain name and record types,
+// see ClientParams.VerifyIntegrity.
+func ClientOptionVerifyIntegrity() ClientOption {
+	return func(p *ClientParams) {
+		p.VerifyIntegrity = true
+	}
+}
+
+// ClientOptionCache caches the results of the typed getters by the TTL of the records, see ClientParams.TTLCache.
+func ClientOptionCache(cache *TTLCache) ClientOption {
+	return func(p *ClientParams) {
+		p.TTLCache = cache
+	}
+}
+
+// ClientOptionScheduler limits the concurrent lookups and serves the waiting ones fairly, see ClientParams.Scheduler.
+func ClientOptionScheduler(scheduler *FairScheduler) ClientOption {
+	return func(p *ClientParams) {
+		p.Scheduler = scheduler
+	}
+}
+
+// ClientOptionDispatcher sets the dispatcher running the lookups of GetAsync, see ClientParams.Dispatcher.
+func ClientOptionDispatcher(dispatcher *Dispatcher) ClientOption {
+	return func(p *ClientParams) {
+		p.Dispatcher = dispatcher
+	}
+}
+
+// ClientOptionPartialParsing makes Get return the parsed data along with PartialParseError,
+// see ClientParams.PartialParsing.
+func ClientOptionPartialParsing() ClientOption {
+	return func(p *ClientParams) {
+		p.PartialParsing = true
+	}
+}
+
+// ClientOptionAdaptiveConcurrency limits the concurrent requests by the adaptive controller,
+// see ClientParams.AdaptiveConcurrency.
+func ClientOptionAdaptiveConcurrency(ac *AdaptiveConcurrency) ClientOption {
+	return func(p *ClientParams) {
+		p.AdaptiveConcurrency = ac
+	}
+}
@@ -0,0 +1,80 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNew tests that the options configure the client like the equivalent ClientParams.
+func TestNew(t *testing.T) {
+	var got http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = req.Header.Clone()
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	dispatcher := &Dispatcher{Concurrency: 2}
+
+	client := New(apiKey,
+		ClientOptionHTTPClient(server.Client()),
+		ClientOptionBaseURL(baseURL),
+		ClientOptionAPIKeys("at_2"),
+		ClientOptionHeader("X-Team", "dns"),
+		ClientOptionUserAgentSuffix("job/1"),
+		ClientOptionLimits(1<<20, 1000),
+		ClientOptionMaxDataAge(time.Hour, StaleIgnore),
+		ClientOptionRetry(&RetryPolicy{MaxRetries: 2}),
+		ClientOptionLazyRecords(),
+		ClientOptionVerifyIntegrity(),
+		ClientOptionCache(&TTLCache{MinTTL: time.Second}),
+		ClientOptionScheduler(&FairScheduler{Concurrency: 2}),
+		ClientOptionDispatcher(dispatcher),
+		ClientOptionPartialParsing(),
+		ClientOptionAdaptiveConcurrency(&AdaptiveConcurrency{MaxConcurrency: 8}),
+	)
+
+	if _, _, err := client.Get(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got.Get("X-Team") != "dns" || !strings.HasSuffix(got.Get("User-Agent"), " job/1") {
+		t.Errorf("Get() headers = %v", got)
+	}
+
+	if !reflect.DeepEqual(client.keys.keys(), []string{apiKey, "at_2"}) || client.maxRecords != 1000 ||
+		client.maxResponseBytes != 1<<20 || client.maxDataAge != time.Hour || client.stalePolicy != StaleIgnore ||
+		client.retry.MaxRetries != 2 || !client.lazyRecords || client.postForm ||
+		!client.verifyIntegrity ||
+		client.ttlCache == nil ||
+		client.scheduler == nil ||
+		client.dispatcher != dispatcher ||
+		!client.partialParsing ||
+		client.adaptive == nil {
+		t.Errorf("New() = %+v", client)
+	}
+}
+
+// TestClientOptionParams tests that the options following ClientOptionParams don't modify the original params.
+func TestClientOptionParams(t *testing.T) {
+	params := ClientParams{APIKeys: []string{"at_2"}, Headers: http.Header{"X-Team": {"dns"}}}
+
+	client := New(apiKey, ClientOptionParams(params), ClientOptionAPIKeys("at_3"), ClientOptionHeader("X-Team", "web"))
+
+	if !reflect.DeepEqual(client.keys.keys(), []string{apiKey, "at_2", "at_3"}) {
+		t.Errorf("New() keys = %v", client.keys.keys())
+	}
+
+	if len(params.APIKeys) != 1 || len(params.Headers["X-Team"]) != 1 {
+		t.Errorf("ClientOptionParams() modified params = %+v", params)
+	}
+}
@@ -0,0 +1,62 @@
+// Command dnslookupd runs a small local HTTP API in front of DNS Lookup API.
+//
+// All local tools talking to the daemon share one client, so the response cache,
+// the rate limit and the usage counters are shared too.
+//
+// Usage:
+//
+//	DNS_LOOKUP_API_KEY=at_... dnslookupd -addr 127.0.0.1:8053 -cache-ttl 5m -cache-size 10000 -rate 2
+//
+// The client is configured with the DNS_LOOKUP_* environment variables, see dnslookupapi.ConfigFromEnv.
+//
+// Endpoints:
+//
+//	GET /lookup?domainName=whoisxmlapi.com&type=A,MX   raw API response, always queried upstream
+//	GET /cached?domainName=whoisxmlapi.com&type=A,MX   raw API response, served from the cache when possible
+//	GET /watch?domainName=whoisxmlapi.com&interval=1m  blocks until the response changes or timeout elapses
+//	GET /audit?domainName=whoisxmlapi.com              audit dates of the DNS records
+//	GET /debug/vars                                    metrics
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+func main() {
+	cfg, err := dnslookupapi.ConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	addr := flag.String("addr", "127.0.0.1:8053", "address to listen on")
+	apiKey := flag.String("api-key", cfg.APIKey, "API key, defaults to $DNS_LOOKUP_API_KEY")
+	cacheTTL := flag.Duration("cache-ttl", 5*time.Minute, "how long cached responses are served")
+	cacheSize := flag.Int("cache-size", defaultCacheSize, "maximum number of cached responses")
+	rate := flag.Float64("rate", 2, "maximum number of upstream requests per second")
+	flag.Parse()
+
+	if *apiKey == "" {
+		log.Fatal("API key is required")
+	}
+
+	cfg.APIKey = *apiKey
+
+	client, err := dnslookupapi.NewClientFromConfig(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newServer(client, *cacheTTL, *cacheSize, *rate),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("dnslookupd listening on %s", *addr)
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,495 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+const (
+	defaultCacheSize     = 10000
+	defaultWatchInterval = time.Minute
+	defaultWatchTimeout  = 10 * time.Minute
+	minWatchInterval     = 10 * time.Second
+)
+
+// server is the HTTP API of the daemon backed by one shared DNS Lookup API client.
+type server struct {
+	client      dnslookupapi.DNSLookupService
+	cache       *cache
+	limiter     *limiter
+	metrics     *expvar.Map
+	mux         *http.ServeMux
+	minInterval time.Duration
+}
+
+// newServer creates the daemon HTTP handler.
+func newServer(client dnslookupapi.DNSLookupService, cacheTTL time.Duration, cacheSize int, rate float64) *server {
+	s := &server{
+		client:      client,
+		cache:       newCache(cacheTTL, cacheSize),
+		limiter:     newLimiter(rate),
+		metrics:     new(expvar.Map).Init(),
+		mux:         http.NewServeMux(),
+		minInterval: minWatchInterval,
+	}
+
+	s.mux.HandleFunc("/lookup", s.handleLookup)
+	s.mux.HandleFunc("/cached", s.handleCached)
+	s.mux.HandleFunc("/watch", s.handleWatch)
+	s.mux.HandleFunc("/audit", s.handleAudit)
+	s.mux.HandleFunc("/debug/vars", s.handleMetrics)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.metrics.Add("requests", 1)
+	s.mux.ServeHTTP(w, r)
+}
+
+// lookupArgs are the query parameters accepted by the lookup endpoints.
+type lookupArgs struct {
+	domainName string
+	types      string
+}
+
+// key returns the cache key of the lookup.
+func (a lookupArgs) key() string {
+	return strings.ToLower(a.domainName) + "|" + strings.ToUpper(a.types)
+}
+
+// options returns the client options of the lookup.
+func (a lookupArgs) options() []dnslookupapi.Option {
+	opts := []dnslookupapi.Option{dnslookupapi.OptionOutputFormat("JSON")}
+	if a.types != "" {
+		opts = append(opts, dnslookupapi.OptionType(a.types))
+	}
+
+	return opts
+}
+
+// parseLookupArgs reads lookup arguments from the request query.
+func parseLookupArgs(r *http.Request) (lookupArgs, error) {
+	q := r.URL.Query()
+
+	args := lookupArgs{
+		domainName: q.Get("domainName"),
+		types:      q.Get("type"),
+	}
+	if args.domainName == "" {
+		return args, &dnslookupapi.ArgError{Name: "domainName", Message: "is required"}
+	}
+
+	return args, nil
+}
+
+// fetch queries the upstream API respecting the rate limit and stores the result in the cache
+// unless it's an API error message.
+func (s *server) fetch(ctx context.Context, args lookupArgs) ([]byte, error) {
+	if err := s.limiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	s.metrics.Add("upstream_requests", 1)
+
+	resp, err := s.client.GetRaw(ctx, args.domainName, args.options()...)
+	if err != nil {
+		s.metrics.Add("upstream_errors", 1)
+		return nil, err
+	}
+
+	if isErrorMessage(resp.Body) {
+		s.metrics.Add("upstream_errors", 1)
+		return resp.Body, nil
+	}
+
+	s.cache.set(args.key(), resp.Body)
+
+	return resp.Body, nil
+}
+
+// isErrorMessage reports whether the raw API response carries an error message instead of the DNS data.
+func isErrorMessage(body []byte) bool {
+	var resp struct {
+		ErrorMessage *dnslookupapi.ErrorMessage `json:"ErrorMessage"`
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil || resp.ErrorMessage == nil {
+		return false
+	}
+
+	return resp.ErrorMessage.Code != "" || resp.ErrorMessage.Message != ""
+}
+
+// handleLookup always queries the upstream API.
+func (s *server) handleLookup(w http.ResponseWriter, r *http.Request) {
+	args, err := parseLookupArgs(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body, err := s.fetch(r.Context(), args)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, body)
+}
+
+// handleCached serves the response from the cache and falls back to the upstream API.
+func (s *server) handleCached(w http.ResponseWriter, r *http.Request) {
+	args, err := parseLookupArgs(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if body, ok := s.cache.get(args.key()); ok {
+		s.metrics.Add("cache_hits", 1)
+		writeJSON(w, body)
+
+		return
+	}
+
+	s.metrics.Add("cache_misses", 1)
+
+	body, err := s.fetch(r.Context(), args)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, body)
+}
+
+// handleWatch polls the upstream API until the DNS records change or the timeout elapses.
+// It responds with 200 and the new response on change and with 304 on timeout.
+func (s *server) handleWatch(w http.ResponseWriter, r *http.Request) {
+	args, err := parseLookupArgs(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	interval, err := durationArg(r, "interval", defaultWatchInterval)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if interval < s.minInterval {
+		interval = s.minInterval
+	}
+
+	timeout, err := durationArg(r, "timeout", defaultWatchTimeout)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	body, err := s.fetch(ctx, args)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	initial, err := recordsOf(body)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.WriteHeader(http.StatusNotModified)
+			return
+		case <-ticker.C:
+		}
+
+		body, err = s.fetch(ctx, args)
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			continue
+		}
+
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		current, err := recordsOf(body)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if !bytes.Equal(initial, current) {
+			writeJSON(w, body)
+			return
+		}
+	}
+}
+
+// handleAudit returns audit dates of the DNS records.
+func (s *server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	args, err := parseLookupArgs(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	body, ok := s.cache.get(args.key())
+	if !ok {
+		body, err = s.fetch(r.Context(), args)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+
+	var resp struct {
+		DNSData struct {
+			Audit json.RawMessage `json:"audit"`
+		} `json:"DNSData"`
+	}
+
+	if err = json.Unmarshal(body, &resp); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, resp.DNSData.Audit)
+}
+
+// handleMetrics writes the daemon metrics in the expvar format.
+func (s *server) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, []byte(s.metrics.String()))
+}
+
+// recordsOf extracts the DNS records from the raw API response for comparison.
+func recordsOf(body []byte) ([]byte, error) {
+	var resp struct {
+		DNSData struct {
+			DNSRecords json.RawMessage `json:"dnsRecords"`
+		} `json:"DNSData"`
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.DNSData.DNSRecords, nil
+}
+
+// durationArg parses the duration query parameter.
+func durationArg(r *http.Request, name string, def time.Duration) (time.Duration, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, &dnslookupapi.ArgError{Name: name, Message: "is not a valid duration"}
+	}
+
+	return d, nil
+}
+
+// writeJSON writes the JSON body.
+func writeJSON(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// writeError writes the error with a matching status code.
+// Only argument errors are sent to the caller as is, other errors are logged and replaced with fixed messages
+// because upstream errors may carry details of the daemon's requests.
+func writeError(w http.ResponseWriter, err error) {
+	var (
+		argErr  *dnslookupapi.ArgError
+		respErr *dnslookupapi.ErrorResponse
+	)
+
+	if errors.As(err, &argErr) {
+		http.Error(w, argErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("dnslookupd: %v", err)
+
+	switch {
+	case errors.As(err, &respErr):
+		http.Error(w, "upstream API failed with status code: "+strconv.Itoa(respErr.Response.StatusCode),
+			http.StatusBadGateway)
+	case errors.Is(err, context.DeadlineExceeded):
+		http.Error(w, "upstream API timed out", http.StatusGatewayTimeout)
+	default:
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+// cacheEntry is the cached raw API response.
+type cacheEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// cache is the in-memory cache of raw API responses. It holds at most size entries
+// evicting the least recently used ones.
+type cache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// newCache creates the cache with the specified TTL and maximum number of entries.
+// Non-positive size means the default size.
+func newCache(ttl time.Duration, size int) *cache {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+
+	return &cache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// get returns the cached response if it has not expired yet.
+func (c *cache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return entry.body, true
+}
+
+// set stores the response in the cache evicting the least recently used entry if the cache is full.
+func (c *cache) set(key string, body []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, body: body, expires: time.Now().Add(c.ttl)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// len returns the number of entries including the expired ones not evicted yet.
+func (c *cache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// remove deletes the entry.
+func (c *cache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// limiter limits the rate of upstream requests.
+type limiter struct {
+	mu    sync.Mutex
+	every time.Duration
+	next  time.Time
+}
+
+// newLimiter creates the limiter allowing rate requests per second. Non-positive rate disables limiting.
+func newLimiter(rate float64) *limiter {
+	l := &limiter{}
+	if rate > 0 {
+		l.every = time.Duration(float64(time.Second) / rate)
+	}
+
+	return l
+}
+
+// wait blocks until the next request is allowed or the context is done.
+func (l *limiter) wait(ctx context.Context) error {
+	if l.every == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.every)
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// fakeService is the DNSLookupService returning the prepared responses one by one.
+type fakeService struct {
+	mu        sync.Mutex
+	responses []string
+	err       error
+	calls     int
+}
+
+// Get is not used by the daemon.
+func (f *fakeService) Get(context.Context, string, ...dnslookupapi.Option) (
+	*dnslookupapi.DNSLookupResponse, *dnslookupapi.Response, error) {
+	panic("not implemented")
+}
+
+// GetRaw returns the next prepared response.
+func (f *fakeService) GetRaw(context.Context, string, ...dnslookupapi.Option) (*dnslookupapi.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i := f.calls
+	if i >= len(f.responses) {
+		i = len(f.responses) - 1
+	}
+	f.calls++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &dnslookupapi.Response{Body: []byte(f.responses[i])}, nil
+}
+
+const (
+	respFirst  = `{"DNSData":{"audit":{"createdDate":"2022-07-12 11:46:25 UTC"},"dnsRecords":[{"dnsType":"A","address":"1.1.1.1"}]}}`
+	respSecond = `{"DNSData":{"audit":{"createdDate":"2022-07-13 11:46:25 UTC"},"dnsRecords":[{"dnsType":"A","address":"2.2.2.2"}]}}`
+	respError  = `{"ErrorMessage":{"errorCode":"DNS_01","msg":"Temporary failure"}}`
+)
+
+// TestServer tests the daemon endpoints.
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		wantCode   int
+		wantBody   string
+		wantCalls  int
+		responses  []string
+		err        error
+		preRequest string
+	}{
+		{
+			name:      "lookup",
+			target:    "/lookup?domainName=whoisxmlapi.com",
+			wantCode:  http.StatusOK,
+			wantBody:  respFirst,
+			wantCalls: 1,
+			responses: []string{respFirst},
+		},
+		{
+			name:      "missing domain name",
+			target:    "/lookup",
+			wantCode:  http.StatusBadRequest,
+			wantBody:  `invalid argument: "domainName" is required`,
+			wantCalls: 0,
+			responses: []string{respFirst},
+		},
+		{
+			name:       "cached",
+			target:     "/cached?domainName=whoisxmlapi.com&type=a",
+			preRequest: "/lookup?domainName=WHOISXMLAPI.com&type=A",
+			wantCode:   http.StatusOK,
+			wantBody:   respFirst,
+			wantCalls:  1,
+			responses:  []string{respFirst, respSecond},
+		},
+		{
+			name:       "error message not cached",
+			target:     "/cached?domainName=whoisxmlapi.com&type=a",
+			preRequest: "/lookup?domainName=whoisxmlapi.com&type=a",
+			wantCode:   http.StatusOK,
+			wantBody:   respFirst,
+			wantCalls:  2,
+			responses:  []string{respError, respFirst},
+		},
+		{
+			name:      "audit",
+			target:    "/audit?domainName=whoisxmlapi.com",
+			wantCode:  http.StatusOK,
+			wantBody:  `{"createdDate":"2022-07-12 11:46:25 UTC"}`,
+			wantCalls: 1,
+			responses: []string{respFirst},
+		},
+		{
+			name:      "watch changed",
+			target:    "/watch?domainName=whoisxmlapi.com&interval=1ms&timeout=1s",
+			wantCode:  http.StatusOK,
+			wantBody:  respSecond,
+			wantCalls: 2,
+			responses: []string{respFirst, respSecond},
+		},
+		{
+			name:   "upstream error",
+			target: "/lookup?domainName=whoisxmlapi.com",
+			err: fmt.Errorf("cannot execute request: %w",
+				errors.New(`Get "https://example.com/?apiKey=at_SECRETKEY123456": dial tcp: refused`)),
+			wantCode:  http.StatusInternalServerError,
+			wantBody:  "internal error",
+			wantCalls: 1,
+			responses: []string{respFirst},
+		},
+		{
+			name:      "upstream status error",
+			target:    "/lookup?domainName=whoisxmlapi.com",
+			err:       &dnslookupapi.ErrorResponse{Response: &http.Response{StatusCode: 503}, Message: "Unavailable"},
+			wantCode:  http.StatusBadGateway,
+			wantBody:  "upstream API failed with status code: 503",
+			wantCalls: 1,
+			responses: []string{respFirst},
+		},
+		{
+			name:      "watch not changed",
+			target:    "/watch?domainName=whoisxmlapi.com&interval=1ms&timeout=20ms",
+			wantCode:  http.StatusNotModified,
+			wantBody:  "",
+			wantCalls: -1,
+			responses: []string{respFirst},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeService{responses: tt.responses, err: tt.err}
+			s := newServer(service, time.Minute, 0, 0)
+			s.minInterval = time.Millisecond
+
+			if tt.preRequest != "" {
+				s.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.preRequest, nil))
+			}
+
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("code = %v, want %v", rec.Code, tt.wantCode)
+			}
+
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.wantBody {
+				t.Errorf("body = %v, want %v", got, tt.wantBody)
+			}
+
+			if tt.wantCalls >= 0 && service.calls != tt.wantCalls {
+				t.Errorf("calls = %v, want %v", service.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// TestCache tests the expiration and the eviction of the least recently used entries.
+func TestCache(t *testing.T) {
+	c := newCache(time.Minute, 2)
+
+	c.set("a", []byte("1"))
+	c.set("b", []byte("2"))
+
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("get(a) = false, want true")
+	}
+
+	c.set("c", []byte("3"))
+
+	if _, ok := c.get("b"); ok {
+		t.Error("get(b) = true, want the least recently used entry evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(key); !ok {
+			t.Errorf("get(%s) = false, want true", key)
+		}
+	}
+
+	if n := c.len(); n != 2 {
+		t.Errorf("len() = %d, want 2", n)
+	}
+
+	expired := newCache(time.Nanosecond, 2)
+	expired.set("a", []byte("1"))
+	time.Sleep(time.Millisecond)
+
+	if _, ok := expired.get("a"); ok || expired.len() != 0 {
+		t.Errorf("get() of the expired entry = %v, len() = %d, want false, 0", ok, expired.len())
+	}
+}
+
+// TestLimiter tests the rate limiter.
+func TestLimiter(t *testing.T) {
+	l := newLimiter(100)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("elapsed = %v, want at least 20ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	l = newLimiter(0.001)
+	_ = l.wait(ctx)
+	if err := l.wait(ctx); err != context.Canceled {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+}
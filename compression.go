@@ -0,0 +1,72 @@
+package dnslookupapi
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is the value of the Accept-Encoding header sent by the client.
+const acceptEncoding = "gzip, deflate"
+
+// ErrResponseTooLarge is returned when the response body exceeds ClientParams.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response is too large")
+
+// decodeBody returns the reader of the response body decompressed according to the Content-Encoding header.
+// As http.Transport does for transparently decompressed responses, the encoding headers are removed.
+func decodeBody(resp *http.Response) (io.Reader, error) {
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" {
+		return resp.Body, nil
+	}
+
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	switch encoding {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(resp.Body)
+	case "deflate":
+		// deflate is supposed to be zlib-wrapped, but some servers send the raw deflate stream
+		br := bufio.NewReader(resp.Body)
+
+		header, err := br.Peek(2)
+		if err != nil {
+			return nil, err
+		}
+
+		if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			return zlib.NewReader(br)
+		}
+
+		return flate.NewReader(br), nil
+	}
+
+	return nil, errors.New("unsupported Content-Encoding: " + encoding)
+}
+
+// copyBody copies the response body to the writer enforcing the size limit. Non-positive limit means no limit.
+func copyBody(w io.Writer, r io.Reader, limit int64) error {
+	if limit <= 0 {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	n, err := io.Copy(w, io.LimitReader(r, limit+1))
+	if err != nil {
+		return err
+	}
+
+	if n > limit {
+		return ErrResponseTooLarge
+	}
+
+	return nil
+}
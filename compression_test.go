@@ -0,0 +1,102 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestCompression tests decompression of responses and the response size limit.
+func TestCompression(t *testing.T) {
+	const resp = `{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[]}}`
+
+	compress := func(encoding string) []byte {
+		var b bytes.Buffer
+
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&b)
+		case "deflate":
+			w = zlib.NewWriter(&b)
+		case "raw-deflate":
+			w, _ = flate.NewWriter(&b, flate.DefaultCompression)
+		default:
+			return []byte(resp)
+		}
+
+		_, _ = w.Write([]byte(resp))
+		_ = w.Close()
+
+		return b.Bytes()
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Accept-Encoding") != acceptEncoding {
+			panic(req.Header.Get("Accept-Encoding"))
+		}
+
+		encoding := req.URL.Path[1:]
+		if encoding == "raw-deflate" {
+			w.Header().Set("Content-Encoding", "deflate")
+		} else if encoding != "identity" {
+			w.Header().Set("Content-Encoding", encoding)
+		}
+
+		_, _ = w.Write(compress(encoding))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		encoding string
+		maxBytes int64
+		wantErr  error
+	}{
+		{name: "identity", encoding: "identity"},
+		{name: "gzip", encoding: "gzip"},
+		{name: "deflate", encoding: "deflate"},
+		{name: "raw deflate", encoding: "raw-deflate"},
+		{name: "limit not exceeded", encoding: "gzip", maxBytes: int64(len(resp))},
+		{name: "limit exceeded", encoding: "gzip", maxBytes: int64(len(resp) - 1), wantErr: ErrResponseTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiURL, err := url.Parse(server.URL + "/" + tt.encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			api := NewClient(apiKey, ClientParams{
+				HTTPClient:       server.Client(),
+				DNSLookupBaseURL: apiURL,
+				MaxResponseBytes: tt.maxBytes,
+			})
+
+			got, err := api.GetRaw(context.Background(), "whoisxmlapi.com")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetRaw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if string(got.Body) != resp {
+				t.Errorf("GetRaw() = %s, want %s", got.Body, resp)
+			}
+
+			if got.Header.Get("Content-Encoding") != "" {
+				t.Errorf("Content-Encoding = %v, want empty", got.Header.Get("Content-Encoding"))
+			}
+		})
+	}
+}
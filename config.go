@@ -0,0 +1,293 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvAPIKey           = "DNS_LOOKUP_API_KEY"
+	EnvAPIKeys          = "DNS_LOOKUP_API_KEYS"
+	EnvBaseURL          = "DNS_LOOKUP_BASE_URL"
+	EnvMirrorURLs       = "DNS_LOOKUP_MIRROR_URLS"
+	EnvHedgeDelay       = "DNS_LOOKUP_HEDGE_DELAY"
+	EnvTimeout          = "DNS_LOOKUP_TIMEOUT"
+	EnvDialTimeout      = "DNS_LOOKUP_DIAL_TIMEOUT"
+	EnvLookupTimeout    = "DNS_LOOKUP_LOOKUP_TIMEOUT"
+	EnvProxyURL         = "DNS_LOOKUP_PROXY_URL"
+	EnvMaxResponseBytes = "DNS_LOOKUP_MAX_RESPONSE_BYTES"
+	EnvMaxRecords       = "DNS_LOOKUP_MAX_RECORDS"
+	EnvMaxRedirects     = "DNS_LOOKUP_MAX_REDIRECTS"
+	EnvUserAgentSuffix  = "DNS_LOOKUP_USER_AGENT_SUFFIX"
+
+	// EnvConfigFile is the path of the JSON configuration file loaded before the other variables.
+	EnvConfigFile = "DNS_LOOKUP_CONFIG"
+)
+
+// ConfigDuration is the duration encoded in JSON as a string accepted by time.ParseDuration, e.g. "1m30s",
+// or as a number of seconds.
+type ConfigDuration time.Duration
+
+// MarshalJSON encodes the duration as a string.
+func (d ConfigDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes the duration from a string or a number of seconds.
+func (d *ConfigDuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		s = string(b)
+	}
+
+	duration, err := parseConfigDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = ConfigDuration(duration)
+
+	return nil
+}
+
+// parseConfigDuration parses a duration string or a number of seconds.
+func parseConfigDuration(s string) (time.Duration, error) {
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(n * float64(time.Second)), nil
+	}
+
+	return time.ParseDuration(s)
+}
+
+// Config is the serializable subset of ClientParams, loaded with LoadConfig or ConfigFromEnv,
+// so deployments can configure the client without code. Zero values keep the defaults of ClientParams.
+type Config struct {
+	// APIKey is the API key passed to NewClient
+	APIKey string `json:"apiKey,omitempty"`
+
+	// APIKeys are the fallback API keys, see ClientParams.APIKeys
+	APIKeys []string `json:"apiKeys,omitempty"`
+
+	// BaseURL is the API endpoint, see ClientParams.DNSLookupBaseURL
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// MirrorURLs are the mirror endpoints, see ClientParams.DNSLookupMirrorURLs
+	MirrorURLs []string `json:"mirrorURLs,omitempty"`
+
+	// HedgeDelay is the time to wait before trying a mirror, see ClientParams.HedgeDelay
+	HedgeDelay ConfigDuration `json:"hedgeDelay,omitempty"`
+
+	// Timeout is the time limit for requests, see ClientParams.Timeout
+	Timeout ConfigDuration `json:"timeout,omitempty"`
+
+	// DialTimeout is the time limit for establishing connections, see ClientParams.DialTimeout
+	DialTimeout ConfigDuration `json:"dialTimeout,omitempty"`
+
+	// LookupTimeout caps the total time of a lookup, see ClientParams.LookupTimeout
+	LookupTimeout ConfigDuration `json:"lookupTimeout,omitempty"`
+
+	// ProxyURL is the proxy, see ClientParams.ProxyURL
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// MaxResponseBytes is the maximum size of the response body, see ClientParams.MaxResponseBytes
+	MaxResponseBytes int64 `json:"maxResponseBytes,omitempty"`
+
+	// MaxRecords is the maximum number of records in the response, see ClientParams.MaxRecords
+	MaxRecords int `json:"maxRecords,omitempty"`
+
+	// MaxRedirects is the maximum number of redirects, see ClientParams.MaxRedirects
+	MaxRedirects int `json:"maxRedirects,omitempty"`
+
+	// UserAgentSuffix is appended to the User-Agent header, see ClientParams.UserAgentSuffix
+	UserAgentSuffix string `json:"userAgentSuffix,omitempty"`
+}
+
+// LoadConfig decodes the JSON configuration. Unknown fields are rejected to catch typos.
+func LoadConfig(r io.Reader) (Config, error) {
+	var cfg Config
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("cannot load config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// ConfigFromEnv returns the configuration loaded from the JSON file named by DNS_LOOKUP_CONFIG, if it's set,
+// and overridden by the DNS_LOOKUP_* environment variables. Lists are comma-separated,
+// durations are accepted by time.ParseDuration or are numbers of seconds.
+func ConfigFromEnv() (Config, error) {
+	return configFromEnv(os.LookupEnv)
+}
+
+// configFromEnv returns the configuration read with the lookup function.
+func configFromEnv(lookup func(string) (string, bool)) (Config, error) {
+	var cfg Config
+
+	if name, ok := lookup(EnvConfigFile); ok && name != "" {
+		raw, err := os.ReadFile(name)
+		if err != nil {
+			return Config{}, fmt.Errorf("cannot load config: %w", err)
+		}
+
+		if cfg, err = LoadConfig(bytes.NewReader(raw)); err != nil {
+			return Config{}, err
+		}
+	}
+
+	var errs []string
+
+	setString := func(name string, v *string) {
+		if s, ok := lookup(name); ok {
+			*v = strings.TrimSpace(s)
+		}
+	}
+
+	setList := func(name string, v *[]string) {
+		if s, ok := lookup(name); ok {
+			*v = splitList(s, ",")
+		}
+	}
+
+	setDuration := func(name string, v *ConfigDuration) {
+		if s, ok := lookup(name); ok {
+			d, err := parseConfigDuration(strings.TrimSpace(s))
+			if err != nil {
+				errs = append(errs, name+": "+err.Error())
+			}
+
+			*v = ConfigDuration(d)
+		}
+	}
+
+	setInt := func(name string, v *int64) {
+		if s, ok := lookup(name); ok {
+			n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			if err != nil {
+				errs = append(errs, name+": "+err.Error())
+			}
+
+			*v = n
+		}
+	}
+
+	maxRecords, maxRedirects := int64(cfg.MaxRecords), int64(cfg.MaxRedirects)
+
+	setString(EnvAPIKey, &cfg.APIKey)
+	setList(EnvAPIKeys, &cfg.APIKeys)
+	setString(EnvBaseURL, &cfg.BaseURL)
+	setList(EnvMirrorURLs, &cfg.MirrorURLs)
+	setDuration(EnvHedgeDelay, &cfg.HedgeDelay)
+	setDuration(EnvTimeout, &cfg.Timeout)
+	setDuration(EnvDialTimeout, &cfg.DialTimeout)
+	setDuration(EnvLookupTimeout, &cfg.LookupTimeout)
+	setString(EnvProxyURL, &cfg.ProxyURL)
+	setInt(EnvMaxResponseBytes, &cfg.MaxResponseBytes)
+	setInt(EnvMaxRecords, &maxRecords)
+	setInt(EnvMaxRedirects, &maxRedirects)
+	setString(EnvUserAgentSuffix, &cfg.UserAgentSuffix)
+
+	cfg.MaxRecords, cfg.MaxRedirects = int(maxRecords), int(maxRedirects)
+
+	if len(errs) != 0 {
+		return Config{}, errors.New("invalid environment: " + strings.Join(errs, "; "))
+	}
+
+	return cfg, nil
+}
+
+// Params returns ClientParams with the configured values.
+func (cfg Config) Params() (ClientParams, error) {
+	params := ClientParams{
+		APIKeys:          cfg.APIKeys,
+		HedgeDelay:       time.Duration(cfg.HedgeDelay),
+		Timeout:          time.Duration(cfg.Timeout),
+		DialTimeout:      time.Duration(cfg.DialTimeout),
+		LookupTimeout:    time.Duration(cfg.LookupTimeout),
+		MaxResponseBytes: cfg.MaxResponseBytes,
+		MaxRecords:       cfg.MaxRecords,
+		MaxRedirects:     cfg.MaxRedirects,
+		UserAgentSuffix:  cfg.UserAgentSuffix,
+	}
+
+	var err error
+
+	if cfg.BaseURL != "" {
+		if params.DNSLookupBaseURL, err = parseConfigURL("baseURL", cfg.BaseURL); err != nil {
+			return ClientParams{}, err
+		}
+	}
+
+	for _, mirror := range cfg.MirrorURLs {
+		u, err := parseConfigURL("mirrorURLs", mirror)
+		if err != nil {
+			return ClientParams{}, err
+		}
+
+		params.DNSLookupMirrorURLs = append(params.DNSLookupMirrorURLs, u)
+	}
+
+	if cfg.ProxyURL != "" {
+		if params.ProxyURL, err = parseConfigURL("proxyURL", cfg.ProxyURL); err != nil {
+			return ClientParams{}, err
+		}
+	}
+
+	return params, nil
+}
+
+// parseConfigURL parses the absolute URL of the configuration field.
+func parseConfigURL(field, raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err == nil && (u.Scheme == "" || u.Host == "") {
+		err = errors.New("must be an absolute URL")
+	}
+
+	if err != nil {
+		return nil, &ArgError{Name: field, Message: err.Error()}
+	}
+
+	return u, nil
+}
+
+// NewClientFromConfig creates Client with the configuration.
+// If APIKey is empty, the first of APIKeys is used as the API key.
+func NewClientFromConfig(cfg Config) (*Client, error) {
+	params, err := cfg.Params()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := cfg.APIKey
+	if apiKey == "" && len(params.APIKeys) != 0 {
+		apiKey, params.APIKeys = params.APIKeys[0], params.APIKeys[1:]
+	}
+
+	return NewClient(apiKey, params), nil
+}
+
+// NewClientFromEnv creates Client configured with ConfigFromEnv. The API key is required.
+func NewClientFromEnv() (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.APIKey == "" && len(cfg.APIKeys) == 0 {
+		return nil, errors.New("API key is not set: " + EnvAPIKey + " is empty")
+	}
+
+	return NewClientFromConfig(cfg)
+}
@@ -0,0 +1,99 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mapEnv returns the lookup function of the environment variables.
+func mapEnv(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+// TestConfigFromEnv tests that the file configuration is overridden by the environment variables.
+func TestConfigFromEnv(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "config.json")
+
+	file := `{"apiKey":"at_file","timeout":"20s","lookupTimeout":90,"maxRecords":100,"userAgentSuffix":"job/1"}`
+	if err := os.WriteFile(name, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := configFromEnv(mapEnv(map[string]string{
+		EnvConfigFile:       name,
+		EnvAPIKey:           "at_env",
+		EnvAPIKeys:          "at_2, at_3,",
+		EnvBaseURL:          "https://api.example.com/DNSService",
+		EnvTimeout:          "1m",
+		EnvMaxRedirects:     "-1",
+		EnvMirrorURLs:       "https://eu.example.com/DNSService",
+		EnvMaxResponseBytes: "1048576",
+	}))
+	if err != nil {
+		t.Fatalf("configFromEnv() error = %v", err)
+	}
+
+	want := Config{
+		APIKey:           "at_env",
+		APIKeys:          []string{"at_2", "at_3"},
+		BaseURL:          "https://api.example.com/DNSService",
+		MirrorURLs:       []string{"https://eu.example.com/DNSService"},
+		Timeout:          ConfigDuration(time.Minute),
+		LookupTimeout:    ConfigDuration(90 * time.Second),
+		MaxResponseBytes: 1 << 20,
+		MaxRecords:       100,
+		MaxRedirects:     -1,
+		UserAgentSuffix:  "job/1",
+	}
+
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("configFromEnv() = %+v, want %+v", cfg, want)
+	}
+
+	params, err := cfg.Params()
+	if err != nil {
+		t.Fatalf("Params() error = %v", err)
+	}
+
+	if params.DNSLookupBaseURL.Host != "api.example.com" || len(params.DNSLookupMirrorURLs) != 1 ||
+		params.Timeout != time.Minute || params.LookupTimeout != 90*time.Second || params.MaxRecords != 100 {
+		t.Errorf("Params() = %+v", params)
+	}
+}
+
+// TestConfigErrors tests the errors of invalid configurations.
+func TestConfigErrors(t *testing.T) {
+	_, err := configFromEnv(mapEnv(map[string]string{EnvTimeout: "soon", EnvMaxRecords: "many"}))
+	if err == nil || !strings.Contains(err.Error(), EnvTimeout) || !strings.Contains(err.Error(), EnvMaxRecords) {
+		t.Errorf("configFromEnv() error = %v", err)
+	}
+
+	if _, err = LoadConfig(strings.NewReader(`{"apikey":"at_1","timout":"1s"}`)); err == nil {
+		t.Error("LoadConfig() expected error for unknown field")
+	}
+
+	var argErr *ArgError
+	if _, err = (Config{BaseURL: "api.example.com"}).Params(); !errors.As(err, &argErr) || argErr.Name != "baseURL" {
+		t.Errorf("Params() error = %v, want baseURL ArgError", err)
+	}
+}
+
+// TestNewClientFromConfig tests that the first fallback key is used if the API key is not set.
+func TestNewClientFromConfig(t *testing.T) {
+	client, err := NewClientFromConfig(Config{APIKeys: []string{"at_1", "at_2"}})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig() error = %v", err)
+	}
+
+	if keys := client.keys.keys(); !reflect.DeepEqual(keys, []string{"at_1", "at_2"}) {
+		t.Errorf("NewClientFromConfig() keys = %v", keys)
+	}
+}
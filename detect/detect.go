@@ -0,0 +1,318 @@
+// Package detect maps the DNS records returned by DNS Lookup API to the providers hosting the domain:
+// name servers and mail servers by their host names, and web hosting and CDNs by CNAME targets and
+// A/AAAA address ranges. The built-in ruleset is embedded and can be extended with custom rules.
+package detect
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// Service is the service a provider is detected for.
+type Service string
+
+// Services.
+const (
+	ServiceDNS   Service = "dns"
+	ServiceEmail Service = "email"
+	ServiceWeb   Service = "web"
+)
+
+// defaultRules is the built-in ruleset.
+//
+//go:embed rules.json
+var defaultRules []byte
+
+// Rule maps host names and address ranges to a provider of the service.
+type Rule struct {
+	// Provider is the provider name, e.g. "Cloudflare".
+	Provider string `json:"provider"`
+
+	// Service is the service the rule applies to.
+	Service Service `json:"service"`
+
+	// Tags describe the kind of the service, e.g. "cdn" or "email-security".
+	Tags []string `json:"tags,omitempty"`
+
+	// Suffixes match host names equal to or below them, e.g. "cloudfront.net".
+	Suffixes []string `json:"suffixes,omitempty"`
+
+	// LabelPrefixes match host names with a label starting with them, e.g. "awsdns-".
+	LabelPrefixes []string `json:"labelPrefixes,omitempty"`
+
+	// CIDRs match the addresses of A and AAAA records. They apply to ServiceWeb only.
+	CIDRs []string `json:"cidrs,omitempty"`
+}
+
+// compiledRule is the rule with normalized suffixes and parsed address ranges.
+type compiledRule struct {
+	Rule
+
+	suffixes []string
+	networks []*net.IPNet
+}
+
+// Ruleset is the ordered set of rules. The first matching rule of a provider wins.
+// It's not safe to modify it concurrently with detection.
+type Ruleset struct {
+	rules []compiledRule
+}
+
+// DefaultRules returns a new copy of the built-in ruleset, which can be extended with Add.
+func DefaultRules() *Ruleset {
+	r, err := ParseRules(defaultRules)
+	if err != nil {
+		panic("detect: invalid built-in rules: " + err.Error())
+	}
+
+	return r
+}
+
+// ParseRules parses the ruleset from JSON in the format of the built-in rules: {"rules": [Rule...]}.
+func ParseRules(data []byte) (*Ruleset, error) {
+	var file struct {
+		Rules []Rule `json:"rules"`
+	}
+
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("cannot parse rules: %w", err)
+	}
+
+	r := &Ruleset{}
+	if err := r.Add(file.Rules...); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Add appends the rules to the ruleset. Custom rules can override the built-in ones by using
+// a new Ruleset with the custom rules added first and then the rules of DefaultRules.
+func (r *Ruleset) Add(rules ...Rule) error {
+	compiled := make([]compiledRule, 0, len(rules))
+
+	for _, rule := range rules {
+		if rule.Provider == "" {
+			return errors.New("rule has no provider")
+		}
+
+		switch rule.Service {
+		case ServiceDNS, ServiceEmail, ServiceWeb:
+		default:
+			return fmt.Errorf("rule of %s has unknown service %q", rule.Provider, rule.Service)
+		}
+
+		c := compiledRule{Rule: rule}
+
+		for _, suffix := range rule.Suffixes {
+			c.suffixes = append(c.suffixes, dnslookupapi.NormalizeName(suffix))
+		}
+
+		for _, cidr := range rule.CIDRs {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return fmt.Errorf("rule of %s: %w", rule.Provider, err)
+			}
+
+			c.networks = append(c.networks, network)
+		}
+
+		compiled = append(compiled, c)
+	}
+
+	r.rules = append(r.rules, compiled...)
+
+	return nil
+}
+
+// Rules returns the rules in order.
+func (r *Ruleset) Rules() []Rule {
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule.Rule)
+	}
+
+	return rules
+}
+
+// matchHost reports whether the normalized host name matches the rule.
+func (c *compiledRule) matchHost(host string) bool {
+	for _, suffix := range c.suffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		for _, prefix := range c.LabelPrefixes {
+			if strings.HasPrefix(label, prefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchIP reports whether the address is in the ranges of the rule.
+func (c *compiledRule) matchIP(ip net.IP) bool {
+	for _, network := range c.networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchHost returns the rule matching the host name for the service.
+func (r *Ruleset) MatchHost(service Service, host string) (Rule, bool) {
+	host = dnslookupapi.NormalizeName(host)
+
+	for i := range r.rules {
+		if r.rules[i].Service == service && r.rules[i].matchHost(host) {
+			return r.rules[i].Rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// MatchIP returns the web rule matching the address.
+func (r *Ruleset) MatchIP(ip net.IP) (Rule, bool) {
+	for i := range r.rules {
+		if r.rules[i].Service == ServiceWeb && r.rules[i].matchIP(ip) {
+			return r.rules[i].Rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// Provider is the provider detected for a service.
+type Provider struct {
+	// Name is the provider name.
+	Name string `json:"name"`
+
+	// Tags are the tags of the matching rules.
+	Tags []string `json:"tags,omitempty"`
+
+	// Evidence are the host names and addresses the provider was detected by.
+	Evidence []string `json:"evidence"`
+}
+
+// Result are the providers detected per service in the order of the records.
+type Result struct {
+	DNS   []Provider `json:"dns,omitempty"`
+	Email []Provider `json:"email,omitempty"`
+	Web   []Provider `json:"web,omitempty"`
+}
+
+// Providers returns the providers of the service.
+func (r *Result) Providers(service Service) []Provider {
+	switch service {
+	case ServiceDNS:
+		return r.DNS
+	case ServiceEmail:
+		return r.Email
+	case ServiceWeb:
+		return r.Web
+	default:
+		return nil
+	}
+}
+
+// defaultRuleset is the built-in ruleset used by Detect.
+var defaultRuleset = DefaultRules()
+
+// Detect detects the providers of the records with the built-in rules.
+func Detect(records *dnslookupapi.DNSRecords) *Result {
+	return defaultRuleset.Detect(records)
+}
+
+// Detect detects the providers of the records: NS targets for ServiceDNS, MX targets for ServiceEmail,
+// and CNAME targets and A/AAAA addresses for ServiceWeb.
+func (r *Ruleset) Detect(records *dnslookupapi.DNSRecords) *Result {
+	result := &Result{}
+
+	add := func(providers *[]Provider, rule Rule, evidence string) {
+		for i := range *providers {
+			p := &(*providers)[i]
+			if p.Name != rule.Provider {
+				continue
+			}
+
+			for _, tag := range rule.Tags {
+				if !contains(p.Tags, tag) {
+					p.Tags = append(p.Tags, tag)
+				}
+			}
+
+			if !contains(p.Evidence, evidence) {
+				p.Evidence = append(p.Evidence, evidence)
+			}
+
+			return
+		}
+
+		*providers = append(*providers, Provider{
+			Name:     rule.Provider,
+			Tags:     append([]string(nil), rule.Tags...),
+			Evidence: []string{evidence},
+		})
+	}
+
+	host := func(providers *[]Provider, service Service, name string) {
+		if rule, ok := r.MatchHost(service, name); ok {
+			add(providers, rule, dnslookupapi.NormalizeName(name))
+		}
+	}
+
+	for _, ns := range records.NS {
+		host(&result.DNS, ServiceDNS, ns.Target)
+	}
+
+	for _, mx := range records.MX {
+		host(&result.Email, ServiceEmail, mx.Target)
+	}
+
+	for _, cname := range records.CNAME {
+		host(&result.Web, ServiceWeb, cname.Target)
+	}
+
+	addresses := make([]string, 0, len(records.A)+len(records.AAAA))
+	for _, a := range records.A {
+		addresses = append(addresses, a.Address)
+	}
+
+	for _, aaaa := range records.AAAA {
+		addresses = append(addresses, aaaa.Address)
+	}
+
+	for _, address := range addresses {
+		if ip := net.ParseIP(address); ip != nil {
+			if rule, ok := r.MatchIP(ip); ok {
+				add(&result.Web, rule, ip.String())
+			}
+		}
+	}
+
+	return result
+}
+
+// contains reports whether the string is in the list.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package detect
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/dnslookupapitest"
+)
+
+// TestDetect tests detection of the providers of the fixture.
+func TestDetect(t *testing.T) {
+	var resp struct {
+		DNSData dnslookupapi.DNSLookupResponse `json:"DNSData"`
+	}
+
+	if err := json.Unmarshal([]byte(dnslookupapitest.FixtureResponse), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	result := Detect(&resp.DNSData.DNSRecords)
+
+	if len(result.DNS) != 1 || result.DNS[0].Name != "Cloudflare" || len(result.DNS[0].Evidence) != 2 {
+		t.Errorf("DNS = %+v", result.DNS)
+	}
+
+	if len(result.Email) != 1 || result.Email[0].Name != "Google" {
+		t.Errorf("Email = %+v", result.Email)
+	}
+
+	if len(result.Web) != 1 || result.Web[0].Name != "Cloudflare" ||
+		!reflect.DeepEqual(result.Web[0].Tags, []string{"cdn", "proxy"}) {
+		t.Errorf("Web = %+v", result.Web)
+	}
+
+	if !reflect.DeepEqual(result.Providers(ServiceEmail), result.Email) || result.Providers("other") != nil {
+		t.Error("Providers() mismatch")
+	}
+}
+
+// TestRuleset tests matching and extending of the rules.
+func TestRuleset(t *testing.T) {
+	rules := DefaultRules()
+
+	tests := []struct {
+		service  Service
+		host     string
+		provider string
+	}{
+		{ServiceDNS, "ns-1234.awsdns-12.co.uk.", "AWS"},
+		{ServiceDNS, "Kurt.NS.Cloudflare.com.", "Cloudflare"},
+		{ServiceEmail, "example-com.mail.protection.outlook.com.", "Microsoft"},
+		{ServiceWeb, "d111111abcdef8.cloudfront.net.", "AWS"},
+		{ServiceWeb, "e123.a.akamaiedge.net", "Akamai"},
+		{ServiceWeb, "notcloudfront.net", ""},
+	}
+
+	for _, tt := range tests {
+		rule, ok := rules.MatchHost(tt.service, tt.host)
+		if ok != (tt.provider != "") || rule.Provider != tt.provider {
+			t.Errorf("MatchHost(%v, %q) = %q, %v, want %q", tt.service, tt.host, rule.Provider, ok, tt.provider)
+		}
+	}
+
+	for address, provider := range map[string]string{
+		"151.101.1.69": "Fastly", "2606:4700::6810:84e5": "Cloudflare", "185.199.110.153": "GitHub", "192.0.2.1": "",
+	} {
+		rule, _ := rules.MatchIP(net.ParseIP(address))
+		if rule.Provider != provider {
+			t.Errorf("MatchIP(%v) = %q, want %q", address, rule.Provider, provider)
+		}
+	}
+
+	err := rules.Add(Rule{Provider: "Internal", Service: ServiceWeb, Tags: []string{"on-prem"},
+		Suffixes: []string{"lb.corp.example."}, CIDRs: []string{"192.0.2.0/24"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records := &dnslookupapi.DNSRecords{
+		A:     []dnslookupapi.ARecord{{Address: "192.0.2.1"}, {Address: "192.0.2.2"}},
+		CNAME: []dnslookupapi.CNAMERecord{{Target: "www.lb.corp.example."}},
+	}
+
+	want := []Provider{{Name: "Internal", Tags: []string{"on-prem"}, Evidence: []string{"www.lb.corp.example", "192.0.2.1", "192.0.2.2"}}}
+	if result := rules.Detect(records); !reflect.DeepEqual(result.Web, want) {
+		t.Errorf("Web = %+v, want %+v", result.Web, want)
+	}
+
+	if len(DefaultRules().Rules()) != len(rules.Rules())-1 {
+		t.Error("Add() modified the built-in rules")
+	}
+
+	for _, invalid := range []Rule{
+		{Service: ServiceDNS},
+		{Provider: "X", Service: "ftp"},
+		{Provider: "X", Service: ServiceWeb, CIDRs: []string{"192.0.2.0/33"}},
+	} {
+		if err := rules.Add(invalid); err == nil {
+			t.Errorf("Add(%+v) error = nil", invalid)
+		}
+	}
+
+	if _, err := ParseRules([]byte(`{"rules":[{"provider":"X","service":"dns","suffixes":["x.example"]}]}`)); err != nil {
+		t.Errorf("ParseRules() error = %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// diffOptions configures the comparison of DNS records.
+type diffOptions struct {
+	ignoreTTL   bool
+	ignoreOrder bool
+}
+
+// DiffOption configures Diff.
+type DiffOption func(o *diffOptions)
+
+// DiffIgnoreTTL makes Diff ignore records which differ only in TTL.
+func DiffIgnoreTTL() DiffOption {
+	return func(o *diffOptions) {
+		o.ignoreTTL = true
+	}
+}
+
+// DiffIgnoreOrder makes Diff ignore the order of the records.
+func DiffIgnoreOrder() DiffOption {
+	return func(o *diffOptions) {
+		o.ignoreOrder = true
+	}
+}
+
+// TypeDiff is the difference between records of a single DNS type.
+type TypeDiff struct {
+	// DNSType is the DNS record type.
+	DNSType string
+
+	// Added are the records present only in the new set.
+	Added []DNSRecord
+
+	// Removed are the records present only in the old set.
+	Removed []DNSRecord
+
+	// Changed are the records with the same owner name and data but different other fields, e.g. TTL.
+	Changed []RecordChange
+
+	// Reordered reports whether the records present in both sets are in a different order.
+	Reordered bool
+}
+
+// Empty reports whether there are no differences.
+func (d *TypeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && !d.Reordered
+}
+
+// RecordsDiff is the difference between two sets of DNS records.
+type RecordsDiff struct {
+	// Types are the differences per DNS record type sorted by type. Types without differences are omitted.
+	Types []TypeDiff
+}
+
+// Empty reports whether there are no differences.
+func (d *RecordsDiff) Empty() bool {
+	return len(d.Types) == 0
+}
+
+// Changes returns added, changed and removed records of all types as a flat slice.
+func (d *RecordsDiff) Changes() []RecordChange {
+	var changes []RecordChange
+
+	for i := range d.Types {
+		t := &d.Types[i]
+
+		for j := range t.Added {
+			changes = append(changes, RecordChange{Kind: ChangeAdded, New: &t.Added[j]})
+		}
+
+		changes = append(changes, t.Changed...)
+
+		for j := range t.Removed {
+			changes = append(changes, RecordChange{Kind: ChangeRemoved, Old: &t.Removed[j]})
+		}
+	}
+
+	return changes
+}
+
+// Diff returns the difference between the old and the new sets of DNS records.
+// Records are matched by their type, owner name and data; other fields such as TTL are compared
+// to find changed records. Nil sets are treated as empty.
+func Diff(old, new *DNSRecords, opts ...DiffOption) *RecordsDiff {
+	var o diffOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	oldByType := groupByType(old)
+	newByType := groupByType(new)
+
+	types := make([]string, 0, len(oldByType)+len(newByType))
+	for t := range oldByType {
+		types = append(types, t)
+	}
+
+	for t := range newByType {
+		if _, ok := oldByType[t]; !ok {
+			types = append(types, t)
+		}
+	}
+
+	sort.Strings(types)
+
+	result := &RecordsDiff{}
+
+	for _, t := range types {
+		d := diffType(t, oldByType[t], newByType[t], o)
+		if !d.Empty() {
+			result.Types = append(result.Types, d)
+		}
+	}
+
+	return result
+}
+
+// groupByType groups DNS records by their type preserving the order.
+func groupByType(records *DNSRecords) map[string][]DNSRecord {
+	groups := make(map[string][]DNSRecord)
+	if records == nil {
+		return groups
+	}
+
+	for _, record := range records.All {
+		groups[record.CommonFields.DNSType] = append(groups[record.CommonFields.DNSType], record)
+	}
+
+	return groups
+}
+
+// diffType compares records of a single DNS type.
+func diffType(dnsType string, old, new []DNSRecord, o diffOptions) TypeDiff {
+	d := TypeDiff{DNSType: dnsType}
+
+	unmatched := make(map[string][]int, len(old))
+	for i := range old {
+		key := recordKey(&old[i])
+		unmatched[key] = append(unmatched[key], i)
+	}
+
+	matchedOld := make([]bool, len(old))
+
+	// indexes of the matched old records in the order of the new records
+	var order []int
+
+	for i := range new {
+		key := recordKey(&new[i])
+
+		candidates := unmatched[key]
+		if len(candidates) == 0 {
+			d.Added = append(d.Added, new[i])
+			continue
+		}
+
+		j := candidates[0]
+		unmatched[key] = candidates[1:]
+		matchedOld[j] = true
+		order = append(order, j)
+
+		if !equalRecords(&old[j], &new[i], o.ignoreTTL) {
+			d.Changed = append(d.Changed, RecordChange{Kind: ChangeModified, Old: &old[j], New: &new[i]})
+		}
+	}
+
+	for i := range old {
+		if !matchedOld[i] {
+			d.Removed = append(d.Removed, old[i])
+		}
+	}
+
+	if !o.ignoreOrder {
+		d.Reordered = !sort.IntsAreSorted(order)
+	}
+
+	return d
+}
+
+// recordKey returns the identity of the DNS record: its type, owner name and data without the TTL.
+func recordKey(record *DNSRecord) string {
+	return record.Key()
+}
+
+// equalRecords reports whether two DNS records are equal.
+func equalRecords(a, b *DNSRecord, ignoreTTL bool) bool {
+	return bytes.Equal(normalizeRecord(a.Raw, ignoreTTL), normalizeRecord(b.Raw, ignoreTTL))
+}
+
+// normalizeRecord returns the raw record with sorted keys and without insignificant whitespace.
+// If ignoreTTL is set, TTL is removed from the record.
+func normalizeRecord(raw json.RawMessage, ignoreTTL bool) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw
+	}
+
+	if ignoreTTL {
+		delete(fields, "ttl")
+
+		var rawText string
+		if err := json.Unmarshal(fields["rawText"], &rawText); err == nil {
+			if f := strings.Fields(rawText); len(f) >= 4 {
+				f[1] = ""
+				fields["rawText"], _ = json.Marshal(strings.Join(f, " "))
+			}
+		}
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+
+	return b
+}
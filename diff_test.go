@@ -0,0 +1,126 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// summarize returns a compact representation of the diff for comparison.
+func summarize(d *RecordsDiff) []string {
+	var result []string
+
+	for _, t := range d.Types {
+		for _, r := range t.Added {
+			result = append(result, t.DNSType+" added "+r.CommonFields.RawText)
+		}
+
+		for _, c := range t.Changed {
+			result = append(result, t.DNSType+" changed "+c.Old.CommonFields.RawText+" -> "+c.New.CommonFields.RawText)
+		}
+
+		for _, r := range t.Removed {
+			result = append(result, t.DNSType+" removed "+r.CommonFields.RawText)
+		}
+
+		if t.Reordered {
+			result = append(result, t.DNSType+" reordered")
+		}
+	}
+
+	return result
+}
+
+// TestDiff tests the Diff function.
+func TestDiff(t *testing.T) {
+	const (
+		a1     = `{"dnsType":"A","name":"example.com.","ttl":300,"rawText":"example.com. 300 IN A 1.1.1.1","address":"1.1.1.1"}`
+		a1TTL  = `{"dnsType":"A","name":"example.com.","ttl":60,"rawText":"example.com. 60 IN A 1.1.1.1","address":"1.1.1.1"}`
+		a2     = `{"dnsType":"A","name":"example.com.","ttl":300,"rawText":"example.com. 300 IN A 2.2.2.2","address":"2.2.2.2"}`
+		mx     = `{"dnsType":"MX","name":"example.com.","ttl":300,"rawText":"example.com. 300 IN MX 10 mx.example.com.","target":"mx.example.com.","priority":10}`
+		txtRaw = `{"dnsType":"TXT","name":"example.com.","strings":["v=spf1 -all"]}`
+	)
+
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		opts []DiffOption
+		want []string
+	}{
+		{
+			name: "equal",
+			old:  "[" + a1 + "," + mx + "]",
+			new:  "[" + a1 + "," + mx + "]",
+			want: nil,
+		},
+		{
+			name: "added and removed",
+			old:  "[" + a1 + "," + txtRaw + "]",
+			new:  "[" + a2 + "," + mx + "]",
+			want: []string{
+				"A added example.com. 300 IN A 2.2.2.2",
+				"A removed example.com. 300 IN A 1.1.1.1",
+				"MX added example.com. 300 IN MX 10 mx.example.com.",
+				"TXT removed ",
+			},
+		},
+		{
+			name: "ttl changed",
+			old:  "[" + a1 + "]",
+			new:  "[" + a1TTL + "]",
+			want: []string{"A changed example.com. 300 IN A 1.1.1.1 -> example.com. 60 IN A 1.1.1.1"},
+		},
+		{
+			name: "ttl ignored",
+			old:  "[" + a1 + "]",
+			new:  "[" + a1TTL + "]",
+			opts: []DiffOption{DiffIgnoreTTL()},
+			want: nil,
+		},
+		{
+			name: "reordered",
+			old:  "[" + a1 + "," + a2 + "]",
+			new:  "[" + a2 + "," + a1 + "]",
+			want: []string{"A reordered"},
+		},
+		{
+			name: "order ignored",
+			old:  "[" + a1 + "," + a2 + "]",
+			new:  "[" + a2 + "," + a1 + "]",
+			opts: []DiffOption{DiffIgnoreOrder()},
+			want: nil,
+		},
+		{
+			name: "duplicates",
+			old:  "[" + a1 + "]",
+			new:  "[" + a1 + "," + a1 + "]",
+			want: []string{"A added example.com. 300 IN A 1.1.1.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var old, new DNSRecords
+			if err := json.Unmarshal([]byte(tt.old), &old); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := json.Unmarshal([]byte(tt.new), &new); err != nil {
+				t.Fatal(err)
+			}
+
+			d := Diff(&old, &new, tt.opts...)
+			if got := summarize(d); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+
+			if d.Empty() != (tt.want == nil) {
+				t.Errorf("Empty() = %v", d.Empty())
+			}
+		})
+	}
+
+	if d := Diff(nil, nil); !d.Empty() {
+		t.Errorf("Diff(nil, nil) = %v, want empty", d)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+	"unicode"
 )
 
 // DNSLookupService is an interface for DNS Lookup API.
@@ -24,25 +28,41 @@ type Response struct {
 
 	// Body is the byte slice representation of http.Response Body
 	Body []byte
+
+	// Redirects is the chain of URLs the request was redirected to, with the API key redacted
+	Redirects []string
+
+	// KeyID is the masked API key which served the request
+	KeyID string
+
+	// RateLimit is the rate-limit and credit metadata parsed from the response headers
+	RateLimit RateLimit
+
+	// Duration is the time elapsed from sending the request to reading the whole response
+	Duration time.Duration
 }
 
 // dnsLookupServiceOp is the type implementing the DNSLookupService interface.
 type dnsLookupServiceOp struct {
 	client  *Client
 	baseURL *url.URL
+
+	// mirrors are the endpoints the requests are hedged across
+	mirrors    []*url.URL
+	hedgeDelay time.Duration
 }
 
 var _ DNSLookupService = &dnsLookupServiceOp{}
 
 // newRequest creates the API request with default parameters and the specified apiKey.
-func (service *dnsLookupServiceOp) newRequest() (*http.Request, error) {
+func (service *dnsLookupServiceOp) newRequest(apiKey string) (*http.Request, error) {
 	req, err := service.client.NewRequest(http.MethodGet, service.baseURL, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	query := url.Values{}
-	query.Set("apiKey", service.client.apiKey)
+	query.Set("apiKey", apiKey)
 
 	req.URL.RawQuery = query.Encode()
 
@@ -55,15 +75,25 @@ type apiResponse struct {
 	ErrorMessage      `json:"ErrorMessage"`
 }
 
-// request returns intermediate API response for further actions.
-func (service *dnsLookupServiceOp) request(ctx context.Context, domainName string, opts ...Option) (*Response, error) {
-	req, err := service.newRequest()
+// buildRequest creates the API request for the domain name with the options applied.
+// Internationalized domain names are converted to the ASCII form.
+func (service *dnsLookupServiceOp) buildRequest(apiKey, domainName string, opts ...Option) (*http.Request, error) {
+	if err := validateDomainName(domainName); err != nil {
+		return nil, err
+	}
+
+	asciiName, err := service.queryName(domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := service.newRequest(apiKey)
 	if err != nil {
 		return nil, err
 	}
 
 	q := req.URL.Query()
-	q.Set("domainName", domainName)
+	q.Set("domainName", asciiName)
 	q.Set("type", "_all")
 
 	for _, opt := range opts {
@@ -72,20 +102,171 @@ func (service *dnsLookupServiceOp) request(ctx context.Context, domainName strin
 
 	req.URL.RawQuery = q.Encode()
 
-	var b bytes.Buffer
+	if service.client.postForm {
+		toPostForm(req)
+	}
 
-	resp, err := service.client.Do(ctx, req, &b)
+	return req, nil
+}
+
+// queryName returns the domain name sent to the API: the ASCII form of the domain name
+// or its registrable domain if ClientParams.RegistrableDomain is set.
+func (service *dnsLookupServiceOp) queryName(domainName string) (string, error) {
+	asciiName, err := ToASCII(domainName)
 	if err != nil {
-		return &Response{
-			Response: resp,
-			Body:     b.Bytes(),
-		}, err
+		return "", err
+	}
+
+	if service.client.registrableDomain {
+		return RegistrableDomain(asciiName)
+	}
+
+	return asciiName, nil
+}
+
+// request returns intermediate API response for further actions.
+// If the API key fails with an authentication or insufficient credits error, the request is repeated
+// with the next key.
+// The whole loop is limited by the lookup timeout, including the wait for ClientParams.Scheduler
+// and ClientParams.AdaptiveConcurrency.
+func (service *dnsLookupServiceOp) request(
+	ctx context.Context,
+	domainName string,
+	opts ...Option,
+) (resp *Response, err error) {
+	budget := service.client.startLookupBudget(ctx)
+	defer func() {
+		err = budget.done(err)
+	}()
+
+	ctx = budget.ctx
+
+	if scheduler := service.client.scheduler; scheduler != nil {
+		release, err := scheduler.acquire(ctx, domainName)
+		if err != nil {
+			return nil, err
+		}
+
+		defer release()
+	}
+
+	if adaptive := service.client.adaptive; adaptive != nil {
+		var release func(*Response, error)
+		if release, err = adaptive.acquire(ctx); err != nil {
+			return nil, err
+		}
+
+		// the outcome is read when the function returns, before it's wrapped by the lookup budget
+		defer func() {
+			release(resp, err)
+		}()
+	}
+
+	keys := service.client.keys.keys()
+	if len(keys) == 0 {
+		keys = []string{""}
+	}
+
+	for i, key := range keys {
+		budget.attempt()
+
+		resp, err = service.requestWithRetry(ctx, key, i+1, domainName, opts...)
+		if err != nil {
+			return resp, err
+		}
+
+		rotate := len(keys) > 1 && shouldRotate(resp.StatusCode, resp.Body)
+		if !rotate {
+			service.client.keys.use(key)
+		}
+
+		if !rotate || i == len(keys)-1 {
+			return resp, nil
+		}
+
+		service.client.log(ctx, LogEvent{
+			Kind:       LogRetry,
+			KeyID:      resp.KeyID,
+			StatusCode: resp.StatusCode,
+			Attempt:    i + 1,
+		})
+	}
+
+	return nil, errors.New("no API keys")
+}
+
+// requestWithRetry returns intermediate API response for the request made with the API key,
+// repeating it after transient failures if ClientParams.Retry is set.
+func (service *dnsLookupServiceOp) requestWithRetry(
+	ctx context.Context,
+	apiKey string,
+	attempt int,
+	domainName string,
+	opts ...Option,
+) (*Response, error) {
+	retry := service.client.retry
+
+	for retries := 0; ; retries++ {
+		resp, err := service.requestWithKey(ctx, apiKey, attempt, domainName, opts...)
+		if !retry.shouldRetry(ctx, retries, resp, err) {
+			return resp, err
+		}
+
+		event := LogEvent{Kind: LogRetry, KeyID: maskKey(apiKey), Attempt: attempt, Err: err}
+		if resp != nil && resp.Response != nil {
+			event.StatusCode = resp.StatusCode
+		}
+
+		service.client.log(ctx, event)
+
+		if werr := retry.wait(ctx, retry.delay(retries, resp)); werr != nil {
+			return resp, err
+		}
+	}
+}
+
+// requestWithKey returns intermediate API response for the request made with the API key.
+func (service *dnsLookupServiceOp) requestWithKey(
+	ctx context.Context,
+	apiKey string,
+	attempt int,
+	domainName string,
+	opts ...Option,
+) (*Response, error) {
+	req, err := service.buildRequest(apiKey, domainName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if base := baseURLFromContext(ctx); base != nil {
+		req = withBaseURL(req.Context(), req, base)
 	}
 
-	return &Response{
-		Response: resp,
-		Body:     b.Bytes(),
-	}, nil
+	start := time.Now()
+
+	event := LogEvent{Kind: LogRequest, URL: RedactURL(req.URL), KeyID: maskKey(apiKey), Attempt: attempt}
+	service.client.log(ctx, event)
+
+	result := service.send(ctx, req)
+	resp, err := result.resp, result.err
+
+	response := &Response{
+		Response:  resp,
+		Body:      result.body,
+		Redirects: result.trace.urls(),
+		KeyID:     maskKey(apiKey),
+		Duration:  time.Since(start),
+	}
+
+	if resp != nil {
+		response.RateLimit = parseRateLimit(resp.Header, time.Now())
+		event.StatusCode = resp.StatusCode
+	}
+
+	event.Kind, event.Duration, event.Err = LogResponse, response.Duration, err
+	service.client.log(ctx, event)
+
+	return response, err
 }
 
 // parse parses raw DNS Lookup API response.
@@ -101,10 +282,25 @@ func parse(raw []byte) (*apiResponse, error) {
 }
 
 // Get returns parsed DNS Lookup API response.
+// If OptionCallback is set, the JSONP wrapper is stripped before parsing while Response Body is left as is.
 func (service dnsLookupServiceOp) Get(
 	ctx context.Context,
 	domainName string,
 	opts ...Option,
+) (dnsLookupResponse *DNSLookupResponse, resp *Response, err error) {
+	dnsLookupResponse, resp, err = service.lookup(ctx, domainName, opts...)
+	if err != nil || service.client.maxDataAge <= 0 {
+		return dnsLookupResponse, resp, err
+	}
+
+	return service.checkFreshness(ctx, domainName, dnsLookupResponse, resp, opts)
+}
+
+// get makes the lookup and parses the response.
+func (service dnsLookupServiceOp) get(
+	ctx context.Context,
+	domainName string,
+	opts ...Option,
 ) (dnsLookupResponse *DNSLookupResponse, resp *Response, err error) {
 	optsJSON := make([]Option, 0, len(opts)+1)
 	optsJSON = append(optsJSON, opts...)
@@ -112,14 +308,48 @@ func (service dnsLookupServiceOp) Get(
 
 	resp, err = service.request(ctx, domainName, optsJSON...)
 	if err != nil {
+		if errors.Is(err, ErrResponseTooLarge) && resp != nil {
+			err = service.tooLarge(domainName, service.client.bodyTooLarge(resp.Body))
+		}
+
 		return nil, resp, err
 	}
 
-	dnsLookupResp, err := parse(resp.Body)
-	if err != nil {
+	if err = checkResponse(resp.Response, resp.Body); err != nil {
 		return nil, resp, err
 	}
 
+	body := resp.Body
+	if callback := callbackOf(opts); callback != "" {
+		if body, err = StripJSONP(callback, body); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	if err = service.client.checkRecordCount(body); err != nil {
+		return nil, resp, service.tooLarge(domainName, err)
+	}
+
+	var invalidFields []*ParseError
+
+	parseBody := parse
+	if service.client.lazy(ctx) {
+		parseBody = parseLazy
+	}
+
+	dnsLookupResp, err := parseBody(body)
+	if err != nil {
+		service.logParseError(ctx, resp, err)
+
+		if !service.client.partialParsing || service.client.strictParsing {
+			return nil, resp, err
+		}
+
+		if dnsLookupResp, invalidFields = parseLenient(body); dnsLookupResp == nil {
+			return nil, resp, err
+		}
+	}
+
 	if dnsLookupResp.Message != "" || dnsLookupResp.Code != "" {
 		return nil, nil, &ErrorMessage{
 			Code:    dnsLookupResp.Code,
@@ -127,9 +357,85 @@ func (service dnsLookupServiceOp) Get(
 		}
 	}
 
+	service.setDomainNames(&dnsLookupResp.DNSLookupResponse, domainName)
+
+	if service.client.verifyIntegrity {
+		if err = verifyIntegrity(&dnsLookupResp.DNSLookupResponse, opts); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	if service.client.validateSchema {
+		if err = validateSchema(body); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	if service.client.validateJSON {
+		if err = ValidateResponse(body); err != nil {
+			return nil, resp, err
+		}
+	}
+
+	if errs := dnsLookupResp.DNSRecords.ParseErrors(); len(errs) != 0 {
+		service.logParseError(ctx, resp, errs)
+
+		if service.client.strictParsing {
+			return nil, resp, errs
+		}
+	}
+
+	for _, hooks := range [][]DecodeHook{service.client.decodeHooks, decodeHooksFromContext(ctx)} {
+		for _, hook := range hooks {
+			if err = hook(&dnsLookupResp.DNSLookupResponse); err != nil {
+				return nil, resp, fmt.Errorf("decode hook failed: %w", err)
+			}
+		}
+	}
+
+	if service.client.accounting != nil {
+		service.client.accounting.Add(&dnsLookupResp.DNSRecords)
+	}
+
+	if service.client.partialParsing {
+		if partial := newPartialParseError(&dnsLookupResp.DNSRecords, invalidFields); partial != nil {
+			return &dnsLookupResp.DNSLookupResponse, resp, partial
+		}
+	}
+
 	return &dnsLookupResp.DNSLookupResponse, resp, nil
 }
 
+// setDomainNames sets the requested domain name and its forms sent to the API.
+func (service dnsLookupServiceOp) setDomainNames(dnsLookupResponse *DNSLookupResponse, domainName string) {
+	dnsLookupResponse.RequestedDomainName = domainName
+	dnsLookupResponse.ASCIIDomainName, _ = service.queryName(domainName)
+	dnsLookupResponse.UnicodeDomainName = ToUnicode(dnsLookupResponse.ASCIIDomainName)
+}
+
+// tooLarge sets the domain names of the partial response attached to TooLargeError.
+func (service dnsLookupServiceOp) tooLarge(domainName string, err error) error {
+	var tooLarge *TooLargeError
+	if errors.As(err, &tooLarge) && tooLarge.Partial != nil {
+		service.setDomainNames(tooLarge.Partial, domainName)
+	}
+
+	return err
+}
+
+// logParseError logs the error of parsing the response.
+func (service dnsLookupServiceOp) logParseError(ctx context.Context, resp *Response, err error) {
+	event := LogEvent{Kind: LogParseError, KeyID: resp.KeyID, Err: err}
+	if resp.Response != nil {
+		event.StatusCode = resp.StatusCode
+		if resp.Request != nil {
+			event.URL = RedactURL(resp.Request.URL)
+		}
+	}
+
+	service.client.log(ctx, event)
+}
+
 // GetRaw returns raw DNS Lookup API response as Response struct with Body saved as a byte slice.
 func (service dnsLookupServiceOp) GetRaw(
 	ctx context.Context,
@@ -141,7 +447,7 @@ func (service dnsLookupServiceOp) GetRaw(
 		return resp, err
 	}
 
-	if respErr := checkResponse(resp.Response); respErr != nil {
+	if respErr := checkResponse(resp.Response, resp.Body); respErr != nil {
 		return resp, respErr
 	}
 
@@ -158,3 +464,20 @@ type ArgError struct {
 func (a *ArgError) Error() string {
 	return `invalid argument: "` + a.Name + `" ` + a.Message
 }
+
+// validateDomainName rejects domain names which are guaranteed to fail, so no API credit is spent on them.
+// The label syntax is validated by ToASCII.
+func validateDomainName(domainName string) error {
+	switch {
+	case strings.TrimSpace(domainName) == "":
+		return domainNameError("is empty")
+	case strings.Contains(domainName, "://"):
+		return domainNameError("must be a domain name, not a URL: " + quote(domainName))
+	case strings.IndexFunc(domainName, unicode.IsSpace) >= 0:
+		return domainNameError("must not contain spaces: " + quote(domainName))
+	case strings.ContainsAny(domainName, "/?#@:"):
+		return domainNameError("must be a domain name without a port, path or query: " + quote(domainName))
+	}
+
+	return nil
+}
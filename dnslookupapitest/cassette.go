@@ -0,0 +1,157 @@
+package dnslookupapitest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// ErrNoRecording is returned by Replayer if there is no recorded response for the request.
+var ErrNoRecording = errors.New("no recorded response")
+
+// recording is the recorded API response persisted to disk.
+type recording struct {
+	// Query is the request query without the API key
+	Query string `json:"query"`
+
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cassetteKey returns the file name of the recording for the request.
+// Requests are keyed by the domain name and the options; the API key is ignored.
+func cassetteKey(req *http.Request) (name, query string) {
+	q, err := dnslookupapi.RequestParams(req)
+	if err != nil {
+		q = req.URL.Query()
+	}
+
+	q.Del("apiKey")
+	query = q.Encode()
+
+	sum := sha256.Sum256([]byte(query))
+
+	domain := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, q.Get("domainName"))
+
+	return domain + "-" + hex.EncodeToString(sum[:8]) + ".json", query
+}
+
+// Recorder is the http.RoundTripper which persists raw API responses to the directory.
+// Use it as the transport of ClientParams.HTTPClient to record responses for Replayer.
+type Recorder struct {
+	// Dir is the directory the responses are saved to
+	Dir string
+
+	// Transport is the underlying transport. If it's nil then http.DefaultTransport is used
+	Transport http.RoundTripper
+}
+
+// NewRecorder creates Recorder saving responses to the directory.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{Dir: dir}
+}
+
+// RoundTrip executes the request and saves the response.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	name, query := cassetteKey(req)
+
+	data, err := json.MarshalIndent(recording{
+		Query:      query,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(r.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cannot save response: %w", err)
+	}
+
+	if err = os.WriteFile(filepath.Join(r.Dir, name), data, 0o644); err != nil {
+		return nil, fmt.Errorf("cannot save response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Replayer is the http.RoundTripper which serves the responses saved by Recorder.
+// It never accesses the network, so tests using it don't need the API key.
+type Replayer struct {
+	// Dir is the directory the responses are read from
+	Dir string
+}
+
+// NewReplayer creates Replayer serving responses from the directory.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{Dir: dir}
+}
+
+// RoundTrip returns the recorded response for the request.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	name, query := cassetteKey(req)
+
+	data, err := os.ReadFile(filepath.Join(r.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNoRecording, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec recording
+	if err = json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("cannot parse recorded response %s: %w", name, err)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.Header,
+		Body:          io.NopCloser(bytes.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
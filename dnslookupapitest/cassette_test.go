@@ -0,0 +1,52 @@
+package dnslookupapitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// TestRecordReplay tests recording responses and replaying them without the server.
+func TestRecordReplay(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	server := NewServer()
+
+	recorder := NewRecorder(dir)
+	recorder.Transport = server.Server.Client().Transport
+
+	client := dnslookupapi.NewClient("at_record", dnslookupapi.ClientParams{
+		HTTPClient:       &http.Client{Transport: recorder},
+		DNSLookupBaseURL: server.BaseURL(),
+	})
+
+	recorded, _, err := client.Get(ctx, FixtureDomain, dnslookupapi.OptionType("A,MX"))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	server.Close()
+
+	client = dnslookupapi.NewClient("at_replay", dnslookupapi.ClientParams{
+		HTTPClient:       &http.Client{Transport: NewReplayer(dir)},
+		DNSLookupBaseURL: server.BaseURL(),
+	})
+
+	replayed, _, err := client.Get(ctx, FixtureDomain, dnslookupapi.OptionType("A,MX"))
+	if err != nil {
+		t.Fatalf("replayed Get() error = %v", err)
+	}
+
+	if len(replayed.DNSRecords.All) != len(recorded.DNSRecords.All) {
+		t.Errorf("replayed %d records, want %d", len(replayed.DNSRecords.All), len(recorded.DNSRecords.All))
+	}
+
+	_, _, err = client.Get(ctx, FixtureDomain, dnslookupapi.OptionType("NS"))
+	if !errors.Is(err, ErrNoRecording) {
+		t.Errorf("Get() with other options error = %v, want %v", err, ErrNoRecording)
+	}
+}
@@ -0,0 +1,102 @@
+package dnslookupapitest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// TestFake tests the fake DNSLookupService.
+func TestFake(t *testing.T) {
+	fake := NewFake()
+	errFake := errors.New("fake error")
+
+	fake.SetResponse("error.com", ErrorResponse("DNS_02", "API key is invalid"))
+	fake.SetError("down.com", errFake)
+
+	ctx := context.Background()
+
+	resp, _, err := fake.Get(ctx, FixtureDomain, dnslookupapi.OptionType("A"))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if len(resp.DNSRecords.A) != 2 || resp.DNSRecords.A[0].Address != "104.26.13.210" {
+		t.Errorf("Get() A = %v", resp.DNSRecords.A)
+	}
+
+	if resp.DNSRecords.SOA[0].Serial != 2280826063 {
+		t.Errorf("Get() SOA = %v", resp.DNSRecords.SOA)
+	}
+
+	resp, _, err = fake.Get(ctx, "unknown.com")
+	if err != nil || resp.DomainName != "unknown.com" || len(resp.DNSRecords.All) != 0 {
+		t.Errorf("Get(unknown.com) = %v, %v", resp, err)
+	}
+
+	if _, _, err = fake.Get(ctx, "error.com"); !errors.Is(err, dnslookupapi.ErrAuthentication) {
+		t.Errorf("Get(error.com) error = %v, want %v", err, dnslookupapi.ErrAuthentication)
+	}
+
+	if _, err = fake.GetRaw(ctx, "down.com"); !errors.Is(err, errFake) {
+		t.Errorf("GetRaw(down.com) error = %v, want %v", err, errFake)
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 4 || calls[0].DomainName != FixtureDomain || calls[0].Query.Get("type") != "A" {
+		t.Errorf("Calls() = %v", calls)
+	}
+
+	fake.SetLatency(time.Minute)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	if _, err = fake.GetRaw(ctx, FixtureDomain); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetRaw() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+// TestServer tests the test server.
+func TestServer(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.SetResponse("limited.com", http.StatusTooManyRequests, ErrorResponse("DNS_03", "Too many requests"))
+
+	ctx := context.Background()
+
+	resp, _, err := server.Client("at_test").Get(ctx, FixtureDomain)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if len(resp.DNSRecords.All) != 10 || len(resp.DNSRecords.MX) != 2 {
+		t.Errorf("Get() records = %v", resp.DNSRecords.All)
+	}
+
+	if _, err = server.Client("at_test").GetRaw(ctx, "limited.com"); !errors.Is(err, dnslookupapi.ErrThrottled) {
+		t.Errorf("GetRaw(limited.com) error = %v, want %v", err, dnslookupapi.ErrThrottled)
+	}
+
+	if _, err = server.Client("").GetRaw(ctx, FixtureDomain); !errors.Is(err, dnslookupapi.ErrAuthentication) {
+		t.Errorf("GetRaw() without API key error = %v, want %v", err, dnslookupapi.ErrAuthentication)
+	}
+}
+
+// TestFixturesMatchSchema tests that the fixtures match the embedded API schema.
+func TestFixturesMatchSchema(t *testing.T) {
+	for name, raw := range map[string]string{
+		"FixtureResponse": FixtureResponse,
+		"SizedResponse":   SizedResponse(SmallRecords),
+		"ErrorResponse":   ErrorResponse("DNS_02", "API key is invalid"),
+	} {
+		if err := dnslookupapi.ValidateResponse([]byte(raw)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package dnslookupapitest provides a fake DNSLookupService and a test server with realistic fixtures
+// for testing code which uses the DNS Lookup API client.
+package dnslookupapitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// Call is a recorded call of the fake service.
+type Call struct {
+	// DomainName is the requested domain name.
+	DomainName string
+
+	// Query holds the query parameters set by the options.
+	Query url.Values
+}
+
+// Fake is the configurable fake implementation of dnslookupapi.DNSLookupService.
+// Responses are raw API responses per domain name; domains without a response get an empty one.
+// It is safe for concurrent use.
+type Fake struct {
+	mu        sync.Mutex
+	responses map[string]string
+	errors    map[string]error
+	latency   time.Duration
+	calls     []Call
+}
+
+var _ dnslookupapi.DNSLookupService = &Fake{}
+
+// NewFake creates the fake service serving FixtureResponse for FixtureDomain.
+func NewFake() *Fake {
+	return &Fake{
+		responses: map[string]string{FixtureDomain: FixtureResponse},
+		errors:    make(map[string]error),
+	}
+}
+
+// SetResponse sets the raw API response returned for the domain name.
+func (f *Fake) SetResponse(domainName, raw string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses[domainName] = raw
+}
+
+// SetError sets the error returned for the domain name. Nil error removes it.
+func (f *Fake) SetError(domainName string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errors, domainName)
+		return
+	}
+
+	f.errors[domainName] = err
+}
+
+// SetLatency sets the delay before every response.
+func (f *Fake) SetLatency(latency time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.latency = latency
+}
+
+// Calls returns all recorded calls.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]Call(nil), f.calls...)
+}
+
+// GetRaw returns the raw response for the domain name.
+func (f *Fake) GetRaw(ctx context.Context, domainName string, opts ...dnslookupapi.Option) (*dnslookupapi.Response, error) {
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{DomainName: domainName, Query: query})
+	raw, ok := f.responses[domainName]
+	err := f.errors[domainName]
+	latency := f.latency
+	f.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("cannot execute request: %w", ctx.Err())
+		case <-timer.C:
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		raw = EmptyResponse(domainName)
+	}
+
+	return &dnslookupapi.Response{Body: []byte(raw)}, nil
+}
+
+// Get returns the parsed response for the domain name.
+func (f *Fake) Get(ctx context.Context, domainName string, opts ...dnslookupapi.Option) (
+	*dnslookupapi.DNSLookupResponse, *dnslookupapi.Response, error) {
+	resp, err := f.GetRaw(ctx, domainName, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var parsed struct {
+		DNSData      dnslookupapi.DNSLookupResponse `json:"DNSData"`
+		ErrorMessage dnslookupapi.ErrorMessage      `json:"ErrorMessage"`
+	}
+
+	if err = json.NewDecoder(bytes.NewReader(resp.Body)).Decode(&parsed); err != nil {
+		return nil, resp, fmt.Errorf("cannot parse response: %w", err)
+	}
+
+	if parsed.ErrorMessage.Code != "" || parsed.ErrorMessage.Message != "" {
+		return nil, resp, &parsed.ErrorMessage
+	}
+
+	return &parsed.DNSData, resp, nil
+}
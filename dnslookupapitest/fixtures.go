@@ -0,0 +1,125 @@
+package dnslookupapitest
+
+// FixtureDomain is the domain name of the realistic fixture served by default.
+const FixtureDomain = "whoisxmlapi.com"
+
+// FixtureResponse is a realistic DNS Lookup API response for FixtureDomain.
+const FixtureResponse = `{"DNSData":{
+  "domainName": "whoisxmlapi.com",
+  "types": [1, 28, 2, 15, 6, 16, 257],
+  "dnsTypes": "A,AAAA,NS,MX,SOA,TXT,CAA",
+  "audit": {"createdDate": "2022-07-12 11:46:25 UTC", "updatedDate": "2022-07-12 11:46:25 UTC"},
+  "dnsRecords": [
+    {
+      "type": 1,
+      "dnsType": "A",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 1,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009A\u0009104.26.13.210",
+      "address": "104.26.13.210"
+    },
+    {
+      "type": 1,
+      "dnsType": "A",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 1,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009A\u0009104.26.12.210",
+      "address": "104.26.12.210"
+    },
+    {
+      "type": 28,
+      "dnsType": "AAAA",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 28,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009AAAA\u00092606:4700:20::681a:dd2",
+      "address": "2606:4700:20::681a:dd2"
+    },
+    {
+      "type": 2,
+      "dnsType": "NS",
+      "name": "whoisxmlapi.com.",
+      "ttl": 21600,
+      "rRsetType": 2,
+      "rawText": "whoisxmlapi.com.\u000921600\u0009IN\u0009NS\u0009elle.ns.cloudflare.com.",
+      "target": "elle.ns.cloudflare.com."
+    },
+    {
+      "type": 2,
+      "dnsType": "NS",
+      "name": "whoisxmlapi.com.",
+      "ttl": 21600,
+      "rRsetType": 2,
+      "rawText": "whoisxmlapi.com.\u000921600\u0009IN\u0009NS\u0009kurt.ns.cloudflare.com.",
+      "target": "kurt.ns.cloudflare.com."
+    },
+    {
+      "type": 15,
+      "dnsType": "MX",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 15,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009MX\u00091 aspmx.l.google.com.",
+      "target": "aspmx.l.google.com.",
+      "priority": 1
+    },
+    {
+      "type": 15,
+      "dnsType": "MX",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 15,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009MX\u00095 alt1.aspmx.l.google.com.",
+      "target": "alt1.aspmx.l.google.com.",
+      "priority": 5
+    },
+    {
+      "type": 6,
+      "dnsType": "SOA",
+      "name": "whoisxmlapi.com.",
+      "ttl": 3600,
+      "rRsetType": 6,
+      "rawText": "whoisxmlapi.com.\u00093600\u0009IN\u0009SOA\u0009elle.ns.cloudflare.com. dns.cloudflare.com. 2280826063 10000 2400 604800 3600",
+      "admin": "dns.cloudflare.com.",
+      "host": "elle.ns.cloudflare.com.",
+      "expire": 604800,
+      "minimum": 3600,
+      "refresh": 10000,
+      "retry": 2400,
+      "serial": 2280826063
+    },
+    {
+      "type": 16,
+      "dnsType": "TXT",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 16,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009TXT\u0009\"v=spf1 include:_spf.google.com ~all\"",
+      "strings": ["v=spf1 include:_spf.google.com ~all"]
+    },
+    {
+      "type": 257,
+      "dnsType": "CAA",
+      "name": "whoisxmlapi.com.",
+      "ttl": 300,
+      "rRsetType": 257,
+      "rawText": "whoisxmlapi.com.\u0009300\u0009IN\u0009CAA\u00090 issue \"letsencrypt.org\"",
+      "flags": 0,
+      "tag": "issue",
+      "value": "letsencrypt.org"
+    }
+  ]
+}}`
+
+// EmptyResponse returns a DNS Lookup API response without records for the domain.
+func EmptyResponse(domainName string) string {
+	return `{"DNSData":{"domainName":"` + domainName + `","types":[],"dnsTypes":"",` +
+		`"audit":{"createdDate":"","updatedDate":""},"dnsRecords":[]}}`
+}
+
+// ErrorResponse returns a DNS Lookup API error response.
+func ErrorResponse(code, message string) string {
+	return `{"ErrorMessage":{"errorCode":"` + code + `","msg":"` + message + `"}}`
+}
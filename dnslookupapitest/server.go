@@ -0,0 +1,104 @@
+package dnslookupapitest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// fixture is the canned HTTP response of the test server.
+type fixture struct {
+	status int
+	body   string
+}
+
+// Server is the httptest server emulating DNS Lookup API.
+// It serves FixtureResponse for FixtureDomain, an empty response for other domains
+// and the authentication error if the apiKey parameter is missing.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	fixtures map[string]fixture
+}
+
+// NewServer starts and returns the new test server. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		fixtures: map[string]fixture{
+			FixtureDomain: {status: http.StatusOK, body: FixtureResponse},
+		},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// SetResponse sets the status code and the body returned for the domain name.
+func (s *Server) SetResponse(domainName string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fixtures[domainName] = fixture{status: status, body: body}
+}
+
+// BaseURL returns the base URL of the server to be used as ClientParams.DNSLookupBaseURL.
+func (s *Server) BaseURL() *url.URL {
+	u, err := url.Parse(s.Server.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	return u
+}
+
+// Client creates the DNS Lookup API client for the server.
+func (s *Server) Client(apiKey string) *dnslookupapi.Client {
+	return dnslookupapi.NewClient(apiKey, dnslookupapi.ClientParams{
+		HTTPClient:       s.Server.Client(),
+		DNSLookupBaseURL: s.BaseURL(),
+	})
+}
+
+// serveHTTP handles the API requests.
+// Parameters are read from the query string or the form-encoded POST body.
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	query := req.Form
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if query.Get("apiKey") == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(ErrorResponse("WHOIS_01", "Access restricted. Enter the correct API key.")))
+
+		return
+	}
+
+	domainName := query.Get("domainName")
+	if domainName == "" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(ErrorResponse("DNS_01", "domainName is required")))
+
+		return
+	}
+
+	s.mu.Lock()
+	f, ok := s.fixtures[domainName]
+	s.mu.Unlock()
+
+	if !ok {
+		f = fixture{status: http.StatusOK, body: EmptyResponse(domainName)}
+	}
+
+	w.WriteHeader(f.status)
+	_, _ = w.Write([]byte(f.body))
+}
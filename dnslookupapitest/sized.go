@@ -0,0 +1,71 @@
+package dnslookupapitest
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Sizes of the responses generated by SizedResponse, used to benchmark the parser.
+const (
+	SmallRecords  = 10
+	MediumRecords = 500
+	HugeRecords   = 20000
+)
+
+// SizedRecords returns the JSON array of n DNS records for benchmarking. The records are the records
+// of FixtureResponse repeated in order, with the owner names of the repeats prefixed by "hN.", so
+// the output is stable across versions and can be used to compare the parser performance.
+func SizedRecords(n int) string {
+	var fixture struct {
+		DNSData struct {
+			DNSRecords []map[string]interface{} `json:"dnsRecords"`
+		} `json:"DNSData"`
+	}
+
+	if err := json.Unmarshal([]byte(FixtureResponse), &fixture); err != nil {
+		panic(err)
+	}
+
+	records := fixture.DNSData.DNSRecords
+
+	var b strings.Builder
+
+	b.WriteByte('[')
+
+	for i := 0; i < n; i++ {
+		record := make(map[string]interface{}, len(records[i%len(records)]))
+		for k, v := range records[i%len(records)] {
+			record[k] = v
+		}
+
+		if repeat := i / len(records); repeat > 0 {
+			prefix := "h" + strconv.Itoa(repeat) + "."
+			record["name"] = prefix + record["name"].(string)
+			record["rawText"] = prefix + record["rawText"].(string)
+		}
+
+		raw, err := json.Marshal(record)
+		if err != nil {
+			panic(err)
+		}
+
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.Write(raw)
+	}
+
+	b.WriteByte(']')
+
+	return b.String()
+}
+
+// SizedResponse returns the DNS Lookup API response for FixtureDomain with n DNS records.
+// See SizedRecords.
+func SizedResponse(n int) string {
+	return `{"DNSData":{"domainName":"` + FixtureDomain + `","types":[],"dnsTypes":"",` +
+		`"audit":{"createdDate":"2022-07-12 11:46:25 UTC","updatedDate":"2022-07-12 11:46:25 UTC"},` +
+		`"dnsRecords":` + SizedRecords(n) + `}}`
+}
@@ -0,0 +1,390 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+// DNSSEC algorithm numbers (RFC 8624).
+const (
+	DNSSECAlgorithmRSAMD5           = 1
+	DNSSECAlgorithmDSA              = 3
+	DNSSECAlgorithmRSASHA1          = 5
+	DNSSECAlgorithmDSANSEC3SHA1     = 6
+	DNSSECAlgorithmRSASHA1NSEC3SHA1 = 7
+	DNSSECAlgorithmRSASHA256        = 8
+	DNSSECAlgorithmRSASHA512        = 10
+	DNSSECAlgorithmECCGOST          = 12
+	DNSSECAlgorithmECDSAP256SHA256  = 13
+	DNSSECAlgorithmECDSAP384SHA384  = 14
+	DNSSECAlgorithmED25519          = 15
+	DNSSECAlgorithmED448            = 16
+)
+
+// DS digest types (RFC 4034, RFC 4509, RFC 5933, RFC 6605).
+const (
+	DSDigestSHA1   = 1
+	DSDigestSHA256 = 2
+	DSDigestGOST   = 3
+	DSDigestSHA384 = 4
+)
+
+// ErrUnsupportedDSDigestType is returned when the DS digest type is not supported.
+var ErrUnsupportedDSDigestType = errors.New("unsupported DS digest type")
+
+// ErrFieldTooLarge is returned when the key or digest of the record exceeds the size any valid record has.
+// It protects the helpers from oversized payloads.
+var ErrFieldTooLarge = errors.New("record field too large")
+
+const (
+	// maxKeyMaterial is the maximum size of the DNSKEY public key, well above 4096-bit RSA keys.
+	maxKeyMaterial = 4096
+
+	// maxDigestLength is the maximum size of the DS digest, SHA-384 digests are 48 bytes.
+	maxDigestLength = 64
+)
+
+var dnssecAlgorithmNames = map[int]string{
+	DNSSECAlgorithmRSAMD5:           "RSAMD5",
+	DNSSECAlgorithmDSA:              "DSA",
+	DNSSECAlgorithmRSASHA1:          "RSASHA1",
+	DNSSECAlgorithmDSANSEC3SHA1:     "DSA-NSEC3-SHA1",
+	DNSSECAlgorithmRSASHA1NSEC3SHA1: "RSASHA1-NSEC3-SHA1",
+	DNSSECAlgorithmRSASHA256:        "RSASHA256",
+	DNSSECAlgorithmRSASHA512:        "RSASHA512",
+	DNSSECAlgorithmECCGOST:          "ECC-GOST",
+	DNSSECAlgorithmECDSAP256SHA256:  "ECDSAP256SHA256",
+	DNSSECAlgorithmECDSAP384SHA384:  "ECDSAP384SHA384",
+	DNSSECAlgorithmED25519:          "ED25519",
+	DNSSECAlgorithmED448:            "ED448",
+}
+
+// dsDigestLengths are the lengths of the DS digests in bytes.
+var dsDigestLengths = map[int]int{
+	DSDigestSHA1:   20,
+	DSDigestSHA256: 32,
+	DSDigestGOST:   32,
+	DSDigestSHA384: 48,
+}
+
+var dsDigestNames = map[int]string{
+	DSDigestSHA1:   "SHA-1",
+	DSDigestSHA256: "SHA-256",
+	DSDigestGOST:   "GOST R 34.11-94",
+	DSDigestSHA384: "SHA-384",
+}
+
+// DNSSECAlgorithmName returns the mnemonic of the DNSSEC algorithm number, e.g. "ECDSAP256SHA256".
+// Unknown numbers are returned as "ALG" followed by the number.
+func DNSSECAlgorithmName(algorithm int) string {
+	if name, ok := dnssecAlgorithmNames[algorithm]; ok {
+		return name
+	}
+
+	return "ALG" + strconv.Itoa(algorithm)
+}
+
+// DSDigestName returns the name of the DS digest type, e.g. "SHA-256".
+// Unknown types are returned as "DIGEST" followed by the number.
+func DSDigestName(digestType int) string {
+	if name, ok := dsDigestNames[digestType]; ok {
+		return name
+	}
+
+	return "DIGEST" + strconv.Itoa(digestType)
+}
+
+// KeyMaterial returns the decoded public key of the DNSKEY record.
+// If the Key field is not valid base64, the key is taken from the raw text of the record.
+// Errors are returned as *FieldError.
+func (r DNSKEYRecord) KeyMaterial() ([]byte, error) {
+	key, err := decodeLimited(base64.StdEncoding.DecodeString, r.Key, base64.StdEncoding.EncodedLen(maxKeyMaterial))
+	if err == nil && len(key) != 0 {
+		return key, nil
+	}
+
+	if fields := rawTextFields(r.RawText, "DNSKEY"); len(fields) > 3 {
+		key, err = decodeLimited(base64.StdEncoding.DecodeString, fields[3:], base64.StdEncoding.EncodedLen(maxKeyMaterial))
+	} else if err == nil {
+		err = errors.New("empty public key")
+	}
+
+	if err != nil {
+		return nil, &FieldError{DNSType: "DNSKEY", Field: "key", Err: err}
+	}
+
+	return key, nil
+}
+
+// RDATA returns the wire format of the DNSKEY record data.
+func (r DNSKEYRecord) RDATA() ([]byte, error) {
+	key, err := r.KeyMaterial()
+	if err != nil {
+		return nil, err
+	}
+
+	rdata := make([]byte, 4, 4+len(key))
+	binary.BigEndian.PutUint16(rdata, uint16(r.Flags))
+	rdata[2] = byte(r.Protocol)
+	rdata[3] = byte(r.Algorithm)
+
+	return append(rdata, key...), nil
+}
+
+// KeyTag computes the key tag of the DNSKEY record from its key material (RFC 4034 appendix B).
+func (r DNSKEYRecord) KeyTag() (uint16, error) {
+	rdata, err := r.RDATA()
+	if err != nil {
+		return 0, err
+	}
+
+	if r.Algorithm == DNSSECAlgorithmRSAMD5 {
+		if len(rdata) < 7 {
+			return 0, errors.New("DNSKEY public key is too short")
+		}
+
+		return binary.BigEndian.Uint16(rdata[len(rdata)-3:]), nil
+	}
+
+	var ac uint32
+
+	for i, b := range rdata {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+
+	ac += ac >> 16 & 0xFFFF
+
+	return uint16(ac & 0xFFFF), nil
+}
+
+// DS generates the DS record of the DNSKEY record with the digest type (RFC 4034 section 5.1.4).
+// SHA-1, SHA-256 and SHA-384 digests are supported.
+func (r DNSKEYRecord) DS(digestType int) (DSRecord, error) {
+	digest, err := r.dsDigest(digestType)
+	if err != nil {
+		return DSRecord{}, err
+	}
+
+	tag, err := r.KeyTag()
+	if err != nil {
+		return DSRecord{}, err
+	}
+
+	ds := DSRecord{
+		Algorithm: r.Algorithm,
+		Digest:    []string{strings.ToUpper(hex.EncodeToString(digest))},
+		DigestID:  digestType,
+		Footprint: int(tag),
+	}
+
+	ds.Type = 43
+	ds.DNSType = "DS"
+	ds.Name = r.Name
+	ds.TTL = r.TTL
+	ds.RRsetType = 43
+
+	return ds, nil
+}
+
+// dsDigest computes the DS digest of the owner name and the record data.
+func (r DNSKEYRecord) dsDigest(digestType int) ([]byte, error) {
+	var h hash.Hash
+
+	switch digestType {
+	case DSDigestSHA1:
+		h = sha1.New()
+	case DSDigestSHA256:
+		h = sha256.New()
+	case DSDigestSHA384:
+		h = sha512.New384()
+	default:
+		return nil, ErrUnsupportedDSDigestType
+	}
+
+	rdata, err := r.RDATA()
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := canonicalName(r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(owner)
+	h.Write(rdata)
+
+	return h.Sum(nil), nil
+}
+
+// DigestBytes returns the digest of the DS record as a byte slice.
+// If the Digest field is not a hex string, the digest is taken from the raw text of the record.
+// The length of the digest is validated for the known digest types.
+// Errors are returned as *FieldError.
+func (r DSRecord) DigestBytes() ([]byte, error) {
+	return decodeDigest("DS", r.Digest, r.RawText, r.DigestID)
+}
+
+// decodeDigest decodes the hex digest of the DS or DLV record, falling back to the raw text.
+func decodeDigest(dnsType string, parts []string, rawText string, digestType int) ([]byte, error) {
+	digest, err := decodeLimited(hex.DecodeString, parts, hex.EncodedLen(maxDigestLength))
+	if err != nil || len(digest) == 0 {
+		if fields := rawTextFields(rawText, dnsType); len(fields) > 3 {
+			digest, err = decodeLimited(hex.DecodeString, fields[3:], hex.EncodedLen(maxDigestLength))
+		} else if err == nil {
+			err = errors.New("empty digest")
+		}
+	}
+
+	if err == nil {
+		err = checkLength(digest, dsDigestLengths, digestType)
+	}
+
+	if err != nil {
+		return nil, &FieldError{DNSType: dnsType, Field: "digest", Err: err}
+	}
+
+	return digest, nil
+}
+
+// Matches reports whether the DS record corresponds to the DNSKEY record:
+// the key tag, the algorithm and the digest must match.
+func (r DSRecord) Matches(key DNSKEYRecord) (bool, error) {
+	tag, err := key.KeyTag()
+	if err != nil {
+		return false, err
+	}
+
+	if int(tag) != r.Footprint || key.Algorithm != r.Algorithm {
+		return false, nil
+	}
+
+	want, err := r.DigestBytes()
+	if err != nil {
+		return false, err
+	}
+
+	got, err := key.dsDigest(r.DigestID)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(got, want), nil
+}
+
+// DSMatch is the result of checking the DS record against DNSKEY records of the response.
+type DSMatch struct {
+	DS DSRecord
+
+	// Key is the matching DNSKEY record, nil if none matches
+	Key *DNSKEYRecord
+
+	// Err is the error of the last failed check, e.g. ErrUnsupportedDSDigestType
+	Err error
+}
+
+// MatchDS checks every DS record against DNSKEY records with the same owner name within the response.
+func (r *DNSRecords) MatchDS() []DSMatch {
+	matches := make([]DSMatch, 0, len(r.DS))
+
+	// key tags are computed once, so only the keys with the DS footprint are digested
+	tags := make([]int, len(r.DNSKEY))
+	tagErrs := make([]error, len(r.DNSKEY))
+
+	for i, key := range r.DNSKEY {
+		tag, err := key.KeyTag()
+		tags[i], tagErrs[i] = int(tag), err
+	}
+
+	for _, ds := range r.DS {
+		match := DSMatch{DS: ds}
+
+		for i := range r.DNSKEY {
+			key := r.DNSKEY[i]
+			if !strings.EqualFold(strings.TrimSuffix(key.Name, "."), strings.TrimSuffix(ds.Name, ".")) {
+				continue
+			}
+
+			if tagErrs[i] != nil {
+				match.Err = tagErrs[i]
+				continue
+			}
+
+			if tags[i] != ds.Footprint {
+				continue
+			}
+
+			ok, err := ds.Matches(key)
+			if err != nil {
+				match.Err = err
+				continue
+			}
+
+			if ok {
+				match.Key, match.Err = &key, nil
+				break
+			}
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// decodeLimited joins the parts and decodes them if the encoded size is within the limit.
+func decodeLimited(decode func(string) ([]byte, error), parts []string, limit int) ([]byte, error) {
+	n := 0
+	for _, part := range parts {
+		if n += len(part); n > limit {
+			return nil, ErrFieldTooLarge
+		}
+	}
+
+	return decode(strings.Join(parts, ""))
+}
+
+// canonicalName returns the canonical wire format of the domain name (RFC 4034 section 6.2).
+func canonicalName(name string) ([]byte, error) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+
+	var wire []byte
+
+	if name != "" {
+		for _, label := range strings.Split(name, ".") {
+			if label == "" || len(label) > maxLabelLength {
+				return nil, errors.New(`invalid owner name "` + name + `"`)
+			}
+
+			wire = append(wire, byte(len(label)))
+			wire = append(wire, label...)
+		}
+	}
+
+	return append(wire, 0), nil
+}
+
+// rawTextFields returns the fields of the raw text following the record type.
+func rawTextFields(rawText, dnsType string) []string {
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(rawText))
+	for i, field := range fields {
+		if strings.EqualFold(field, dnsType) {
+			return fields[i+1:]
+		}
+	}
+
+	return nil
+}
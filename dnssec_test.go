@@ -0,0 +1,156 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// rfc4034Key is the DNSKEY record from RFC 4034 section 5.4.
+func rfc4034Key() DNSKEYRecord {
+	key := DNSKEYRecord{
+		Flags:     256,
+		Protocol:  3,
+		Algorithm: DNSSECAlgorithmRSASHA1,
+		Key: []string{
+			"AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/",
+			"2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvx",
+			"egXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9Xzc",
+			"nOf+EPbtG9DMBmADjFDc2w/rljwvFw==",
+		},
+	}
+	key.Name = "dskey.example.com."
+	key.DNSType = "DNSKEY"
+
+	return key
+}
+
+// TestDNSKEYKeyTag tests the key tag computation.
+func TestDNSKEYKeyTag(t *testing.T) {
+	tag, err := rfc4034Key().KeyTag()
+	if err != nil || tag != 60485 {
+		t.Errorf("KeyTag() = %v, %v, want 60485", tag, err)
+	}
+
+	fromRaw := DNSKEYRecord{Flags: 256, Protocol: 3, Algorithm: DNSSECAlgorithmRSASHA1}
+	fromRaw.RawText = "dskey.example.com. 86400 IN DNSKEY 256 3 5 ( " + strings.Join(rfc4034Key().Key, " ") + " )"
+
+	if tag, err = fromRaw.KeyTag(); err != nil || tag != 60485 {
+		t.Errorf("KeyTag() from raw text = %v, %v, want 60485", tag, err)
+	}
+
+	if _, err = (DNSKEYRecord{}).KeyTag(); err == nil {
+		t.Error("KeyTag() of empty key expected error")
+	}
+}
+
+// TestDNSKEYDS tests the DS generation and matching.
+func TestDNSKEYDS(t *testing.T) {
+	key := rfc4034Key()
+
+	ds, err := key.DS(DSDigestSHA1)
+	if err != nil {
+		t.Fatalf("DS() error = %v", err)
+	}
+
+	if ds.Footprint != 60485 || ds.Algorithm != 5 || ds.DigestID != 1 || ds.Name != "dskey.example.com." ||
+		ds.Digest[0] != "2BB183AF5F22588179A53B0A98631FAD1A292118" {
+		t.Errorf("DS() = %+v", ds)
+	}
+
+	for _, digestType := range []int{DSDigestSHA1, DSDigestSHA256, DSDigestSHA384} {
+		ds, err = key.DS(digestType)
+		if err != nil {
+			t.Fatalf("DS(%d) error = %v", digestType, err)
+		}
+
+		if ok, err := ds.Matches(key); !ok || err != nil {
+			t.Errorf("DS(%d).Matches() = %v, %v", digestType, ok, err)
+		}
+	}
+
+	if _, err = key.DS(DSDigestGOST); !errors.Is(err, ErrUnsupportedDSDigestType) {
+		t.Errorf("DS(GOST) error = %v, want %v", err, ErrUnsupportedDSDigestType)
+	}
+
+	other := key
+	other.Flags = 257
+
+	if ok, err := ds.Matches(other); ok || err != nil {
+		t.Errorf("Matches() with other key = %v, %v", ok, err)
+	}
+}
+
+// TestMatchDS tests checking DS records against DNSKEY records of the response.
+func TestMatchDS(t *testing.T) {
+	key := rfc4034Key()
+
+	ds, err := key.DS(DSDigestSHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphan := ds
+	orphan.Footprint = 1
+
+	gost := ds
+	gost.DigestID = DSDigestGOST
+
+	records := DNSRecords{DNSKEY: []DNSKEYRecord{key}, DS: []DSRecord{ds, orphan, gost}}
+
+	matches := records.MatchDS()
+	if len(matches) != 3 {
+		t.Fatalf("MatchDS() = %v", matches)
+	}
+
+	if matches[0].Key == nil || matches[0].Err != nil {
+		t.Errorf("MatchDS()[0] = %+v, want match", matches[0])
+	}
+
+	if matches[1].Key != nil || matches[1].Err != nil {
+		t.Errorf("MatchDS()[1] = %+v, want no match", matches[1])
+	}
+
+	if matches[2].Key != nil || !errors.Is(matches[2].Err, ErrUnsupportedDSDigestType) {
+		t.Errorf("MatchDS()[2] = %+v, want unsupported digest", matches[2])
+	}
+}
+
+// TestDNSSECNames tests the algorithm and digest names.
+func TestDNSSECNames(t *testing.T) {
+	if got := DNSSECAlgorithmName(13); got != "ECDSAP256SHA256" {
+		t.Errorf("DNSSECAlgorithmName(13) = %v", got)
+	}
+
+	if got := DNSSECAlgorithmName(200); got != "ALG200" {
+		t.Errorf("DNSSECAlgorithmName(200) = %v", got)
+	}
+
+	if got := DSDigestName(2); got != "SHA-256" {
+		t.Errorf("DSDigestName(2) = %v", got)
+	}
+
+	if got := DSDigestName(9); got != "DIGEST9" {
+		t.Errorf("DSDigestName(9) = %v", got)
+	}
+}
+
+// TestDNSSECFieldLimits tests that oversized keys and digests are rejected before decoding.
+func TestDNSSECFieldLimits(t *testing.T) {
+	key := rfc4034Key()
+	key.Key = []string{strings.Repeat("A", 4096), strings.Repeat("A", 4096)}
+
+	if _, err := key.KeyMaterial(); !errors.Is(err, ErrFieldTooLarge) {
+		t.Errorf("KeyMaterial() error = %v, want ErrFieldTooLarge", err)
+	}
+
+	ds := DSRecord{Digest: []string{strings.Repeat("ab", 65)}}
+	if _, err := ds.DigestBytes(); !errors.Is(err, ErrFieldTooLarge) {
+		t.Errorf("DigestBytes() error = %v, want ErrFieldTooLarge", err)
+	}
+
+	ds.Digest = []string{strings.Repeat("ab", 48)}
+	if digest, err := ds.DigestBytes(); err != nil || len(digest) != 48 {
+		t.Errorf("DigestBytes() = %d bytes, %v", len(digest), err)
+	}
+}
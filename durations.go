@@ -0,0 +1,68 @@
+package dnslookupapi
+
+import "time"
+
+// seconds converts the number of seconds to time.Duration.
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
+// TTLDuration returns the time to live of the record as time.Duration.
+func (c commonFields) TTLDuration() time.Duration {
+	return seconds(c.TTL)
+}
+
+// ExpiresAt returns the time the record expires if it was received at the given time.
+func (c commonFields) ExpiresAt(received time.Time) time.Time {
+	return received.Add(c.TTLDuration())
+}
+
+// RefreshDuration returns the refresh interval of secondary name servers.
+func (r SOARecord) RefreshDuration() time.Duration {
+	return seconds(r.Refresh)
+}
+
+// RetryDuration returns the retry interval of secondary name servers.
+func (r SOARecord) RetryDuration() time.Duration {
+	return seconds(r.Retry)
+}
+
+// ExpireDuration returns the time after which secondary name servers stop answering
+// if the master does not respond.
+func (r SOARecord) ExpireDuration() time.Duration {
+	return seconds(r.Expire)
+}
+
+// MinimumTTL returns the negative response caching TTL.
+func (r SOARecord) MinimumTTL() time.Duration {
+	return seconds(r.Minimum)
+}
+
+// SerialNumber returns the serial number as the unsigned 32-bit integer.
+func (r SOARecord) SerialNumber() uint32 {
+	return uint32(r.Serial)
+}
+
+// CompareSerial compares serial numbers of the records using serial number arithmetic.
+// See CompareSerials.
+func (r SOARecord) CompareSerial(other SOARecord) (cmp int, ok bool) {
+	return CompareSerials(r.SerialNumber(), other.SerialNumber())
+}
+
+// CompareSerials compares the serial numbers according to RFC 1982, so the comparison is correct
+// across the wrap-around of the 32-bit space. It returns -1 if a precedes b, 1 if a follows b and 0
+// if they are equal. ok is false if the comparison is undefined, i.e. the numbers differ by exactly 2^31.
+func CompareSerials(a, b uint32) (cmp int, ok bool) {
+	const half = 1 << 31
+
+	switch diff := b - a; {
+	case diff == 0:
+		return 0, true
+	case diff < half:
+		return -1, true
+	case diff > half:
+		return 1, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,66 @@
+package dnslookupapi
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTTLDuration tests the TTL accessors.
+func TestTTLDuration(t *testing.T) {
+	record := ARecord{commonFields: commonFields{TTL: 300}}
+
+	if got := record.TTLDuration(); got != 5*time.Minute {
+		t.Errorf("TTLDuration() = %v, want 5m", got)
+	}
+
+	received := time.Date(2022, 7, 12, 11, 46, 25, 0, time.UTC)
+	if got := record.ExpiresAt(received); !got.Equal(received.Add(5 * time.Minute)) {
+		t.Errorf("ExpiresAt() = %v", got)
+	}
+}
+
+// TestSOADurations tests the SOA record accessors.
+func TestSOADurations(t *testing.T) {
+	soa := SOARecord{Refresh: 10000, Retry: 2400, Expire: 604800, Minimum: 3600, Serial: 2280826063}
+
+	if soa.RefreshDuration() != 10000*time.Second || soa.RetryDuration() != 40*time.Minute ||
+		soa.ExpireDuration() != 7*24*time.Hour || soa.MinimumTTL() != time.Hour {
+		t.Errorf("durations = %v, %v, %v, %v",
+			soa.RefreshDuration(), soa.RetryDuration(), soa.ExpireDuration(), soa.MinimumTTL())
+	}
+
+	if soa.SerialNumber() != 2280826063 {
+		t.Errorf("SerialNumber() = %v", soa.SerialNumber())
+	}
+}
+
+// TestCompareSerials tests serial number arithmetic.
+func TestCompareSerials(t *testing.T) {
+	tests := []struct {
+		a, b   uint32
+		want   int
+		wantOK bool
+	}{
+		{a: 1, b: 1, want: 0, wantOK: true},
+		{a: 1, b: 2, want: -1, wantOK: true},
+		{a: 2, b: 1, want: 1, wantOK: true},
+		{a: 4294967295, b: 0, want: -1, wantOK: true},
+		{a: 0, b: 4294967295, want: 1, wantOK: true},
+		{a: 2022071201, b: 2022071101, want: 1, wantOK: true},
+		{a: 0, b: 1 << 31, want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := CompareSerials(tt.a, tt.b)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CompareSerials(%d, %d) = %v, %v, want %v, %v", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	older := SOARecord{Serial: 4294967295}
+	newer := SOARecord{Serial: 5}
+
+	if cmp, ok := newer.CompareSerial(older); cmp != 1 || !ok {
+		t.Errorf("CompareSerial() = %v, %v, want 1, true", cmp, ok)
+	}
+}
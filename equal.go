@@ -0,0 +1,139 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net"
+	"strings"
+)
+
+// compareOptions configures the comparison of DNS records.
+type compareOptions struct {
+	ttl bool
+}
+
+// CompareOption configures Equal, Hash and Key.
+type CompareOption func(o *compareOptions)
+
+// CompareTTL makes records which differ only in TTL different. By default TTL is not compared.
+func CompareTTL() CompareOption {
+	return func(o *compareOptions) {
+		o.ttl = true
+	}
+}
+
+// Key returns the canonical identity of the record: its type, owner name and data.
+// Domain names are compared case-insensitively and without the trailing dot, addresses are compared
+// in their canonical form, the raw text is used only for records without parsed data.
+// Records with equal keys are equal, the key is the foundation of Diff, Merge and Dedupe.
+func (r *DNSRecord) Key(opts ...CompareOption) string {
+	raw := r.Raw
+	if len(raw) == 0 {
+		raw, _ = json.Marshal(r.CommonFields)
+	}
+
+	return canonicalKey(raw, opts)
+}
+
+// Equal reports whether the records have the same canonical key.
+func (r *DNSRecord) Equal(other *DNSRecord, opts ...CompareOption) bool {
+	return r.Key(opts...) == other.Key(opts...)
+}
+
+// Hash returns the 64-bit FNV-1a hash of the canonical key, e.g. to build sets of records.
+// Equal records have equal hashes.
+func (r *DNSRecord) Hash(opts ...CompareOption) uint64 {
+	return hashKey(r.Key(opts...))
+}
+
+// EqualRecords reports whether the typed records are equal by the rules of DNSRecord.Equal.
+func EqualRecords(a, b Record, opts ...CompareOption) bool {
+	return recordValueKey(a, opts) == recordValueKey(b, opts)
+}
+
+// HashRecord returns the hash of the typed record by the rules of DNSRecord.Hash.
+func HashRecord(record Record, opts ...CompareOption) uint64 {
+	return hashKey(recordValueKey(record, opts))
+}
+
+// recordValueKey returns the canonical key of the typed record.
+func recordValueKey(record Record, opts []CompareOption) string {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return record.GetDNSType() + "|" + record.GetRawText()
+	}
+
+	return canonicalKey(raw, opts)
+}
+
+// canonicalKey returns the canonical key of the raw record.
+func canonicalKey(raw json.RawMessage, opts []CompareOption) string {
+	var o compareOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return string(raw)
+	}
+
+	var dnsType, name, rawText string
+	_ = json.Unmarshal(fields["dnsType"], &dnsType)
+	_ = json.Unmarshal(fields["name"], &name)
+	_ = json.Unmarshal(fields["rawText"], &rawText)
+
+	ttl := fields["ttl"]
+
+	for _, field := range []string{"type", "dnsType", "name", "ttl", "rRsetType", "rawText"} {
+		delete(fields, field)
+	}
+
+	for _, field := range nameFields {
+		var value string
+		if err := json.Unmarshal(fields[field], &value); err == nil {
+			fields[field], _ = json.Marshal(keyName(value))
+		}
+	}
+
+	var address string
+	if err := json.Unmarshal(fields["address"], &address); err == nil {
+		if ip := net.ParseIP(address); ip != nil {
+			fields["address"], _ = json.Marshal(ip.String())
+		}
+	}
+
+	var data string
+
+	if len(fields) != 0 {
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return string(raw)
+		}
+
+		data = string(b)
+	} else if f := strings.Fields(rawText); len(f) >= 4 {
+		// the raw text is "name ttl class type data...", only the data is not in the common fields
+		data = strings.Join(f[4:], " ")
+	}
+
+	key := strings.ToUpper(dnsType) + "|" + keyName(name) + "|" + data
+	if o.ttl {
+		key += "|" + string(ttl)
+	}
+
+	return key
+}
+
+// keyName returns the domain name in lower case without the trailing dot.
+func keyName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// hashKey returns the FNV-1a hash of the key.
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	return h.Sum64()
+}
@@ -0,0 +1,95 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestRecordEqual tests the canonical comparison and hashing of records.
+func TestRecordEqual(t *testing.T) {
+	parse := func(s string) *DNSRecord {
+		var r DNSRecords
+		if err := json.Unmarshal([]byte("["+s+"]"), &r); err != nil {
+			t.Fatal(err)
+		}
+
+		return &r.All[0]
+	}
+
+	const mx = `{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,` +
+		`"rawText":"example.com.\t300\tIN\tMX\t10 mx.example.com.","target":"mx.example.com.","priority":10}`
+
+	tests := []struct {
+		name    string
+		a, b    string
+		opts    []CompareOption
+		want    bool
+		wantTTL bool
+	}{
+		{
+			name: "same", a: mx, b: mx, want: true, wantTTL: true,
+		},
+		{
+			name: "names case and trailing dot",
+			a:    mx,
+			b:    `{"type":15,"dnsType":"MX","name":"EXAMPLE.com","ttl":300,"target":"MX.Example.com","priority":10}`,
+			want: true, wantTTL: true,
+		},
+		{
+			name: "TTL",
+			a:    mx,
+			b:    `{"type":15,"dnsType":"MX","name":"example.com.","ttl":60,"target":"mx.example.com.","priority":10}`,
+			want: true,
+		},
+		{
+			name: "data",
+			a:    mx,
+			b:    `{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"target":"mx.example.com.","priority":20}`,
+		},
+		{
+			name: "canonical address",
+			a:    `{"type":28,"dnsType":"AAAA","name":"example.com.","ttl":300,"address":"2001:DB8:0:0::1"}`,
+			b:    `{"type":28,"dnsType":"AAAA","name":"example.com.","ttl":300,"address":"2001:db8::1"}`,
+			want: true, wantTTL: true,
+		},
+		{
+			name: "TXT is case-sensitive",
+			a:    `{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"strings":["v=spf1 -all"]}`,
+			b:    `{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"strings":["V=SPF1 -ALL"]}`,
+		},
+		{
+			name: "raw text of unparsed data",
+			a:    `{"type":99,"dnsType":"SPF","name":"example.com.","ttl":300,"rawText":"example.com. 300 IN SPF x"}`,
+			b:    `{"type":99,"dnsType":"SPF","name":"Example.com","ttl":60,"rawText":"Example.com 60 IN SPF x"}`,
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := parse(tt.a), parse(tt.b)
+
+			if got := a.Equal(b); got != tt.want || (a.Hash() == b.Hash()) != tt.want {
+				t.Errorf("Equal() = %v, want %v; keys %q, %q", got, tt.want, a.Key(), b.Key())
+			}
+
+			if got := a.Equal(b, CompareTTL()); got != tt.wantTTL {
+				t.Errorf("Equal(CompareTTL) = %v, want %v", got, tt.wantTTL)
+			}
+		})
+	}
+}
+
+// TestEqualRecords tests the comparison of typed records.
+func TestEqualRecords(t *testing.T) {
+	a := NSRecord{commonFields: commonFields{DNSType: "NS", Name: "example.com.", TTL: 300}, Target: "NS1.example.com."}
+	b := NSRecord{commonFields: commonFields{DNSType: "NS", Name: "example.com", TTL: 60}, Target: "ns1.example.com"}
+
+	if !EqualRecords(a, b) || HashRecord(a) != HashRecord(b) {
+		t.Error("EqualRecords() = false, want true")
+	}
+
+	if EqualRecords(a, b, CompareTTL()) {
+		t.Error("EqualRecords(CompareTTL) = true, want false")
+	}
+}
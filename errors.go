@@ -0,0 +1,264 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Errors for known API failures. Use errors.Is to check whether ErrorMessage or ErrorResponse is one of them.
+var (
+	// ErrAuthentication means that the API key is missing or invalid.
+	ErrAuthentication = errors.New("authentication failed")
+
+	// ErrInsufficientCredits means that the account has run out of credits.
+	ErrInsufficientCredits = errors.New("insufficient credits")
+
+	// ErrInvalidDomain means that the domain name or other input parameter is not valid.
+	ErrInvalidDomain = errors.New("invalid domain name")
+
+	// ErrThrottled means that the request rate limit is exceeded.
+	ErrThrottled = errors.New("request throttled")
+)
+
+// errorCodes are the known error codes of the API. WhoisXML API endpoints report the HTTP status code
+// in the code field of the error body. Code 403 is used both for invalid keys and for exhausted credits.
+var errorCodes = map[string]error{
+	"400": ErrInvalidDomain,
+	"401": ErrAuthentication,
+	"402": ErrInsufficientCredits,
+	"422": ErrInvalidDomain,
+	"429": ErrThrottled,
+}
+
+// classifyError returns the known error matching the error code, the status code or, as a last resort,
+// the error message. It returns nil if the error is not recognized.
+func classifyError(statusCode int, code, message string) error {
+	known, restricted := classifyCode(statusCode, code)
+	if known != nil {
+		return known
+	}
+
+	known = classifyMessage(message)
+
+	if restricted {
+		if known == ErrInsufficientCredits {
+			return known
+		}
+
+		return ErrAuthentication
+	}
+
+	return known
+}
+
+// classifyCode returns the known error matching the error code or the status code.
+// restricted is true for the access restricted code 403 that means either an authentication or a credit failure.
+func classifyCode(statusCode int, code string) (known error, restricted bool) {
+	code = strings.TrimSpace(code)
+
+	if known, ok := errorCodes[code]; ok {
+		return known, false
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrAuthentication, false
+	case http.StatusPaymentRequired:
+		return ErrInsufficientCredits, false
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidDomain, false
+	case http.StatusTooManyRequests:
+		return ErrThrottled, false
+	case http.StatusForbidden:
+		return nil, true
+	}
+
+	return nil, code == strconv.Itoa(http.StatusForbidden)
+}
+
+// classifyMessage returns the known error matching the words of the error message.
+// Words containing dots are skipped because the API echoes the domain name in error messages.
+func classifyMessage(message string) error {
+	var words []string
+
+	for _, field := range strings.Fields(strings.ToLower(message)) {
+		if strings.Contains(strings.TrimRight(field, ".,;:!?\"')"), ".") {
+			continue
+		}
+
+		words = append(words, strings.FieldsFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+
+	text := " " + strings.Join(words, " ") + " "
+
+	has := func(phrases ...string) bool {
+		for _, phrase := range phrases {
+			if strings.Contains(text, " "+phrase+" ") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch {
+	case has("domain") && has("invalid", "incorrect", "not valid"):
+		return ErrInvalidDomain
+	case has("credits", "insufficient balance", "credits balance"):
+		return ErrInsufficientCredits
+	case has("api key", "apikey"):
+		return ErrAuthentication
+	case has("too many requests", "rate limit"):
+		return ErrThrottled
+	}
+
+	return nil
+}
+
+// Is reports whether the API error matches the target known error.
+func (e *ErrorMessage) Is(target error) bool {
+	known := classifyError(0, e.Code, e.Message)
+
+	return known != nil && known == target
+}
+
+// Is reports whether the API error matches the target known error.
+func (e *ErrorResponse) Is(target error) bool {
+	var statusCode int
+	if e.Response != nil {
+		statusCode = e.Response.StatusCode
+	}
+
+	known := classifyError(statusCode, e.Code, e.Message)
+
+	return known != nil && known == target
+}
+
+// errorBody is the error body returned by the API with non-2xx status codes.
+type errorBody struct {
+	ErrorMessage *ErrorMessage `json:"ErrorMessage"`
+
+	// Code and Messages are used by other WhoisXML API endpoints.
+	Code     json.RawMessage `json:"code"`
+	Messages json.RawMessage `json:"messages"`
+}
+
+// maxErrorBody is the maximum length of ErrorResponse.Body.
+const maxErrorBody = 512
+
+// xmlErrorBody is the error body returned by the API in the XML output format.
+type xmlErrorBody struct {
+	XMLName xml.Name
+
+	// ErrorCode and Msg are used by the ErrorMessage element.
+	ErrorCode string `xml:"errorCode"`
+	Msg       string `xml:"msg"`
+
+	// Code and Messages are used by other WhoisXML API endpoints.
+	Code     string   `xml:"code"`
+	Messages []string `xml:"messages"`
+}
+
+// parseErrorBody extracts the error code and message from the JSON, XML or plain text error body.
+func parseErrorBody(body []byte) (code, message string) {
+	trimmed := bytes.TrimSpace(body)
+
+	switch {
+	case len(trimmed) == 0:
+		return "", ""
+	case trimmed[0] == '{':
+		return parseJSONErrorBody(trimmed)
+	case trimmed[0] == '<':
+		return parseXMLErrorBody(trimmed)
+	}
+
+	// plain text bodies are short messages, e.g. from proxies
+	line := string(trimmed)
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	return "", truncate(strings.TrimSpace(line), maxErrorBody)
+}
+
+// parseJSONErrorBody extracts the error code and message from the JSON error body.
+func parseJSONErrorBody(body []byte) (code, message string) {
+	var b errorBody
+	if err := json.Unmarshal(body, &b); err != nil {
+		return "", ""
+	}
+
+	if b.ErrorMessage != nil {
+		return b.ErrorMessage.Code, b.ErrorMessage.Message
+	}
+
+	code = strings.Trim(string(b.Code), `"`)
+
+	var messages []string
+	if err := json.Unmarshal(b.Messages, &messages); err == nil {
+		return code, strings.Join(messages, "; ")
+	}
+
+	var msg string
+	_ = json.Unmarshal(b.Messages, &msg)
+
+	return code, msg
+}
+
+// parseXMLErrorBody extracts the error code and message from the XML error body
+// or the title of the HTML error page.
+func parseXMLErrorBody(body []byte) (code, message string) {
+	var b xmlErrorBody
+	if err := xml.Unmarshal(body, &b); err == nil {
+		if b.ErrorCode != "" || b.Msg != "" {
+			return strings.TrimSpace(b.ErrorCode), strings.TrimSpace(b.Msg)
+		}
+
+		if b.Code != "" || len(b.Messages) != 0 {
+			return strings.TrimSpace(b.Code), strings.TrimSpace(strings.Join(b.Messages, "; "))
+		}
+	}
+
+	lower := bytes.ToLower(body)
+
+	start := bytes.Index(lower, []byte("<title>"))
+	if start < 0 {
+		return "", ""
+	}
+
+	start += len("<title>")
+
+	end := bytes.Index(lower[start:], []byte("</title>"))
+	if end < 0 {
+		return "", ""
+	}
+
+	return "", truncate(strings.Join(strings.Fields(string(body[start:start+end])), " "), maxErrorBody)
+}
+
+// errorBodyExcerpt returns the body with collapsed whitespace truncated to maxErrorBody bytes.
+func errorBodyExcerpt(body []byte) string {
+	return truncate(strings.Join(strings.Fields(string(body)), " "), maxErrorBody)
+}
+
+// truncate returns s truncated to n bytes at a rune boundary with "..." appended if it was longer.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return s[:n] + "..."
+}
@@ -0,0 +1,181 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestErrorsIs tests errors.Is support of the API errors.
+func TestErrorsIs(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "credits message",
+			err:  &ErrorMessage{Code: "403", Message: "Access restricted. Check credits balance or enter the correct API key."},
+			want: ErrInsufficientCredits,
+		},
+		{
+			name: "api key message",
+			err:  &ErrorMessage{Message: "ApiKey authenticate failed"},
+			want: ErrAuthentication,
+		},
+		{
+			name: "invalid domain message",
+			err:  &ErrorMessage{Code: "DNS_02", Message: "Invalid domain name"},
+			want: ErrInvalidDomain,
+		},
+		{
+			name: "invalid domain message echoing credit",
+			err:  &ErrorMessage{Message: "Invalid domain name: mycredit.com"},
+			want: ErrInvalidDomain,
+		},
+		{
+			name: "invalid domain message echoing api key",
+			err:  &ErrorMessage{Message: "Domain name apikeys.io is not valid."},
+			want: ErrInvalidDomain,
+		},
+		{
+			name: "unknown message echoing credits",
+			err:  &ErrorMessage{Message: "No data for credits.balance.example"},
+			want: nil,
+		},
+		{
+			name: "restricted code",
+			err:  &ErrorMessage{Code: "403", Message: "Access restricted."},
+			want: ErrAuthentication,
+		},
+		{
+			name: "invalid domain status echoing credit",
+			err: &ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+				Code: "422", Message: "Invalid domain name: credit-union.com"},
+			want: ErrInvalidDomain,
+		},
+		{
+			name: "unknown message",
+			err:  &ErrorMessage{Code: "TEST_CODE", Message: "test error message"},
+			want: nil,
+		},
+		{
+			name: "unauthorized status",
+			err:  &ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnauthorized}},
+			want: ErrAuthentication,
+		},
+		{
+			name: "too many requests status",
+			err:  &ErrorResponse{Response: &http.Response{StatusCode: http.StatusTooManyRequests}},
+			want: ErrThrottled,
+		},
+		{
+			name: "unprocessable entity status",
+			err:  &ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}},
+			want: ErrInvalidDomain,
+		},
+		{
+			name: "server error status",
+			err:  &ErrorResponse{Response: &http.Response{StatusCode: http.StatusInternalServerError}},
+			want: nil,
+		},
+	}
+
+	known := []error{ErrAuthentication, ErrInsufficientCredits, ErrInvalidDomain, ErrThrottled}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, target := range known {
+				if got := errors.Is(tt.err, target); got != (target == tt.want) {
+					t.Errorf("errors.Is(%v) = %v, want %v", target, got, target == tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestParseErrorBody tests the parseErrorBody function.
+func TestParseErrorBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:        "error message",
+			body:        `{"ErrorMessage":{"errorCode":"TEST_CODE","msg":"test error message"}}`,
+			wantCode:    "TEST_CODE",
+			wantMessage: "test error message",
+		},
+		{
+			name:        "code and messages",
+			body:        `{"code":403,"messages":"Access restricted."}`,
+			wantCode:    "403",
+			wantMessage: "Access restricted.",
+		},
+		{
+			name:        "messages list",
+			body:        `{"code":"422","messages":["bad domain","bad type"]}`,
+			wantCode:    "422",
+			wantMessage: "bad domain; bad type",
+		},
+		{
+			name: "unparsable",
+			body: `<html></html>`,
+		},
+		{
+			name:        "xml error message",
+			body:        `<?xml version="1.0"?><ErrorMessage><errorCode>AUTH_2</errorCode><msg>Bad key</msg></ErrorMessage>`,
+			wantCode:    "AUTH_2",
+			wantMessage: "Bad key",
+		},
+		{
+			name:        "xml code and messages",
+			body:        `<error><code>422</code><messages>bad domain</messages><messages>bad type</messages></error>`,
+			wantCode:    "422",
+			wantMessage: "bad domain; bad type",
+		},
+		{
+			name:        "html page",
+			body:        "<html><head><TITLE>502 Bad\n Gateway</TITLE></head><body><hr></body></html>",
+			wantMessage: "502 Bad Gateway",
+		},
+		{
+			name:        "plain text",
+			body:        "  upstream connect error\nreset reason: overflow",
+			wantMessage: "upstream connect error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, message := parseErrorBody([]byte(tt.body))
+			if code != tt.wantCode || message != tt.wantMessage {
+				t.Errorf("parseErrorBody() = %v, %v, want %v, %v", code, message, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// TestErrorResponseBody tests the body excerpt of ErrorResponse.
+func TestErrorResponseBody(t *testing.T) {
+	body := "<html>\n<body>" + strings.Repeat("é", 300) + "</body>\n</html>"
+
+	err := checkResponse(&http.Response{StatusCode: http.StatusBadGateway}, []byte(body))
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("checkResponse() error = %v", err)
+	}
+
+	if !strings.HasPrefix(errResp.Body, "<html> <body>é") || !strings.HasSuffix(errResp.Body, "...") ||
+		len(errResp.Body) > maxErrorBody+3 || !utf8.ValidString(errResp.Body) {
+		t.Errorf("Body = %q", errResp.Body)
+	}
+
+	if errResp.Error() != "API failed with status code: 502" {
+		t.Errorf("Error() = %v", errResp.Error())
+	}
+}
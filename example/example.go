@@ -23,6 +23,10 @@ func GetData(apikey string) {
 			log.Println(apiErr.Code)
 			log.Println(apiErr.Message)
 		}
+		// Or branch on known API failures
+		if errors.Is(err, dnslookupapi.ErrInsufficientCredits) {
+			log.Fatal("out of credits")
+		}
 		log.Fatal(err)
 	}
 
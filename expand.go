@@ -0,0 +1,219 @@
+package dnslookupapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// CommonPrefixes are the host name prefixes commonly found under a domain,
+// including mail authentication records and popular DKIM selectors.
+var CommonPrefixes = []string{
+	"www", "mail", "smtp", "imap", "pop", "mx", "webmail", "autodiscover", "ftp", "vpn",
+	"api", "dev", "staging", "admin", "portal", "cdn",
+	"_dmarc", "_mta-sts", "_smtp._tls", "default._bimi",
+	"default._domainkey", "google._domainkey", "selector1._domainkey", "selector2._domainkey",
+	"k1._domainkey", "s1._domainkey", "s2._domainkey",
+}
+
+// HostNode is the node of the host name tree built by Expand.
+// Intermediate nodes which were not looked up, e.g. _domainkey, have no Response and no Err.
+type HostNode struct {
+	// Label is the leftmost label of the host name, the root node has the base domain as the label.
+	Label string
+
+	// Name is the full host name.
+	Name string
+
+	// Response is the parsed response for the host name.
+	Response *DNSLookupResponse
+
+	// Err is the error of the lookup.
+	Err error
+
+	// Wildcard reports whether the address records of the host match the wildcard records of the domain,
+	// i.e. the host most likely does not exist on its own.
+	Wildcard bool
+
+	// Children are the subordinate host names sorted by label.
+	Children []*HostNode
+}
+
+// Find returns the node of the host name or nil.
+func (n *HostNode) Find(name string) *HostNode {
+	var found *HostNode
+
+	n.Walk(func(node *HostNode) bool {
+		if strings.EqualFold(node.Name, name) {
+			found = node
+			return false
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// Walk calls fn for the node and all its descendants in depth-first order until fn returns false.
+func (n *HostNode) Walk(fn func(*HostNode) bool) bool {
+	if !fn(n) {
+		return false
+	}
+
+	for _, child := range n.Children {
+		if !child.Walk(fn) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// child returns the child node with the label, creating it if needed.
+func (n *HostNode) child(label string) *HostNode {
+	for _, c := range n.Children {
+		if c.Label == label {
+			return c
+		}
+	}
+
+	c := &HostNode{Label: label, Name: label + "." + n.Name}
+	n.Children = append(n.Children, c)
+
+	return c
+}
+
+// sort sorts the children recursively.
+func (n *HostNode) sort() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		return n.Children[i].Label < n.Children[j].Label
+	})
+
+	for _, c := range n.Children {
+		c.sort()
+	}
+}
+
+// Expansion is the result of Expand.
+type Expansion struct {
+	// Root is the node of the base domain.
+	Root *HostNode
+
+	// Wildcard is the response for a random label under the base domain,
+	// nil if the domain has no wildcard address records.
+	Wildcard *DNSLookupResponse
+}
+
+// Expand looks up the base domain and the host names built from the prefixes, e.g. CommonPrefixes,
+// concurrently via GetMany and assembles the results into the host name tree.
+// A random label is looked up as well to detect wildcard records.
+func Expand(
+	ctx context.Context,
+	service DNSLookupService,
+	baseDomain string,
+	prefixes []string,
+	concurrency int,
+	opts ...Option,
+) (*Expansion, error) {
+	if err := validateDomainName(baseDomain); err != nil {
+		return nil, err
+	}
+
+	baseDomain = strings.TrimSuffix(strings.ToLower(baseDomain), ".")
+
+	probe, err := wildcardProbe()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{baseDomain, probe + "." + baseDomain}
+	for _, prefix := range prefixes {
+		if prefix = strings.Trim(strings.ToLower(prefix), "."); prefix != "" {
+			names = append(names, prefix+"."+baseDomain)
+		}
+	}
+
+	results := GetMany(ctx, service, names, concurrency, opts...)
+
+	expansion := &Expansion{Root: &HostNode{Label: baseDomain, Name: baseDomain}}
+
+	if wildcard := results[1].Response; wildcard != nil && len(addressValues(wildcard)) != 0 {
+		expansion.Wildcard = wildcard
+	}
+
+	for i, result := range results {
+		if i == 1 {
+			continue
+		}
+
+		node := expansion.Root
+
+		if i > 1 {
+			labels := strings.Split(strings.TrimSuffix(result.DomainName, "."+baseDomain), ".")
+			for j := len(labels) - 1; j >= 0; j-- {
+				node = node.child(labels[j])
+			}
+		}
+
+		node.Response, node.Err = result.Response, result.Err
+
+		if expansion.Wildcard != nil && node.Response != nil {
+			node.Wildcard = equalStrings(addressValues(node.Response), addressValues(expansion.Wildcard))
+		}
+	}
+
+	expansion.Root.sort()
+
+	return expansion, nil
+}
+
+// wildcardProbe returns a random label which is unlikely to exist.
+func wildcardProbe() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "wildcard-probe-" + hex.EncodeToString(b), nil
+}
+
+// addressValues returns the sorted A, AAAA and CNAME values of the response.
+func addressValues(resp *DNSLookupResponse) []string {
+	var values []string
+
+	records := resp.ParsedRecords()
+
+	for _, r := range records.A {
+		values = append(values, "A "+r.Address)
+	}
+
+	for _, r := range records.AAAA {
+		values = append(values, "AAAA "+r.Address)
+	}
+
+	for _, r := range records.CNAME {
+		values = append(values, "CNAME "+strings.ToLower(r.Target))
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// equalStrings reports whether the slices are equal.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
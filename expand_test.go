@@ -0,0 +1,73 @@
+package dnslookupapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestExpand tests expanding the base domain into the host name tree.
+func TestExpand(t *testing.T) {
+	const wildcardA = `[{"type":1,"dnsType":"A","name":"*.example.com.","address":"9.9.9.9"}]`
+
+	service := &hostService{records: map[string]string{
+		"example.com":     `[{"type":1,"dnsType":"A","name":"example.com.","address":"1.1.1.1"}]`,
+		"www.example.com": `[{"type":5,"dnsType":"CNAME","name":"www.example.com.","target":"example.com."}]`,
+		"_dmarc.example.com": `[{"type":16,"dnsType":"TXT","name":"_dmarc.example.com.",` +
+			`"strings":["v=DMARC1; p=reject"]}]`,
+		"google._domainkey.example.com": `[{"type":16,"dnsType":"TXT","name":"google._domainkey.example.com.",` +
+			`"strings":["v=DKIM1; p=MIIB"]}]`,
+		"dev.example.com": wildcardA,
+	}}
+
+	service.probe = wildcardA
+
+	expansion, err := Expand(context.Background(), service, "Example.com.",
+		[]string{"www", "_dmarc", "google._domainkey", "dev", "fail"}, 3)
+	if err != nil {
+		t.Fatalf("Expand() error = %v", err)
+	}
+
+	root := expansion.Root
+	if root.Name != "example.com" || root.Response == nil || len(root.Response.DNSRecords.A) != 1 {
+		t.Fatalf("Expand() root = %+v", root)
+	}
+
+	if expansion.Wildcard == nil {
+		t.Error("Expand() wildcard not detected")
+	}
+
+	var labels []string
+	for _, c := range root.Children {
+		labels = append(labels, c.Label)
+	}
+
+	if got := strings.Join(labels, ","); got != "_dmarc,_domainkey,dev,fail,www" {
+		t.Errorf("Expand() children = %v", got)
+	}
+
+	dkim := root.Find("google._domainkey.example.com")
+	if dkim == nil || dkim.Response == nil || len(dkim.Response.DNSRecords.TXT) != 1 {
+		t.Errorf("Find(google._domainkey) = %+v", dkim)
+	}
+
+	if intermediate := root.Find("_domainkey.example.com"); intermediate == nil || intermediate.Response != nil {
+		t.Errorf("Find(_domainkey) = %+v", intermediate)
+	}
+
+	if dev := root.Find("dev.example.com"); dev == nil || !dev.Wildcard {
+		t.Errorf("Find(dev) = %+v, want wildcard", dev)
+	}
+
+	if www := root.Find("www.example.com"); www == nil || www.Wildcard {
+		t.Errorf("Find(www) = %+v, want not wildcard", www)
+	}
+
+	if fail := root.Find("fail.example.com"); fail == nil || fail.Err == nil {
+		t.Errorf("Find(fail) = %+v, want error", fail)
+	}
+
+	if _, err = Expand(context.Background(), service, "", nil, 1); err == nil {
+		t.Error("Expand() expected error for empty base domain")
+	}
+}
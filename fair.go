@@ -0,0 +1,254 @@
+package dnslookupapi
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// TenantTag is the tag key identifying the tenant of the call for FairScheduler, see WithTag.
+const TenantTag = "tenant"
+
+// FairScheduler limits the number of concurrent lookups of the clients sharing it and serves the waiting lookups
+// fairly, so a huge batch of one tenant doesn't starve the interactive lookups of the others.
+// Waiting lookups are served by priority set with WithPriority first, then in proportion to the tenant weights,
+// and in order within the tenant.
+// It is safe for concurrent use and can be shared by several clients.
+type FairScheduler struct {
+	// Concurrency is the maximum number of concurrent lookups
+	// If it's zero then 4 is used
+	Concurrency int
+
+	// Weights are the relative shares of the tenants, e.g. 3 serves the tenant three times as often as 1
+	// The tenants without the weight have the weight of 1
+	Weights map[string]int
+
+	// Key returns the queue of the lookup, e.g. the tenant ID or the registrable domain
+	// If it's nil then the TenantTag tag of the context is used
+	Key func(ctx context.Context, domainName string) string
+
+	// OnQueueDepth is called when the number of the lookups waiting in the queue changes,
+	// e.g. to export the depth as a metric
+	// It's called synchronously and must not use the scheduler
+	OnQueueDepth func(key string, depth int)
+
+	mu     sync.Mutex
+	active int
+	queues map[string]*fairQueue
+
+	// pass is the virtual time of the last served lookup
+	pass float64
+}
+
+// fairQueue is the queue of the waiting lookups of a single key.
+type fairQueue struct {
+	key     string
+	waiters [3][]*fairWaiter
+	depth   int
+
+	// pass is the virtual time of the next lookup of the queue
+	pass float64
+}
+
+// fairWaiter is the waiting lookup.
+type fairWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// QueueDepth returns the number of the lookups waiting in the queue.
+func (s *FairScheduler) QueueDepth(key string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if q, ok := s.queues[key]; ok {
+		return q.depth
+	}
+
+	return 0
+}
+
+// QueueDepths returns the number of the waiting lookups per queue. Empty queues are omitted.
+func (s *FairScheduler) QueueDepths() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	depths := make(map[string]int, len(s.queues))
+	for key, q := range s.queues {
+		depths[key] = q.depth
+	}
+
+	return depths
+}
+
+// acquire waits for the slot of the lookup and returns the function releasing it.
+func (s *FairScheduler) acquire(ctx context.Context, domainName string) (func(), error) {
+	key := s.key(ctx, domainName)
+
+	s.mu.Lock()
+
+	if s.active < s.concurrency() && len(s.queues) == 0 {
+		s.active++
+		s.mu.Unlock()
+
+		return s.release, nil
+	}
+
+	q, ok := s.queues[key]
+	if !ok {
+		if s.queues == nil {
+			s.queues = make(map[string]*fairQueue)
+		}
+
+		q = &fairQueue{key: key, pass: s.pass}
+		s.queues[key] = q
+	}
+
+	w := &fairWaiter{ready: make(chan struct{})}
+	rank := priorityRank(PriorityFromContext(ctx))
+	q.waiters[rank] = append(q.waiters[rank], w)
+	s.setDepth(q, q.depth+1)
+
+	s.dispatch()
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return s.release, nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if w.granted {
+		s.active--
+		s.dispatch()
+	} else {
+		q.remove(rank, w)
+		s.setDepth(q, q.depth-1)
+	}
+
+	return nil, ctx.Err()
+}
+
+// release frees the slot of the finished lookup.
+func (s *FairScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.active--
+	s.dispatch()
+}
+
+// dispatch grants the free slots to the waiting lookups.
+func (s *FairScheduler) dispatch() {
+	for s.active < s.concurrency() {
+		q, rank := s.next()
+		if q == nil {
+			return
+		}
+
+		w := q.waiters[rank][0]
+		q.waiters[rank][0] = nil
+		q.waiters[rank] = q.waiters[rank][1:]
+
+		s.pass = q.pass
+		q.pass += s.stride(q.key)
+		s.setDepth(q, q.depth-1)
+
+		s.active++
+		w.granted = true
+		close(w.ready)
+	}
+}
+
+// next returns the queue to serve and the priority rank of its lookup, nil if no lookup is waiting.
+// The queue with the smallest virtual time is chosen among the ones waiting with the highest priority.
+func (s *FairScheduler) next() (*fairQueue, int) {
+	keys := make([]string, 0, len(s.queues))
+	for key := range s.queues {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for rank := range [3]struct{}{} {
+		var best *fairQueue
+
+		for _, key := range keys {
+			q := s.queues[key]
+			if len(q.waiters[rank]) != 0 && (best == nil || q.pass < best.pass) {
+				best = q
+			}
+		}
+
+		if best != nil {
+			return best, rank
+		}
+	}
+
+	return nil, 0
+}
+
+// setDepth sets the depth of the queue, reports it and drops the empty queue.
+func (s *FairScheduler) setDepth(q *fairQueue, depth int) {
+	q.depth = depth
+
+	if depth == 0 {
+		delete(s.queues, q.key)
+	}
+
+	if s.OnQueueDepth != nil {
+		s.OnQueueDepth(q.key, depth)
+	}
+}
+
+// remove removes the waiter which gave up.
+func (q *fairQueue) remove(rank int, w *fairWaiter) {
+	for i, waiter := range q.waiters[rank] {
+		if waiter == w {
+			q.waiters[rank] = append(q.waiters[rank][:i], q.waiters[rank][i+1:]...)
+			return
+		}
+	}
+}
+
+// key returns the queue of the lookup.
+func (s *FairScheduler) key(ctx context.Context, domainName string) string {
+	if s.Key != nil {
+		return s.Key(ctx, domainName)
+	}
+
+	return TagsFromContext(ctx)[TenantTag]
+}
+
+// stride returns the virtual time advance of a lookup of the queue, inversely proportional to its weight.
+func (s *FairScheduler) stride(key string) float64 {
+	if weight := s.Weights[key]; weight > 0 {
+		return 1 / float64(weight)
+	}
+
+	return 1
+}
+
+// concurrency returns the maximum number of concurrent lookups.
+func (s *FairScheduler) concurrency() int {
+	if s.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+
+	return s.Concurrency
+}
+
+// priorityRank returns the serving order of the priority, lower is served first.
+func priorityRank(priority Priority) int {
+	switch priority {
+	case PriorityHigh:
+		return 0
+	case PriorityLow:
+		return 2
+	}
+
+	return 1
+}
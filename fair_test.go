@@ -0,0 +1,161 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fairWaiters enqueues the lookups one by one and collects the order in which they are served.
+type fairWaiters struct {
+	scheduler *FairScheduler
+	mu        sync.Mutex
+	order     []string
+	wg        sync.WaitGroup
+}
+
+// add enqueues the lookup of the tenant and waits until it's queued.
+func (w *fairWaiters) add(t *testing.T, ctx context.Context, tenant, name string) {
+	depth := w.total()
+
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+
+		release, err := w.scheduler.acquire(WithTag(ctx, TenantTag, tenant), name)
+		if err != nil {
+			return
+		}
+
+		w.mu.Lock()
+		w.order = append(w.order, name)
+		w.mu.Unlock()
+
+		release()
+	}()
+
+	for deadline := time.Now().Add(time.Second); w.total() == depth; {
+		if time.Now().After(deadline) {
+			t.Fatalf("lookup %s is not queued", name)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// total returns the number of the queued lookups.
+func (w *fairWaiters) total() int {
+	total := 0
+	for _, depth := range w.scheduler.QueueDepths() {
+		total += depth
+	}
+
+	return total
+}
+
+// TestFairScheduler tests that the waiting lookups are served by priority and tenant weight.
+func TestFairScheduler(t *testing.T) {
+	depths := map[string]int{}
+	scheduler := &FairScheduler{
+		Concurrency:  1,
+		Weights:      map[string]int{"bulk": 2},
+		OnQueueDepth: func(key string, depth int) { depths[key] = depth },
+	}
+
+	release, err := scheduler.acquire(context.Background(), "hold")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := &fairWaiters{scheduler: scheduler}
+	ctx := context.Background()
+
+	for _, name := range []string{"b1", "b2", "b3", "b4"} {
+		w.add(t, ctx, "bulk", name)
+	}
+
+	w.add(t, ctx, "web", "w1")
+	w.add(t, ctx, "web", "w2")
+	w.add(t, WithPriority(ctx, PriorityHigh), "api", "h1")
+
+	canceled, cancel := context.WithCancel(ctx)
+	w.add(t, canceled, "web", "c1")
+	cancel()
+
+	if scheduler.QueueDepth("bulk") != 4 || depths["web"] < 2 {
+		t.Errorf("QueueDepth() = %v, reported %v", scheduler.QueueDepths(), depths)
+	}
+
+	release()
+	w.wg.Wait()
+
+	if want := []string{"h1", "b1", "w1", "b2", "b3", "w2", "b4"}; !reflect.DeepEqual(w.order, want) {
+		t.Errorf("order = %v, want %v", w.order, want)
+	}
+
+	if len(scheduler.QueueDepths()) != 0 || depths["bulk"] != 0 || depths["web"] != 0 {
+		t.Errorf("QueueDepths() = %v, reported %v", scheduler.QueueDepths(), depths)
+	}
+}
+
+// TestFairSchedulerClient tests that the client doesn't exceed the scheduler concurrency.
+func TestFairSchedulerClient(t *testing.T) {
+	var inFlight, maxInFlight int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		Scheduler:        &FairScheduler{Concurrency: 2},
+	})
+
+	ctx := WithPriority(WithTag(context.Background(), TenantTag, "bulk"), PriorityLow)
+	domainNames := []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com", "e.example.com"}
+
+	for _, result := range GetMany(ctx, client, domainNames, 5) {
+		if result.Err != nil {
+			t.Errorf("GetMany(%s) error = %v", result.DomainName, result.Err)
+		}
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("max in-flight requests = %d, want 2", maxInFlight)
+	}
+
+	timeout, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client.scheduler.active = 2
+
+	if _, _, err := client.Get(timeout, "example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get() error = %v, want deadline exceeded while waiting", err)
+	}
+
+	if client.scheduler.active != 2 || client.scheduler.QueueDepth("") != 0 {
+		t.Errorf("scheduler after timeout = %d active, %v", client.scheduler.active, client.scheduler.QueueDepths())
+	}
+}
@@ -0,0 +1,124 @@
+package dnslookupapi
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidLength is returned when the decoded field doesn't have the length its type requires,
+// e.g. a SHA-256 digest which is not 32 bytes long.
+var ErrInvalidLength = errors.New("invalid length")
+
+// FieldError is the error of decoding the binary field of the record, e.g. the DS digest.
+type FieldError struct {
+	// DNSType is the DNS type of the record.
+	DNSType string
+
+	// Field is the name of the field.
+	Field string
+
+	// Err is the decoding or validation error.
+	Err error
+}
+
+// Error returns error message as a string.
+func (e *FieldError) Error() string {
+	return "invalid " + e.DNSType + " " + e.Field + ": " + e.Err.Error()
+}
+
+// Unwrap returns the decoding or validation error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// TLSA matching types (RFC 6698).
+const (
+	TLSAMatchingFull   = 0
+	TLSAMatchingSHA256 = 1
+	TLSAMatchingSHA512 = 2
+)
+
+// tlsaDataLengths are the lengths of the TLSA certificate association data in bytes per matching type.
+var tlsaDataLengths = map[int]int{
+	TLSAMatchingSHA256: 32,
+	TLSAMatchingSHA512: 64,
+}
+
+// maxAssociationData is the maximum size of the TLSA certificate association data, well above
+// the size of the full certificates (matching type 0).
+const maxAssociationData = 16384
+
+// checkLength checks the length of the decoded field if the length of the type is known.
+func checkLength(b []byte, lengths map[int]int, typ int) error {
+	if want, ok := lengths[typ]; ok && len(b) != want {
+		return fmt.Errorf("%w %d, want %d", ErrInvalidLength, len(b), want)
+	}
+
+	return nil
+}
+
+// DigestBytes returns the digest of the DLV record as a byte slice.
+// It's decoded the same way as the digest of the DS record, see DSRecord.DigestBytes.
+func (r DLVRecord) DigestBytes() ([]byte, error) {
+	return decodeDigest("DLV", r.Digest, r.RawText, r.DigestID)
+}
+
+// Matches reports whether the DLV record corresponds to the DNSKEY record, see DSRecord.Matches.
+func (r DLVRecord) Matches(key DNSKEYRecord) (bool, error) {
+	digest, err := r.DigestBytes()
+	if err != nil {
+		return false, err
+	}
+
+	ds := DSRecord{
+		Algorithm: r.Algorithm,
+		Digest:    []string{hex.EncodeToString(digest)},
+		DigestID:  r.DigestID,
+		Footprint: r.Footprint,
+	}
+
+	return ds.Matches(key)
+}
+
+// AssociationData returns the certificate association data of the TLSA record as a byte slice.
+// If the CertificateAssociationData field is not a hex string, the data is taken from the raw text of the record.
+// The length is validated for the SHA-256 and SHA-512 matching types. Errors are returned as *FieldError.
+func (r TLSARecord) AssociationData() ([]byte, error) {
+	limit := hex.EncodedLen(maxAssociationData)
+
+	data, err := decodeLimited(hex.DecodeString, r.CertificateAssociationData, limit)
+	if err != nil || len(data) == 0 {
+		if fields := rawTextFields(r.RawText, "TLSA"); len(fields) > 3 {
+			data, err = decodeLimited(hex.DecodeString, fields[3:], limit)
+		} else if err == nil {
+			err = errors.New("empty certificate association data")
+		}
+	}
+
+	if err == nil {
+		err = checkLength(data, tlsaDataLengths, r.MatchingType)
+	}
+
+	if err != nil {
+		return nil, &FieldError{DNSType: "TLSA", Field: "certificate association data", Err: err}
+	}
+
+	return data, nil
+}
+
+// DataBytes returns the base64-decoded data of the DHCID record. Errors are returned as *FieldError.
+func (r DHCIDRecord) DataBytes() ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.Join(r.Data, ""))
+	if err == nil && len(data) == 0 {
+		err = errors.New("empty data")
+	}
+
+	if err != nil {
+		return nil, &FieldError{DNSType: "DHCID", Field: "data", Err: err}
+	}
+
+	return data, nil
+}
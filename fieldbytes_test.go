@@ -0,0 +1,121 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestDigestLength tests that DS digests are validated against the length of the digest type.
+func TestDigestLength(t *testing.T) {
+	sha1Digest := "2BB183AF5F22588179A53B0A98631FAD1A292118"
+
+	tests := []struct {
+		name    string
+		record  DSRecord
+		wantLen int
+		wantErr error
+	}{
+		{"sha1", DSRecord{DigestID: DSDigestSHA1, Digest: []string{sha1Digest}}, 20, nil},
+		{"sha256 too short", DSRecord{DigestID: DSDigestSHA256, Digest: []string{sha1Digest}}, 0, ErrInvalidLength},
+		{"unknown type", DSRecord{DigestID: 200, Digest: []string{"ABCD"}}, 2, nil},
+		{"oversized", DSRecord{DigestID: 200, Digest: []string{strings.Repeat("AB", 65)}}, 0, ErrFieldTooLarge},
+		{"not hex", DSRecord{DigestID: DSDigestSHA1, Digest: []string{"XYZ"}}, 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest, err := tt.record.DigestBytes()
+			if len(digest) != tt.wantLen {
+				t.Errorf("DigestBytes() = %d bytes, want %d", len(digest), tt.wantLen)
+			}
+
+			if tt.wantLen != 0 {
+				if err != nil {
+					t.Errorf("DigestBytes() error = %v", err)
+				}
+
+				return
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) || fieldErr.DNSType != "DS" || fieldErr.Field != "digest" {
+				t.Errorf("DigestBytes() error = %#v, want *FieldError", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("DigestBytes() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestDLVMatches tests that DLV records are matched against DNSKEY records like DS records.
+func TestDLVMatches(t *testing.T) {
+	key := rfc4034Key()
+
+	dlv := DLVRecord{
+		Algorithm: DNSSECAlgorithmRSASHA1,
+		Digest:    []string{"2BB183AF5F22588179A53B0A98631FAD1A292118"},
+		DigestID:  DSDigestSHA1,
+		Footprint: 60485,
+	}
+
+	if ok, err := dlv.Matches(key); !ok || err != nil {
+		t.Errorf("Matches() = %v, %v, want true", ok, err)
+	}
+
+	dlv.RawText = "dskey.example.com. 86400 IN DLV 60485 5 1 " + dlv.Digest[0]
+	dlv.Digest = nil
+
+	if digest, err := dlv.DigestBytes(); err != nil || len(digest) != 20 {
+		t.Errorf("DigestBytes() from raw text = %x, %v", digest, err)
+	}
+
+	dlv.DigestID = DSDigestSHA256
+
+	if _, err := dlv.Matches(key); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Matches() error = %v, want %v", err, ErrInvalidLength)
+	}
+}
+
+// TestTLSAAssociationData tests decoding of the TLSA certificate association data.
+func TestTLSAAssociationData(t *testing.T) {
+	sum := strings.Repeat("0a", 32)
+
+	record := TLSARecord{MatchingType: TLSAMatchingSHA256, CertificateAssociationData: []string{sum[:32], sum[32:]}}
+	if data, err := record.AssociationData(); err != nil || !bytes.Equal(data, bytes.Repeat([]byte{10}, 32)) {
+		t.Errorf("AssociationData() = %x, %v", data, err)
+	}
+
+	record = TLSARecord{MatchingType: TLSAMatchingSHA256}
+	record.RawText = "_443._tcp.example.com. 300 IN TLSA 3 1 1 " + sum
+
+	if data, err := record.AssociationData(); err != nil || len(data) != 32 {
+		t.Errorf("AssociationData() from raw text = %x, %v", data, err)
+	}
+
+	record = TLSARecord{MatchingType: TLSAMatchingSHA512, CertificateAssociationData: []string{sum}}
+	if _, err := record.AssociationData(); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("AssociationData() error = %v, want %v", err, ErrInvalidLength)
+	}
+
+	record = TLSARecord{MatchingType: TLSAMatchingFull, CertificateAssociationData: []string{"30820122"}}
+	if data, err := record.AssociationData(); err != nil || len(data) != 4 {
+		t.Errorf("AssociationData() of full certificate = %x, %v", data, err)
+	}
+}
+
+// TestDHCIDDataBytes tests decoding of the DHCID data.
+func TestDHCIDDataBytes(t *testing.T) {
+	record := DHCIDRecord{Data: []string{"AAIBY2/AuCccgoJbsaxcQc9TUapptP69l", "OjxfNuVAA2kjEA="}}
+	if data, err := record.DataBytes(); err != nil || len(data) != 35 {
+		t.Errorf("DataBytes() = %x, %v", data, err)
+	}
+
+	var fieldErr *FieldError
+	if _, err := (DHCIDRecord{Data: []string{"!"}}).DataBytes(); !errors.As(err, &fieldErr) {
+		t.Errorf("DataBytes() error = %v, want *FieldError", err)
+	}
+}
@@ -0,0 +1,281 @@
+// Package fixtures provides realistic anonymized DNS Lookup API responses for every supported record type
+// and generates responses programmatically, so tests don't need to embed large JSON literals.
+// The golden responses are generated by MakeResponse from the samples, see Sample.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Domain is the domain name of the samples.
+const Domain = "example.com"
+
+// DefaultTTL is the TTL of the records without TTL.
+const DefaultTTL = 300
+
+// auditTime is the audit date of the responses without Audit.
+var auditTime = time.Date(2022, time.July, 12, 11, 46, 25, 0, time.UTC)
+
+//go:embed responses/*.json
+var responses embed.FS
+
+// Record is the DNS record of the generated response.
+type Record struct {
+	// DNSType is the DNS record type, e.g. "MX".
+	DNSType string
+
+	// Code is the DNS record type code. If it's zero then the code of the type with samples is used.
+	Code int
+
+	// Name is the owner name. If it's empty then the domain name of the response is used.
+	Name string
+
+	// TTL is the time to live. If it's zero then DefaultTTL is used; use a negative value for zero TTL.
+	TTL int
+
+	// Data is the record data in the presentation format used in the raw text, e.g. "10 mail.example.com.".
+	Data string
+
+	// Fields are the type-specific fields of the record, e.g. "target" and "priority" of MX records.
+	Fields map[string]interface{}
+}
+
+// Options configure MakeResponse.
+type Options struct {
+	// DomainName is the requested domain name.
+	// If it's empty then the owner name of the first record or Domain is used.
+	DomainName string
+
+	// Records are the returned records in order.
+	Records []Record
+
+	// Types are the requested DNS record types. If it's empty then the types of the records are used.
+	Types []string
+
+	// Audit is the time the data was collected and updated. If it's zero then a fixed time is used.
+	Audit time.Time
+}
+
+// MakeResponse returns the JSON response body in the format of DNS Lookup API.
+// The output is deterministic: the common fields of the records come first, the other fields are sorted.
+func MakeResponse(opts Options) []byte {
+	domainName := opts.DomainName
+	if domainName == "" {
+		domainName = Domain
+		if len(opts.Records) != 0 && opts.Records[0].Name != "" {
+			domainName = strings.TrimSuffix(opts.Records[0].Name, ".")
+		}
+	}
+
+	types := opts.Types
+	if len(types) == 0 {
+		types = recordTypes(opts.Records)
+	}
+
+	codes := make([]int, 0, len(types))
+	for _, dnsType := range types {
+		codes = append(codes, typeCodes[strings.ToUpper(dnsType)])
+	}
+
+	audit := opts.Audit
+	if audit.IsZero() {
+		audit = auditTime
+	}
+
+	date := audit.UTC().Format("2006-01-02 15:04:05 MST")
+
+	var b bytes.Buffer
+
+	b.WriteString(`{"DNSData":{"domainName":`)
+	writeJSON(&b, domainName)
+	b.WriteString(`,"types":`)
+	writeJSON(&b, codes)
+	b.WriteString(`,"dnsTypes":`)
+	writeJSON(&b, strings.Join(types, ","))
+	b.WriteString(`,"audit":{"createdDate":`)
+	writeJSON(&b, date)
+	b.WriteString(`,"updatedDate":`)
+	writeJSON(&b, date)
+	b.WriteString(`},"dnsRecords":[`)
+
+	for i, record := range opts.Records {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+
+		record.write(&b, domainName)
+	}
+
+	b.WriteString(`]}}`)
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, b.Bytes(), "", "  "); err != nil {
+		panic(err)
+	}
+
+	out.WriteByte('\n')
+
+	return out.Bytes()
+}
+
+// write writes the record as a JSON object.
+func (r Record) write(b *bytes.Buffer, domainName string) {
+	dnsType := strings.ToUpper(r.DNSType)
+
+	code := r.Code
+	if code == 0 {
+		code = typeCodes[dnsType]
+	}
+
+	name := r.Name
+	if name == "" {
+		name = strings.TrimSuffix(domainName, ".") + "."
+	}
+
+	ttl := r.TTL
+	switch {
+	case ttl == 0:
+		ttl = DefaultTTL
+	case ttl < 0:
+		ttl = 0
+	}
+
+	rawText := name + "\t" + strconv.Itoa(ttl) + "\tIN\t" + dnsType
+	if r.Data != "" {
+		rawText += "\t" + r.Data
+	}
+
+	b.WriteString(`{"type":`)
+	writeJSON(b, code)
+	b.WriteString(`,"dnsType":`)
+	writeJSON(b, dnsType)
+	b.WriteString(`,"name":`)
+	writeJSON(b, name)
+	b.WriteString(`,"ttl":`)
+	writeJSON(b, ttl)
+	b.WriteString(`,"rRsetType":`)
+	writeJSON(b, code)
+	b.WriteString(`,"rawText":`)
+	writeJSON(b, rawText)
+
+	keys := make([]string, 0, len(r.Fields))
+	for key := range r.Fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		b.WriteByte(',')
+		writeJSON(b, key)
+		b.WriteByte(':')
+		writeJSON(b, r.Fields[key])
+	}
+
+	b.WriteByte('}')
+}
+
+// writeJSON writes the value encoded as JSON. HTML characters are not escaped.
+func writeJSON(b *bytes.Buffer, v interface{}) {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(v); err != nil {
+		panic(err)
+	}
+
+	// Encode terminates the value with a newline
+	b.Truncate(b.Len() - 1)
+}
+
+// recordTypes returns the distinct types of the records in order.
+func recordTypes(records []Record) []string {
+	var types []string
+
+	seen := make(map[string]bool)
+
+	for _, record := range records {
+		dnsType := strings.ToUpper(record.DNSType)
+		if !seen[dnsType] {
+			seen[dnsType] = true
+			types = append(types, dnsType)
+		}
+	}
+
+	return types
+}
+
+// Types returns the DNS record types with samples, sorted.
+func Types() []string {
+	types := make([]string, 0, len(samples))
+	for dnsType := range samples {
+		types = append(types, dnsType)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// Sample returns the sample records of the DNS type. It returns nil if the type has no samples.
+// The records can be modified and passed to MakeResponse.
+func Sample(dnsType string) []Record {
+	dnsType = strings.ToUpper(dnsType)
+
+	records := make([]Record, 0, len(samples[dnsType]))
+
+	for _, record := range samples[dnsType] {
+		record.DNSType = dnsType
+
+		fields := make(map[string]interface{}, len(record.Fields))
+		for key, value := range record.Fields {
+			fields[key] = value
+		}
+
+		record.Fields = fields
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil
+	}
+
+	return records
+}
+
+// Samples returns the sample records of all types in the order of Types.
+func Samples() []Record {
+	var records []Record
+	for _, dnsType := range Types() {
+		records = append(records, Sample(dnsType)...)
+	}
+
+	return records
+}
+
+// Response returns the golden response with the sample records of the DNS type.
+// It returns nil if the type has no samples.
+func Response(dnsType string) []byte {
+	b, err := responses.ReadFile("responses/" + strings.ToLower(dnsType) + ".json")
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
+
+// AllResponse returns the golden response with the sample records of all types for Domain.
+func AllResponse() []byte {
+	b, err := responses.ReadFile("responses/all.json")
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
@@ -0,0 +1,144 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden responses")
+
+// golden returns the golden files and their expected content.
+func golden() map[string][]byte {
+	files := map[string][]byte{
+		"all.json": MakeResponse(Options{DomainName: Domain, Records: Samples()}),
+	}
+
+	for _, dnsType := range Types() {
+		files[strings.ToLower(dnsType)+".json"] = MakeResponse(Options{Records: Sample(dnsType)})
+	}
+
+	return files
+}
+
+// TestGolden tests that the golden responses are up to date. Run with -update to regenerate them.
+func TestGolden(t *testing.T) {
+	for name, want := range golden() {
+		path := filepath.Join("responses", name)
+
+		if *update {
+			if err := os.WriteFile(path, want, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			continue
+		}
+
+		got, err := os.ReadFile(path)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date (%v), run go test -update", path, err)
+		}
+	}
+}
+
+// TestResponses tests that the golden responses parse without errors and cover all supported types.
+func TestResponses(t *testing.T) {
+	for _, chunk := range dnslookupapi.DefaultTypeChunks {
+		for _, dnsType := range chunk {
+			if Response(dnsType) == nil {
+				t.Errorf("Response(%s) = nil", dnsType)
+			}
+		}
+	}
+
+	for _, dnsType := range Types() {
+		if code, _ := dnslookupapi.RRTypeCode(dnsType); code != typeCodes[dnsType] {
+			t.Errorf("%s type code = %d, want %d", dnsType, typeCodes[dnsType], code)
+		}
+
+		resp, err := dnslookupapi.ParseResponse(Response(dnsType))
+		if err != nil {
+			t.Errorf("ParseResponse(%s) error = %v", dnsType, err)
+			continue
+		}
+
+		records := resp.DNSRecords
+		if len(records.All) != len(samples[dnsType]) || records.ParseErrors() != nil {
+			t.Errorf("ParseResponse(%s) = %+v", dnsType, records.All)
+		}
+
+		if problems := resp.CheckTypes(); problems != nil {
+			t.Errorf("%s CheckTypes() = %v", dnsType, problems)
+		}
+
+		if err = dnslookupapi.ValidateResponse(Response(dnsType)); err != nil {
+			t.Errorf("ValidateResponse(%s) error = %v", dnsType, err)
+		}
+	}
+
+	if resp, err := dnslookupapi.ParseResponse(AllResponse()); err != nil || len(resp.DNSRecords.All) != len(Samples()) {
+		t.Errorf("ParseResponse(all) error = %v", err)
+	}
+
+	if Response("SPF") != nil || Sample("SPF") != nil {
+		t.Error("Response(SPF) != nil, want no samples")
+	}
+}
+
+// TestDNSSEC tests that the DNSSEC samples are consistent.
+func TestDNSSEC(t *testing.T) {
+	dnskey, _ := dnslookupapi.ParseResponse(Response("DNSKEY"))
+	ds, _ := dnslookupapi.ParseResponse(Response("DS"))
+
+	key := dnskey.DNSRecords.DNSKEY[0]
+	if tag, err := key.KeyTag(); err != nil || int(tag) != key.Footprint {
+		t.Errorf("KeyTag() = %d, %v, want %d", tag, err, key.Footprint)
+	}
+
+	if ok, err := ds.DNSRecords.DS[0].Matches(key); !ok || err != nil {
+		t.Errorf("DS Matches() = %v, %v", ok, err)
+	}
+}
+
+// TestMakeResponse tests the generated response.
+func TestMakeResponse(t *testing.T) {
+	body := MakeResponse(Options{
+		DomainName: "example.org",
+		Records: []Record{
+			{DNSType: "a", Data: "192.0.2.7", Fields: map[string]interface{}{"address": "192.0.2.7"}},
+			{DNSType: "TXT", Name: "_dmarc.example.org.", TTL: -1, Data: `"v=DMARC1; p=reject"`,
+				Fields: map[string]interface{}{"strings": []string{"v=DMARC1; p=reject"}}},
+		},
+	})
+
+	var resp struct {
+		DNSData struct {
+			DomainName string                   `json:"domainName"`
+			Types      []int                    `json:"types"`
+			DNSTypes   string                   `json:"dnsTypes"`
+			DNSRecords []map[string]interface{} `json:"dnsRecords"`
+		}
+	}
+
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	data := resp.DNSData
+	if data.DomainName != "example.org" || data.DNSTypes != "A,TXT" || len(data.Types) != 2 || data.Types[1] != 16 {
+		t.Errorf("MakeResponse() = %s", body)
+	}
+
+	want := []string{"example.org.\t300\tIN\tA\t192.0.2.7", "_dmarc.example.org.\t0\tIN\tTXT\t\"v=DMARC1; p=reject\""}
+	for i, record := range data.DNSRecords {
+		if record["rawText"] != want[i] {
+			t.Errorf("record %d rawText = %q, want %q", i, record["rawText"], want[i])
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package fixtures
+
+// typeCodes are the codes of the record types with samples.
+var typeCodes = map[string]int{
+	"A": 1, "NS": 2, "MD": 3, "MF": 4, "CNAME": 5, "SOA": 6, "MB": 7, "NULL": 10, "PTR": 12, "HINFO": 13,
+	"MX": 15, "TXT": 16, "RP": 17, "NSAP": 22, "AAAA": 28, "LOC": 29, "SRV": 33, "NAPTR": 35, "CERT": 37,
+	"DNAME": 39, "DS": 43, "SSHFP": 44, "NSEC": 47, "DNSKEY": 48, "DHCID": 49, "NSEC3PARAM": 51, "TLSA": 52,
+	"SMIMEA": 53, "OPENPGPKEY": 61, "URI": 256, "CAA": 257, "DLV": 32769,
+}
+
+// Anonymized sample data. Keys, digests and fingerprints are consistent with each other, e.g. the DS record
+// matches the DNSKEY record, so the samples can be used to test the DNSSEC helpers.
+const (
+	sampleKey       = "mdsswUyr3DPW132mOi8V9xESWE8jTo0dxCjjnopKl+GqJxpVXckHAeF+KkxLbxILfDLUT0rAK9iUzy1L53eKGQ=="
+	sampleKeyTag    = 2371
+	sampleDSDigest  = "C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D64E78B29C3E1C83D245A"
+	sampleSHA256Hex = "d2abde240d7cd3ee6b4b28c54df034b97983a1d16e8a410e4561cb106618e971"
+	sampleSMIMEA    = "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._smimecert.example.com."
+	sampleOPENPGP   = "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey.example.com."
+)
+
+// samples are the sample records per DNS type.
+var samples = map[string][]Record{
+	"A": {
+		{Data: "192.0.2.1", Fields: map[string]interface{}{"address": "192.0.2.1"}},
+		{Data: "192.0.2.2", Fields: map[string]interface{}{"address": "192.0.2.2"}},
+	},
+	"AAAA": {
+		{Data: "2001:db8::1", Fields: map[string]interface{}{"address": "2001:db8::1"}},
+	},
+	"NS": {
+		{TTL: 86400, Data: "ns1.example.net.", Fields: map[string]interface{}{"target": "ns1.example.net."}},
+		{TTL: 86400, Data: "ns2.example.net.", Fields: map[string]interface{}{"target": "ns2.example.net."}},
+	},
+	"MX": {
+		{Data: "10 mail.example.com.", Fields: map[string]interface{}{"target": "mail.example.com.", "priority": 10}},
+		{Data: "20 mail2.example.com.", Fields: map[string]interface{}{"target": "mail2.example.com.", "priority": 20}},
+	},
+	"MD": {
+		{Data: "mail.example.com.", Fields: map[string]interface{}{
+			"additionalName": "mail.example.com.", "mailAgent": "mail.example.com.",
+		}},
+	},
+	"MF": {
+		{Data: "mail.example.com.", Fields: map[string]interface{}{
+			"additionalName": "mail.example.com.", "mailAgent": "mail.example.com.",
+		}},
+	},
+	"MB": {
+		{Data: "mailhost.example.com.", Fields: map[string]interface{}{
+			"additionalName": "mailhost.example.com.", "mailbox": "mailhost.example.com.",
+		}},
+	},
+	"SOA": {
+		{TTL: 3600, Data: "ns1.example.net. hostmaster.example.com. 2022071201 7200 3600 1209600 3600",
+			Fields: map[string]interface{}{
+				"host": "ns1.example.net.", "admin": "hostmaster.example.com.", "serial": 2022071201,
+				"refresh": 7200, "retry": 3600, "expire": 1209600, "minimum": 3600,
+			}},
+	},
+	"TXT": {
+		{Data: `"v=spf1 include:_spf.example.net ~all"`,
+			Fields: map[string]interface{}{"strings": []string{"v=spf1 include:_spf.example.net ~all"}}},
+		{Data: `"example-site-verification=K7sX2mQ9vB4nR1tY"`,
+			Fields: map[string]interface{}{"strings": []string{"example-site-verification=K7sX2mQ9vB4nR1tY"}}},
+	},
+	"CAA": {
+		{Data: `0 issue "letsencrypt.org"`,
+			Fields: map[string]interface{}{"flags": 0, "tag": "issue", "value": "letsencrypt.org"}},
+		{Data: `0 iodef "mailto:security@example.com"`,
+			Fields: map[string]interface{}{"flags": 0, "tag": "iodef", "value": "mailto:security@example.com"}},
+	},
+	"CNAME": {
+		{Name: "www.example.com.", Data: "example.com.",
+			Fields: map[string]interface{}{"alias": "www.example.com.", "target": "example.com."}},
+	},
+	"DNAME": {
+		{Name: "legacy.example.com.", Data: "example.net.",
+			Fields: map[string]interface{}{"alias": "legacy.example.com.", "target": "example.net."}},
+	},
+	"PTR": {
+		{Name: "1.2.0.192.in-addr.arpa.", Data: "example.com.", Fields: map[string]interface{}{"target": "example.com."}},
+	},
+	"SRV": {
+		{Name: "_sip._tcp.example.com.", Data: "10 60 5060 sip.example.com.", Fields: map[string]interface{}{
+			"priority": 10, "weight": 60, "port": 5060, "target": "sip.example.com.",
+		}},
+	},
+	"NAPTR": {
+		{Data: `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`, Fields: map[string]interface{}{
+			"order": 100, "preference": 10, "flags": "U", "service": "E2U+sip",
+			"regexp": "!^.*$!sip:info@example.com!", "replacement": ".",
+		}},
+	},
+	"URI": {
+		{Name: "_http._tcp.example.com.", Data: `10 1 "https://www.example.com/"`, Fields: map[string]interface{}{
+			"priority": 10, "weight": 1, "target": "https://www.example.com/",
+		}},
+	},
+	"LOC": {
+		{Data: "52 22 23.000 N 4 53 32.000 E -2.00m 0.00m 10000.00m 10.00m", Fields: map[string]interface{}{
+			"latitude": 52.373055, "longitude": 4.892222, "altitude": -2, "size": 0,
+			"hPrecision": 10000, "vPrecision": 10,
+		}},
+	},
+	"HINFO": {
+		{Data: `"RFC8482" ""`, Fields: map[string]interface{}{"cpu": "RFC8482", "os": ""}},
+	},
+	"RP": {
+		{Data: "hostmaster.example.com. contact.example.com.", Fields: map[string]interface{}{
+			"mailbox": "hostmaster.example.com.", "textDomain": "contact.example.com.",
+		}},
+	},
+	"NSAP": {
+		{Name: "nsap.example.com.", Data: "0x47000580005a0000000001e133ffffff00016100",
+			Fields: map[string]interface{}{"address": "0x47000580005a0000000001e133ffffff00016100"}},
+	},
+	"NULL": {
+		{Name: "null.example.com.", Data: `\# 4 deadbeef`, Fields: map[string]interface{}{"data": []string{"3q2+7w=="}}},
+	},
+	"DHCID": {
+		{Name: "host.example.com.", Data: "AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA=",
+			Fields: map[string]interface{}{"data": []string{"AAIBY2/AuCccgoJbsaxcQc9TUapptP69lOjxfNuVAA2kjEA="}}},
+	},
+	"DNSKEY": {
+		{TTL: 3600, Data: "257 3 13 " + sampleKey, Fields: map[string]interface{}{
+			"flags": 257, "protocol": 3, "algorithm": 13, "footprint": sampleKeyTag,
+			"key": []string{sampleKey}, "publicKey": sampleKey,
+		}},
+	},
+	"DS": {
+		{TTL: 86400, Data: "2371 13 2 " + sampleDSDigest, Fields: map[string]interface{}{
+			"footprint": sampleKeyTag, "algorithm": 13, "digestID": 2, "digest": []string{sampleDSDigest},
+		}},
+	},
+	"DLV": {
+		{Name: "example.com.dlv.example.net.", TTL: 86400, Data: "2371 13 2 " + sampleDSDigest,
+			Fields: map[string]interface{}{
+				"footprint": sampleKeyTag, "algorithm": 13, "digestID": 2, "digest": []string{sampleDSDigest},
+			}},
+	},
+	"NSEC": {
+		{TTL: 3600, Data: "www.example.com. A NS SOA MX TXT AAAA RRSIG NSEC DNSKEY", Fields: map[string]interface{}{
+			"next": "www.example.com.", "types": []int{1, 2, 6, 15, 16, 28, 46, 47, 48},
+		}},
+	},
+	"NSEC3PARAM": {
+		{TTL: 0, Data: "1 0 10 aabbccdd", Fields: map[string]interface{}{
+			"hashAlgorithm": 1, "flags": 0, "iterations": 10, "salt": []string{"aabbccdd"},
+		}},
+	},
+	"SSHFP": {
+		{Name: "host.example.com.", Data: "4 2 " + sampleSHA256Hex, Fields: map[string]interface{}{
+			"algorithm": 4, "digestType": 2, "fingerPrint": []string{sampleSHA256Hex},
+		}},
+	},
+	"TLSA": {
+		{Name: "_443._tcp.www.example.com.", Data: "3 1 1 " + sampleSHA256Hex, Fields: map[string]interface{}{
+			"certificateUsage": 3, "selector": 1, "matchingType": 1,
+			"certificateAssociationData": []string{sampleSHA256Hex},
+		}},
+	},
+	"SMIMEA": {
+		{Name: sampleSMIMEA, Data: "3 0 1 " + sampleSHA256Hex, Fields: map[string]interface{}{
+			"certificateUsage": 3, "selector": 0, "matchingType": 1,
+			"certificateAssociationData": []string{sampleSHA256Hex},
+		}},
+	},
+	"CERT": {
+		{Data: "1 0 0 MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA", Fields: map[string]interface{}{
+			"certificateType": 1, "keyTag": 0, "algorithm": 0,
+			"certificate": []string{"MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA"},
+		}},
+	},
+	"OPENPGPKEY": {
+		{Name: sampleOPENPGP, Data: "mDMEYrB1ZxYJKwYBBAHaRw8BAQdA", Fields: map[string]interface{}{
+			"publicKey": []string{"mDMEYrB1ZxYJKwYBBAHaRw8BAQdA"},
+		}},
+	},
+}
@@ -0,0 +1,112 @@
+package dnslookupapi
+
+import (
+	"context"
+	"time"
+)
+
+// StalePolicy is the action taken by Get when the data is older than ClientParams.MaxDataAge.
+type StalePolicy int
+
+const (
+	// StaleError makes Get return StaleDataError.
+	StaleError StalePolicy = iota
+
+	// StaleRefresh makes Get repeat the lookup once and return StaleDataError only if the data is still stale.
+	StaleRefresh
+
+	// StaleIgnore makes Get return the stale data without an error. It's useful to only log the staleness
+	// by checking Audit.IsStale in a DecodeHook.
+	StaleIgnore
+)
+
+// StaleDataError is returned by Get when the data is older than ClientParams.MaxDataAge.
+type StaleDataError struct {
+	// DomainName is the requested domain name
+	DomainName string
+
+	// Age is the age of the data
+	Age time.Duration
+
+	// MaxAge is the maximum allowed age
+	MaxAge time.Duration
+
+	// Response is the parsed response with the stale data
+	Response *DNSLookupResponse
+}
+
+// Error returns error message as a string.
+func (e *StaleDataError) Error() string {
+	return "stale data of " + e.DomainName + ": age " + e.Age.String() + " exceeds " + e.MaxAge.String()
+}
+
+// LastModified returns UpdatedDate or CreatedDate if the data was never updated.
+// It returns the zero time if neither date is set.
+func (a Audit) LastModified() time.Time {
+	if updated := a.UpdatedDate.AsTime(); !updated.IsZero() {
+		return updated
+	}
+
+	return a.CreatedDate.AsTime()
+}
+
+// Age returns the age of the data at the given time, measured from LastModified.
+// It returns zero if neither date is set.
+func (a Audit) Age(now time.Time) time.Duration {
+	modified := a.LastModified()
+	if modified.IsZero() || now.Before(modified) {
+		return 0
+	}
+
+	return now.Sub(modified)
+}
+
+// IsStale reports whether the data is older than maxAge. Data without dates is never stale.
+func (a Audit) IsStale(maxAge time.Duration) bool {
+	return a.Age(time.Now()) > maxAge
+}
+
+// checkFreshness applies the stale policy to the response of Get.
+func (service dnsLookupServiceOp) checkFreshness(
+	ctx context.Context,
+	domainName string,
+	dnsLookupResponse *DNSLookupResponse,
+	resp *Response,
+	opts []Option,
+) (*DNSLookupResponse, *Response, error) {
+	maxAge := service.client.maxDataAge
+
+	age := dnsLookupResponse.Audit.Age(service.client.clock())
+	if age <= maxAge || service.client.stalePolicy == StaleIgnore {
+		return dnsLookupResponse, resp, nil
+	}
+
+	if service.client.stalePolicy == StaleRefresh {
+		refreshed, refreshedResp, err := service.lookup(ctx, domainName, opts...)
+		if err != nil {
+			return nil, refreshedResp, err
+		}
+
+		dnsLookupResponse, resp = refreshed, refreshedResp
+
+		if age = dnsLookupResponse.Audit.Age(service.client.clock()); age <= maxAge {
+			return dnsLookupResponse, resp, nil
+		}
+	}
+
+	return nil, resp, &StaleDataError{
+		DomainName: domainName,
+		Age:        age,
+		MaxAge:     maxAge,
+		Response:   dnsLookupResponse,
+	}
+}
+
+// clock returns the current time.
+func (c *Client) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+
+	return time.Now()
+}
@@ -0,0 +1,113 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAuditAge tests the age of the audit dates.
+func TestAuditAge(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(24 * time.Hour)
+	now := updated.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		audit Audit
+		age   time.Duration
+	}{
+		{"updated", Audit{CreatedDate: Time(created), UpdatedDate: Time(updated)}, time.Hour},
+		{"created only", Audit{CreatedDate: Time(created)}, 25 * time.Hour},
+		{"no dates", Audit{}, 0},
+		{"future", Audit{UpdatedDate: Time(now.Add(time.Hour))}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if age := tt.audit.Age(now); age != tt.age {
+				t.Errorf("Age() = %v, want %v", age, tt.age)
+			}
+		})
+	}
+
+	if !(Audit{UpdatedDate: Time(time.Now().Add(-2 * time.Hour))}).IsStale(time.Hour) {
+		t.Error("IsStale() = false, want true")
+	}
+
+	if (Audit{}).IsStale(time.Hour) {
+		t.Error("IsStale() of no dates = true, want false")
+	}
+}
+
+// TestStalePolicy tests handling of stale data by Get.
+func TestStalePolicy(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		updated := now.Add(-48 * time.Hour)
+		if atomic.AddInt32(&calls, 1) > 1 && req.URL.Query().Get("apiKey") == "at_fresh" {
+			updated = now.Add(-time.Minute)
+		}
+
+		_, _ = fmt.Fprintf(w, `{"DNSData":{"domainName":"whoisxmlapi.com","audit":{"updatedDate":%q},"dnsRecords":[]}}`,
+			updated.Format("2006-01-02 15:04:05 MST"))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	tests := []struct {
+		name   string
+		key    string
+		policy StalePolicy
+		calls  int32
+		stale  bool
+	}{
+		{"error", "at_fresh", StaleError, 1, true},
+		{"refreshed", "at_fresh", StaleRefresh, 2, false},
+		{"still stale", "at_stale", StaleRefresh, 2, true},
+		{"ignore", "at_stale", StaleIgnore, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+
+			client := NewClient(tt.key, ClientParams{
+				HTTPClient:       server.Client(),
+				DNSLookupBaseURL: baseURL,
+				MaxDataAge:       time.Hour,
+				StalePolicy:      tt.policy,
+			})
+			client.now = func() time.Time { return now }
+
+			resp, _, err := client.Get(context.Background(), "whoisxmlapi.com")
+
+			var staleErr *StaleDataError
+			if errors.As(err, &staleErr) != tt.stale {
+				t.Fatalf("Get() error = %v, want stale %v", err, tt.stale)
+			}
+
+			if tt.stale && (staleErr.Age != 48*time.Hour || staleErr.MaxAge != time.Hour || staleErr.Response == nil) {
+				t.Errorf("StaleDataError = %+v", staleErr)
+			}
+
+			if !tt.stale && (err != nil || resp == nil) {
+				t.Errorf("Get() = %v, %v", resp, err)
+			}
+
+			if calls != tt.calls {
+				t.Errorf("calls = %d, want %d", calls, tt.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+//go:build go1.18
+
+package dnslookupapi
+
+import (
+	"testing"
+)
+
+// fuzzSeeds are the seed corpus of the response parsing fuzz targets.
+var fuzzSeeds = []string{
+	`{"DNSData":{"domainName":"example.com","dnsRecords":[]}}`,
+	`{"ErrorMessage":{"errorCode":"AUTH_1","msg":"bad key"}}`,
+	`{"DNSData":{"audit":{"createdDate":"2022-07-12 11:46:25 UTC","updatedDate":""},"dnsRecords":[` +
+		`{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"rawText":"example.com.\t300\tIN\tA\t1.1.1.1",` +
+		`"address":"1.1.1.1"},` +
+		`{"type":48,"dnsType":"DNSKEY","name":"example.com.","flags":257,"protocol":3,"algorithm":8,` +
+		`"key":["AwEAAa"]},` +
+		`{"type":43,"dnsType":"DS","name":"example.com.","keyTag":1,"algorithm":8,"digestType":2,` +
+		`"digest":["AB","CD"]},` +
+		`{"type":6,"dnsType":"SOA","admin":"hostmaster.example.com.","host":"ns.example.com.",` +
+		`"serial":2024010101,"refresh":7200,"retry":3600,"expire":1209600,"minimum":3600},` +
+		`{"type":257,"dnsType":"CAA","flags":128,"tag":"issue","value":"letsencrypt.org"},` +
+		`{"type":44,"dnsType":"SSHFP","algorithm":1,"fingerPrintType":2,"fingerPrint":"zz"},` +
+		`{"type":16,"dnsType":"TXT","strings":["v=spf1 -all"]},` +
+		`{"type":65,"dnsType":"HTTPS","rawText":"example.com. 300 IN HTTPS 1 ."}]}}`,
+}
+
+// FuzzParse checks that parsing arbitrary responses never panics.
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		resp, err := parse(data)
+		if err != nil {
+			return
+		}
+
+		exerciseRecords(&resp.DNSRecords)
+
+		_, _ = resp.DNSLookupResponse.MarshalAPI()
+	})
+}
+
+// FuzzDNSRecordsUnmarshal checks that parsing arbitrary DNS records and using their helpers never panics.
+func FuzzDNSRecordsUnmarshal(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		resp, err := parse([]byte(seed))
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		raw, err := resp.DNSRecords.MarshalAPI()
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(raw)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var records DNSRecords
+		if err := records.UnmarshalJSON(data); err != nil {
+			return
+		}
+
+		exerciseRecords(&records)
+
+		var lazy LazyDNSRecords
+		if err := lazy.UnmarshalJSON(data); err != nil {
+			t.Fatalf("LazyDNSRecords failed on records DNSRecords accepted: %v", err)
+		}
+
+		for _, record := range lazy.All() {
+			_ = lazy.Type(record.CommonFields.DNSType)
+		}
+	})
+}
+
+// FuzzTimeUnmarshal checks that the fast path of Time parsing agrees with the generic one.
+func FuzzTimeUnmarshal(f *testing.F) {
+	for _, seed := range []string{`"2022-07-12 11:46:25 UTC"`, `""`, `null`, `"2022-07-12 11:46:25 UTC"`, `1`} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v Time
+
+		err := v.UnmarshalJSON(data)
+
+		fast, ferr := unmarshalTimeString(data)
+		generic, gerr := unmarshalString(data)
+
+		if ferr == nil && gerr == nil && fast != generic {
+			t.Errorf("unmarshalTimeString(%q) = %q, want %q", data, fast, generic)
+		}
+
+		if err == nil {
+			if _, err = v.MarshalJSON(); err != nil {
+				t.Errorf("MarshalJSON() error = %v", err)
+			}
+		}
+	})
+}
+
+// exerciseRecords calls the helpers of the records which take API-controlled data.
+func exerciseRecords(records *DNSRecords) {
+	_ = records.ParseErrors()
+	_ = records.Records()
+	_ = records.MatchDS()
+	_, _ = records.IPs()
+	_ = records.CAAIodef()
+	_ = records.CAAAllowsIssuer("letsencrypt.org", true)
+	_ = records.FindTXT("v=spf1")
+	_, _ = records.MarshalAPI()
+	records.Sort()
+
+	for _, record := range records.All {
+		_, _ = record.ParseRawText()
+	}
+
+	for _, key := range records.DNSKEY {
+		_, _ = key.KeyTag()
+		_, _ = key.DS(DSDigestSHA256)
+	}
+
+	for _, ds := range records.DS {
+		_, _ = ds.DigestBytes()
+	}
+
+	for _, soa := range records.SOA {
+		_ = soa.Check()
+		_ = soa.ExpireDuration()
+	}
+
+	for _, caa := range records.CAA {
+		_ = caa.Issuer()
+	}
+
+	for _, sshfp := range records.SSHFP {
+		_, _ = sshfp.Matches([]byte("key"))
+	}
+
+	for _, txt := range records.TXT {
+		_, _ = ParseTags(txt.Value())
+	}
+}
@@ -0,0 +1,81 @@
+//go:build go1.18
+
+package dnslookupapi
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// RecordsOf returns all successfully parsed records of the type T in the order returned by the API.
+// T is a typed record, e.g. ARecord, or an interface, e.g. Record.
+func RecordsOf[T any](r *DNSRecords) []T {
+	var records []T
+
+	r.Each(func(_ DNSRecord, typed interface{}) bool {
+		if record, ok := typed.(T); ok {
+			records = append(records, record)
+		}
+
+		return true
+	})
+
+	return records
+}
+
+// Get returns the parsed records of the type T for the domain.
+// The DNS type of the query is derived from T, e.g. Get[MXRecord] requests MX records only.
+// If T is an interface, e.g. Record, or UnknownRecord, all the types are requested unless OptionType is set.
+func Get[T Record](ctx context.Context, service DNSLookupService, domainName string, opts ...Option) (
+	[]T, *Response, error) {
+	optsType := make([]Option, 0, len(opts)+1)
+	optsType = append(optsType, opts...)
+
+	if dnsType, ok := dnsTypeOf[T](); ok {
+		optsType = append(optsType, OptionType(dnsType))
+	}
+
+	dnsLookupResp, resp, err := service.Get(ctx, domainName, optsType...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return RecordsOf[T](dnsLookupResp.ParsedRecords()), resp, nil
+}
+
+// dnsTypeOf returns the DNS type of the typed record, e.g. "MX" for MXRecord.
+// It returns false if T is an interface or not a record of a single supported DNS type, e.g. UnknownRecord.
+func dnsTypeOf[T any]() (string, bool) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() == reflect.Interface {
+		return "", false
+	}
+
+	dnsType := strings.TrimSuffix(t.Name(), "Record")
+	if actualDNSType(dnsType) == nil && legacyDNSType(dnsType) == nil {
+		return "", false
+	}
+
+	return dnsType, true
+}
+
+// LazyRecordsOf returns the successfully parsed records of the type T decoding them on first access.
+// T is a typed record, e.g. ARecord. If T is an interface, e.g. Record, or UnknownRecord,
+// all the records are decoded, see LazyDNSRecords.DNSRecords.
+func LazyRecordsOf[T Record](r *LazyDNSRecords) []T {
+	dnsType, ok := dnsTypeOf[T]()
+	if !ok {
+		return RecordsOf[T](r.DNSRecords())
+	}
+
+	var records []T
+
+	for _, record := range r.Type(dnsType) {
+		if typed, ok := record.(T); ok {
+			records = append(records, typed)
+		}
+	}
+
+	return records
+}
@@ -0,0 +1,85 @@
+//go:build go1.18
+
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// TestRecordsOf tests the RecordsOf function.
+func TestRecordsOf(t *testing.T) {
+	r := testRecords(t)
+
+	a := RecordsOf[ARecord](r)
+	if len(a) != 2 || a[0].Address != "1.1.1.1" || a[1].Address != "2.2.2.2" {
+		t.Errorf("RecordsOf[ARecord]() = %+v", a)
+	}
+
+	if all := RecordsOf[Record](r); len(all) != 5 {
+		t.Errorf("RecordsOf[Record]() = %+v", all)
+	}
+
+	if aaaa := RecordsOf[AAAARecord](r); len(aaaa) != 0 {
+		t.Errorf("RecordsOf[AAAARecord]() = %+v", aaaa)
+	}
+}
+
+// TestGetTyped tests the Get function.
+func TestGetTyped(t *testing.T) {
+	const resp = `{"DNSData":{"dnsRecords":[{"type":15,"dnsType":"MX","target":"mx.example.com.","priority":10}]}}`
+
+	server := dummyServer(resp, resp, resp)
+	defer server.Close()
+
+	mx, _, err := Get[MXRecord](context.Background(), newAPI(server, pathDNSLookupResponseOK), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(mx) != 1 || mx[0].Target != "mx.example.com." {
+		t.Errorf("Get[MXRecord]() = %+v", mx)
+	}
+
+	all, _, err := Get[Record](context.Background(), newAPI(server, pathDNSLookupResponseOK), "example.com")
+	if err != nil || len(all) != 1 || all[0].GetDNSType() != "MX" {
+		t.Errorf("Get[Record]() = %+v, %v", all, err)
+	}
+
+	if got, ok := dnsTypeOf[NSEC3PARAMRecord](); got != "NSEC3PARAM" || !ok {
+		t.Errorf("dnsTypeOf[NSEC3PARAMRecord]() = %v, %v", got, ok)
+	}
+
+	if got, ok := dnsTypeOf[KXRecord](); got != "KX" || !ok {
+		t.Errorf("dnsTypeOf[KXRecord]() = %v, %v", got, ok)
+	}
+
+	if got, ok := dnsTypeOf[Record](); ok {
+		t.Errorf("dnsTypeOf[Record]() = %v, %v", got, ok)
+	}
+
+	if got, ok := dnsTypeOf[UnknownRecord](); ok {
+		t.Errorf("dnsTypeOf[UnknownRecord]() = %v, %v", got, ok)
+	}
+}
+
+// TestLazyRecordsOf tests the LazyRecordsOf function.
+func TestLazyRecordsOf(t *testing.T) {
+	var lazy LazyDNSRecords
+	if err := json.Unmarshal([]byte(lazyTestRecords), &lazy); err != nil {
+		t.Fatal(err)
+	}
+
+	if mx := LazyRecordsOf[MXRecord](&lazy); len(mx) != 1 || mx[0].Target != "mx.example.com." {
+		t.Errorf("LazyRecordsOf[MXRecord]() = %+v", mx)
+	}
+
+	if aaaa := LazyRecordsOf[AAAARecord](&lazy); len(aaaa) != 0 {
+		t.Errorf("LazyRecordsOf[AAAARecord]() = %+v", aaaa)
+	}
+
+	if all := LazyRecordsOf[Record](&lazy); len(all) != 3 {
+		t.Errorf("LazyRecordsOf[Record]() = %+v", all)
+	}
+}
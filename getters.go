@@ -0,0 +1,85 @@
+package dnslookupapi
+
+import "context"
+
+// GetA returns A records of the domain.
+func (c *Client) GetA(ctx context.Context, domainName string, opts ...Option) ([]ARecord, *Response, error) {
+	records, resp, err := c.getType(ctx, domainName, "A", opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return records.A, resp, nil
+}
+
+// GetAAAA returns AAAA records of the domain.
+func (c *Client) GetAAAA(ctx context.Context, domainName string, opts ...Option) ([]AAAARecord, *Response, error) {
+	records, resp, err := c.getType(ctx, domainName, "AAAA", opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return records.AAAA, resp, nil
+}
+
+// GetMX returns MX records of the domain.
+func (c *Client) GetMX(ctx context.Context, domainName string, opts ...Option) ([]MXRecord, *Response, error) {
+	records, resp, err := c.getType(ctx, domainName, "MX", opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return records.MX, resp, nil
+}
+
+// GetTXT returns TXT records of the domain.
+func (c *Client) GetTXT(ctx context.Context, domainName string, opts ...Option) ([]TXTRecord, *Response, error) {
+	records, resp, err := c.getType(ctx, domainName, "TXT", opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return records.TXT, resp, nil
+}
+
+// GetNS returns NS records of the domain.
+func (c *Client) GetNS(ctx context.Context, domainName string, opts ...Option) ([]NSRecord, *Response, error) {
+	records, resp, err := c.getType(ctx, domainName, "NS", opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return records.NS, resp, nil
+}
+
+// getType returns DNS records of the domain requested with the single DNS type.
+// The type overrides OptionType passed in opts. The records are served from ClientParams.TTLCache if it's set.
+func (c *Client) getType(
+	ctx context.Context,
+	domainName string,
+	dnsType string,
+	opts ...Option,
+) (*DNSRecords, *Response, error) {
+	var key ttlCacheKey
+	if c.ttlCache != nil {
+		key = newTTLCacheKey(domainName, dnsType, opts)
+		if records, resp, ok := c.ttlCache.get(key); ok {
+			return records, resp, nil
+		}
+	}
+
+	optsType := make([]Option, 0, len(opts)+1)
+	optsType = append(optsType, opts...)
+	optsType = append(optsType, OptionType(dnsType))
+
+	dnsLookupResp, resp, err := c.Get(ctx, domainName, optsType...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if c.ttlCache != nil {
+		c.ttlCache.set(key, dnsLookupResp.ParsedRecords(), resp)
+	}
+
+	return dnsLookupResp.ParsedRecords(), resp, nil
+}
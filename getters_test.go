@@ -0,0 +1,64 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestTypedGetters tests the typed getters.
+func TestTypedGetters(t *testing.T) {
+	records := map[string]string{
+		"A":    `{"type":1,"dnsType":"A","name":"whoisxmlapi.com.","ttl":300,"address":"104.26.13.210"}`,
+		"AAAA": `{"type":28,"dnsType":"AAAA","name":"whoisxmlapi.com.","ttl":300,"address":"2606:4700:20::681a:dd2"}`,
+		"MX": `{"type":15,"dnsType":"MX","name":"whoisxmlapi.com.","ttl":300,` +
+			`"target":"aspmx.l.google.com.","priority":1}`,
+		"TXT": `{"type":16,"dnsType":"TXT","name":"whoisxmlapi.com.","ttl":300,"strings":["v=spf1 -all"]}`,
+		"NS":  `{"type":2,"dnsType":"NS","name":"whoisxmlapi.com.","ttl":21600,"target":"elle.ns.cloudflare.com."}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[` +
+			records[req.URL.Query().Get("type")] + `]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL})
+	ctx := context.Background()
+
+	a, _, err := client.GetA(ctx, "whoisxmlapi.com", OptionType("MX"))
+	if err != nil || len(a) != 1 || a[0].Address != "104.26.13.210" {
+		t.Errorf("GetA() = %v, %v", a, err)
+	}
+
+	aaaa, _, err := client.GetAAAA(ctx, "whoisxmlapi.com")
+	if err != nil || len(aaaa) != 1 || aaaa[0].Address != "2606:4700:20::681a:dd2" {
+		t.Errorf("GetAAAA() = %v, %v", aaaa, err)
+	}
+
+	mx, _, err := client.GetMX(ctx, "whoisxmlapi.com")
+	if err != nil || len(mx) != 1 || mx[0].Target != "aspmx.l.google.com." {
+		t.Errorf("GetMX() = %v, %v", mx, err)
+	}
+
+	txt, _, err := client.GetTXT(ctx, "whoisxmlapi.com")
+	if err != nil || len(txt) != 1 || txt[0].Strings[0] != "v=spf1 -all" {
+		t.Errorf("GetTXT() = %v, %v", txt, err)
+	}
+
+	ns, resp, err := client.GetNS(ctx, "whoisxmlapi.com")
+	if err != nil || len(ns) != 1 || ns[0].Target != "elle.ns.cloudflare.com." {
+		t.Errorf("GetNS() = %v, %v", ns, err)
+	}
+
+	if got := resp.Request.URL.Query().Get("type"); got != "NS" {
+		t.Errorf("GetNS() requested type %v, want NS", got)
+	}
+
+	if _, _, err = client.GetA(ctx, ""); err == nil {
+		t.Error("GetA() expected error for empty domain name")
+	}
+}
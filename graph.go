@@ -0,0 +1,201 @@
+package dnslookupapi
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GraphNodeKind is the kind of the node of the resolution graph.
+type GraphNodeKind string
+
+// Kinds of graph nodes.
+const (
+	GraphNodeName    GraphNodeKind = "name"
+	GraphNodeAddress GraphNodeKind = "address"
+)
+
+// GraphNode is a domain name or an address in the resolution graph.
+type GraphNode struct {
+	// ID is the normalized domain name or the address.
+	ID string
+
+	// Kind is the kind of the node.
+	Kind GraphNodeKind
+}
+
+// GraphEdge is a record linking two nodes of the resolution graph.
+type GraphEdge struct {
+	// From is the ID of the owner name node.
+	From string
+
+	// To is the ID of the target name or address node.
+	To string
+
+	// DNSType is the DNS type of the record.
+	DNSType string
+
+	// Label is the additional record data, e.g. the MX priority. It may be empty.
+	Label string
+}
+
+// Graph is the resolution graph of the records: owner names linked to CNAME and DNAME targets,
+// A and AAAA addresses, MX, NS, SRV and PTR hosts. Nodes and edges are in the order of the records.
+type Graph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// Graph returns the resolution graph of the records.
+func (r *DNSRecords) Graph() *Graph {
+	g := &Graph{}
+
+	nodes := make(map[string]bool)
+	edges := make(map[GraphEdge]bool)
+
+	node := func(id string, kind GraphNodeKind) {
+		if !nodes[id] {
+			nodes[id] = true
+			g.Nodes = append(g.Nodes, GraphNode{ID: id, Kind: kind})
+		}
+	}
+
+	edge := func(owner, dnsType, target string, kind GraphNodeKind, label string) {
+		if kind == GraphNodeName {
+			target = NormalizeName(target)
+		}
+
+		if owner == "" || target == "" {
+			return
+		}
+
+		node(owner, GraphNodeName)
+		node(target, kind)
+
+		e := GraphEdge{From: owner, To: target, DNSType: dnsType, Label: label}
+		if !edges[e] {
+			edges[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	r.Each(func(record DNSRecord, typed interface{}) bool {
+		owner := record.CommonFields.Owner()
+
+		switch rec := typed.(type) {
+		case CNAMERecord:
+			edge(owner, "CNAME", rec.Target, GraphNodeName, "")
+		case DNAMERecord:
+			edge(owner, "DNAME", rec.Target, GraphNodeName, "")
+		case ARecord:
+			edge(owner, "A", rec.Address, GraphNodeAddress, "")
+		case AAAARecord:
+			edge(owner, "AAAA", rec.Address, GraphNodeAddress, "")
+		case MXRecord:
+			edge(owner, "MX", rec.Target, GraphNodeName, strconv.Itoa(rec.Priority))
+		case NSRecord:
+			edge(owner, "NS", rec.Target, GraphNodeName, "")
+		case SRVRecord:
+			edge(owner, "SRV", rec.Target, GraphNodeName, strconv.Itoa(rec.Priority)+" "+strconv.Itoa(rec.Port))
+		case PTRRecord:
+			edge(owner, "PTR", rec.Target, GraphNodeName, "")
+		}
+
+		return true
+	})
+
+	return g
+}
+
+// Graph returns the resolution graph of the response. The requested domain name is the first node.
+func (r *DNSLookupResponse) Graph() *Graph {
+	g := r.ParsedRecords().Graph()
+
+	root := NormalizeName(r.DomainName)
+	if root == "" {
+		return g
+	}
+
+	for i, n := range g.Nodes {
+		if n.ID == root && n.Kind == GraphNodeName {
+			copy(g.Nodes[1:i+1], g.Nodes[:i])
+			g.Nodes[0] = n
+
+			return g
+		}
+	}
+
+	g.Nodes = append([]GraphNode{{ID: root, Kind: GraphNodeName}}, g.Nodes...)
+
+	return g
+}
+
+// edgeLabel returns the DNS type of the edge followed by its label.
+func (e GraphEdge) edgeLabel() string {
+	if e.Label == "" {
+		return e.DNSType
+	}
+
+	return e.DNSType + " " + e.Label
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("digraph dns {\n\trankdir=LR;\n")
+
+	for _, n := range g.Nodes {
+		shape := "ellipse"
+		if n.Kind == GraphNodeAddress {
+			shape = "box"
+		}
+
+		bw.WriteString("\t" + dotQuote(n.ID) + " [shape=" + shape + "];\n")
+	}
+
+	for _, e := range g.Edges {
+		bw.WriteString("\t" + dotQuote(e.From) + " -> " + dotQuote(e.To) + " [label=" + dotQuote(e.edgeLabel()) + "];\n")
+	}
+
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	ids := make(map[string]string, len(g.Nodes))
+
+	bw.WriteString("flowchart LR\n")
+
+	for i, n := range g.Nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[n.ID] = id
+
+		if n.Kind == GraphNodeAddress {
+			bw.WriteString("    " + id + "[" + mermaidQuote(n.ID) + "]\n")
+		} else {
+			bw.WriteString("    " + id + "(" + mermaidQuote(n.ID) + ")\n")
+		}
+	}
+
+	for _, e := range g.Edges {
+		bw.WriteString("    " + ids[e.From] + " -->|" + mermaidQuote(e.edgeLabel()) + "| " + ids[e.To] + "\n")
+	}
+
+	return bw.Flush()
+}
+
+// dotQuote returns the DOT quoted string.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// mermaidQuote returns the Mermaid quoted label.
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
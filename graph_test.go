@@ -0,0 +1,86 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// graphTestRecords are the records of the graph tests.
+const graphTestRecords = `[
+	{"type":5,"dnsType":"CNAME","name":"www.example.com.","target":"example.cdn.net."},
+	{"type":1,"dnsType":"A","name":"example.cdn.net.","address":"192.0.2.1"},
+	{"type":28,"dnsType":"AAAA","name":"example.cdn.net.","address":"2001:db8::1"},
+	{"type":15,"dnsType":"MX","name":"Example.com.","target":"mx.example.com.","priority":10},
+	{"type":2,"dnsType":"NS","name":"example.com.","target":"ns1.example.net."},
+	{"type":2,"dnsType":"NS","name":"example.com.","target":"NS1.example.net."}
+]`
+
+// TestGraph tests rendering of the resolution graph.
+func TestGraph(t *testing.T) {
+	resp := DNSLookupResponse{DomainName: "example.com"}
+	if err := json.Unmarshal([]byte(graphTestRecords), &resp.DNSRecords); err != nil {
+		t.Fatal(err)
+	}
+
+	g := resp.Graph()
+	if len(g.Nodes) != 7 || g.Nodes[0].ID != "example.com" || len(g.Edges) != 5 {
+		t.Fatalf("Graph() = %+v", g)
+	}
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+
+	const wantDOT = `digraph dns {
+	rankdir=LR;
+	"example.com" [shape=ellipse];
+	"www.example.com" [shape=ellipse];
+	"example.cdn.net" [shape=ellipse];
+	"192.0.2.1" [shape=box];
+	"2001:db8::1" [shape=box];
+	"mx.example.com" [shape=ellipse];
+	"ns1.example.net" [shape=ellipse];
+	"www.example.com" -> "example.cdn.net" [label="CNAME"];
+	"example.cdn.net" -> "192.0.2.1" [label="A"];
+	"example.cdn.net" -> "2001:db8::1" [label="AAAA"];
+	"example.com" -> "mx.example.com" [label="MX 10"];
+	"example.com" -> "ns1.example.net" [label="NS"];
+}
+`
+	if dot.String() != wantDOT {
+		t.Errorf("WriteDOT() = %s, want %s", dot.String(), wantDOT)
+	}
+
+	var mermaid bytes.Buffer
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+
+	const wantMermaid = `flowchart LR
+    n0("example.com")
+    n1("www.example.com")
+    n2("example.cdn.net")
+    n3["192.0.2.1"]
+    n4["2001:db8::1"]
+    n5("mx.example.com")
+    n6("ns1.example.net")
+    n1 -->|"CNAME"| n2
+    n2 -->|"A"| n3
+    n2 -->|"AAAA"| n4
+    n0 -->|"MX 10"| n5
+    n0 -->|"NS"| n6
+`
+	if mermaid.String() != wantMermaid {
+		t.Errorf("WriteMermaid() = %s, want %s", mermaid.String(), wantMermaid)
+	}
+
+	if g := (&DNSLookupResponse{DomainName: "empty.com"}).Graph(); len(g.Nodes) != 1 || len(g.Edges) != 0 {
+		t.Errorf("Graph() of no records = %+v", g)
+	}
+
+	if q := dotQuote(`a"b\c`); q != `"a\"b\\c"` {
+		t.Errorf("dotQuote() = %s", q)
+	}
+}
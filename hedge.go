@@ -0,0 +1,113 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// attempt is the outcome of the request sent to one of the endpoints.
+type attempt struct {
+	resp  *http.Response
+	body  []byte
+	trace *redirectTrace
+	err   error
+}
+
+// failed reports whether the attempt failed and the next endpoint should be tried.
+func (a attempt) failed() bool {
+	return a.err != nil || isFailure(a.resp, nil)
+}
+
+// withBaseURL returns the copy of the request sent to the base URL.
+func withBaseURL(ctx context.Context, req *http.Request, base *url.URL) *http.Request {
+	r := req.Clone(ctx)
+
+	// the body isn't cloned, every copy gets its own reader
+	if req.GetBody != nil {
+		r.Body, _ = req.GetBody()
+	}
+
+	r.URL.Scheme = base.Scheme
+	r.URL.Host = base.Host
+	r.URL.Path = base.Path
+	r.URL.RawPath = base.RawPath
+	r.Host = base.Host
+
+	return r
+}
+
+// send sends the request to the base URL and, if mirrors are configured, hedges it across the mirrors.
+// A mirror is tried when the previous endpoint has not answered within the hedge delay or has failed
+// with a transport error or a 5xx or 429 status. The first successful answer wins and the other
+// requests are canceled. If all endpoints fail, the outcome of the last answered one is returned.
+func (service *dnsLookupServiceOp) send(ctx context.Context, req *http.Request) attempt {
+	do := func(ctx context.Context, r *http.Request) attempt {
+		var b bytes.Buffer
+
+		a := attempt{trace: &redirectTrace{}}
+		a.resp, a.err = service.client.Do(withRedirectTrace(ctx, a.trace), r, &b)
+		a.body = b.Bytes()
+
+		return a
+	}
+
+	if len(service.mirrors) == 0 || baseURLFromContext(ctx) != nil {
+		return do(ctx, req)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	endpoints := append([]*url.URL{service.baseURL}, service.mirrors...)
+	results := make(chan attempt, len(endpoints))
+
+	launched, pending := 0, 0
+	launch := func() {
+		r := withBaseURL(ctx, req, endpoints[launched])
+		launched++
+		pending++
+
+		go func() {
+			results <- do(ctx, r)
+		}()
+	}
+
+	var hedge <-chan time.Time
+
+	launch()
+
+	if service.hedgeDelay > 0 {
+		hedge = time.After(service.hedgeDelay)
+	}
+
+	var last attempt
+
+	for pending > 0 {
+		select {
+		case a := <-results:
+			pending--
+
+			if !a.failed() {
+				return a
+			}
+
+			last = a
+
+			if launched < len(endpoints) {
+				launch()
+			}
+		case <-hedge:
+			hedge = nil
+
+			if launched < len(endpoints) {
+				launch()
+				hedge = time.After(service.hedgeDelay)
+			}
+		}
+	}
+
+	return last
+}
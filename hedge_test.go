@@ -0,0 +1,124 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// hedgeServer returns the test server answering with its name after the delay.
+func hedgeServer(name string, status int, delay time.Duration, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(hits, 1)
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return
+		}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"` + name + `","dnsRecords":[]}}`))
+	}))
+}
+
+// TestHedgedRequests tests hedging requests across mirror endpoints.
+func TestHedgedRequests(t *testing.T) {
+	tests := []struct {
+		name          string
+		primaryStatus int
+		primaryDelay  time.Duration
+		hedgeDelay    time.Duration
+		mirrorStatus  int
+		want          string
+		wantStatus    int
+		wantMirrorHit int32
+	}{
+		{
+			name:          "primary answers in time",
+			primaryStatus: http.StatusOK,
+			hedgeDelay:    time.Second,
+			mirrorStatus:  http.StatusOK,
+			want:          "primary",
+			wantStatus:    http.StatusOK,
+			wantMirrorHit: 0,
+		},
+		{
+			name:          "slow primary",
+			primaryStatus: http.StatusOK,
+			primaryDelay:  time.Second,
+			hedgeDelay:    10 * time.Millisecond,
+			mirrorStatus:  http.StatusOK,
+			want:          "mirror",
+			wantStatus:    http.StatusOK,
+			wantMirrorHit: 1,
+		},
+		{
+			name:          "failing primary without hedge delay",
+			primaryStatus: http.StatusServiceUnavailable,
+			mirrorStatus:  http.StatusOK,
+			want:          "mirror",
+			wantStatus:    http.StatusOK,
+			wantMirrorHit: 1,
+		},
+		{
+			name:          "all endpoints fail",
+			primaryStatus: http.StatusServiceUnavailable,
+			mirrorStatus:  http.StatusBadGateway,
+			want:          "mirror",
+			wantStatus:    http.StatusBadGateway,
+			wantMirrorHit: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var primaryHits, mirrorHits int32
+
+			primary := hedgeServer("primary", tt.primaryStatus, tt.primaryDelay, &primaryHits)
+			defer primary.Close()
+
+			mirror := hedgeServer("mirror", tt.mirrorStatus, 0, &mirrorHits)
+			defer mirror.Close()
+
+			primaryURL, _ := url.Parse(primary.URL)
+			mirrorURL, _ := url.Parse(mirror.URL)
+
+			client := NewClient(apiKey, ClientParams{
+				DNSLookupBaseURL:    primaryURL,
+				DNSLookupMirrorURLs: []*url.URL{mirrorURL},
+				HedgeDelay:          tt.hedgeDelay,
+			})
+
+			start := time.Now()
+
+			resp, err := client.GetRaw(context.Background(), "whoisxmlapi.com")
+
+			var errResp *ErrorResponse
+			if err != nil && !errors.As(err, &errResp) {
+				t.Fatalf("GetRaw() error = %v", err)
+			}
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GetRaw() status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			if got := string(resp.Body); got != `{"DNSData":{"domainName":"`+tt.want+`","dnsRecords":[]}}` {
+				t.Errorf("GetRaw() body = %s, want answer from %s", got, tt.want)
+			}
+
+			if got := atomic.LoadInt32(&mirrorHits); got != tt.wantMirrorHit {
+				t.Errorf("mirror hits = %d, want %d", got, tt.wantMirrorHit)
+			}
+
+			if elapsed := time.Since(start); elapsed >= time.Second {
+				t.Errorf("GetRaw() took %v", elapsed)
+			}
+		})
+	}
+}
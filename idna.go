@@ -0,0 +1,145 @@
+package dnslookupapi
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	// maxLabelLength is the maximum length of a domain name label in the ASCII form.
+	maxLabelLength = 63
+
+	// maxDomainLength is the maximum length of a domain name in the ASCII form without the trailing dot.
+	maxDomainLength = 253
+
+	// acePrefix is the prefix of punycode encoded labels.
+	acePrefix = "xn--"
+)
+
+// ToASCII converts the domain name to the ASCII form, encoding Unicode labels (U-labels)
+// as punycode labels (A-labels), and validates the label syntax.
+// Unicode labels are mapped and validated using the UTS #46 lookup profile of golang.org/x/net/idna,
+// ASCII labels are kept as is to support underscores.
+// It returns ArgError if the domain name is not valid.
+func ToASCII(domainName string) (string, error) {
+	name := strings.Map(mapDot, domainName)
+
+	trailingDot := strings.HasSuffix(name, ".") && name != "."
+	name = strings.TrimSuffix(name, ".")
+
+	if name == "" {
+		return "", domainNameError("is empty")
+	}
+
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "" {
+			return "", domainNameError("has an empty label")
+		}
+
+		if !isASCII(label) {
+			encoded, err := idna.Lookup.ToASCII(label)
+			if err != nil || !isASCII(encoded) || strings.Contains(encoded, ".") {
+				return "", domainNameError("has a label which cannot be encoded: " + quote(label))
+			}
+
+			label = encoded
+		}
+
+		if err := validateLabel(label); err != nil {
+			return "", err
+		}
+
+		labels[i] = label
+	}
+
+	name = strings.Join(labels, ".")
+	if len(name) > maxDomainLength {
+		return "", domainNameError("is longer than 253 characters")
+	}
+
+	if trailingDot {
+		name += "."
+	}
+
+	return name, nil
+}
+
+// ToUnicode converts the domain name to the Unicode form, decoding punycode labels (A-labels).
+// Labels which are not valid punycode are left unchanged.
+func ToUnicode(domainName string) string {
+	labels := strings.Split(domainName, ".")
+
+	for i, label := range labels {
+		if len(label) <= len(acePrefix) || !strings.EqualFold(label[:len(acePrefix)], acePrefix) {
+			continue
+		}
+
+		decoded, err := idna.Lookup.ToUnicode(label)
+		if err == nil {
+			labels[i] = decoded
+		}
+	}
+
+	return strings.Join(labels, ".")
+}
+
+// validateLabel validates the syntax of the label in the ASCII form.
+// Underscores are allowed to support names like _dmarc.example.com.
+func validateLabel(label string) error {
+	if len(label) > maxLabelLength {
+		return domainNameError("has a label longer than 63 characters: " + quote(label))
+	}
+
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return domainNameError("has a label starting or ending with a hyphen: " + quote(label))
+	}
+
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return domainNameError("has an invalid character in the label: " + quote(label))
+		}
+	}
+
+	if len(label) > len(acePrefix) && strings.EqualFold(label[:len(acePrefix)], acePrefix) {
+		if _, err := idna.Lookup.ToUnicode(label); err != nil {
+			return domainNameError("has an invalid punycode label: " + quote(label))
+		}
+	}
+
+	return nil
+}
+
+// domainNameError returns ArgError for the domainName argument.
+func domainNameError(message string) *ArgError {
+	return &ArgError{Name: "domainName", Message: message}
+}
+
+// quote returns the string in double quotes.
+func quote(s string) string {
+	return `"` + s + `"`
+}
+
+// mapDot maps the Unicode full stops to the ASCII dot.
+func mapDot(r rune) rune {
+	switch r {
+	case '。', '．', '｡':
+		return '.'
+	}
+
+	return r
+}
+
+// isASCII reports whether the string consists of ASCII characters only.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestToASCII tests the domain name conversion to the ASCII form.
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		want    string
+		wantErr string
+	}{
+		{name: "ascii", domain: "whoisxmlapi.com", want: "whoisxmlapi.com"},
+		{name: "trailing dot", domain: "whoisxmlapi.com.", want: "whoisxmlapi.com."},
+		{name: "underscore", domain: "_dmarc.whoisxmlapi.com", want: "_dmarc.whoisxmlapi.com"},
+		{name: "german", domain: "bücher.de", want: "xn--bcher-kva.de"},
+		{name: "uppercase unicode", domain: "MÜNCHEN.de", want: "xn--mnchen-3ya.de"},
+		{name: "ideographic dot", domain: "bücher。de", want: "xn--bcher-kva.de"},
+		{name: "arabic", domain: "ليهمابتكلموشعربي؟.com", want: "xn--egbpdaj6bu4bxfgehfvwxn.com"},
+		{name: "chinese", domain: "他们为什么不说中文.cn", want: "xn--ihqwcrb4cv8a8dqg056pqjye.cn"},
+		{name: "already encoded", domain: "xn--bcher-kva.de", want: "xn--bcher-kva.de"},
+		{name: "decomposed", domain: "bu\u0308cher.de", want: "xn--bcher-kva.de"},
+		{name: "fullwidth", domain: "ｂüｃher.de", want: "xn--bcher-kva.de"},
+		{
+			name:    "zero width joiner",
+			domain:  "a\u200db.com",
+			wantErr: "invalid argument: \"domainName\" has a label which cannot be encoded: \"a\u200db\"",
+		},
+		{
+			name:    "disallowed rune",
+			domain:  "bü\u2028cher.de",
+			wantErr: "invalid argument: \"domainName\" has a label which cannot be encoded: \"bü\u2028cher\"",
+		},
+		{
+			name:    "empty",
+			domain:  "",
+			wantErr: `invalid argument: "domainName" is empty`,
+		},
+		{
+			name:    "empty label",
+			domain:  "whoisxmlapi..com",
+			wantErr: `invalid argument: "domainName" has an empty label`,
+		},
+		{
+			name:    "hyphen",
+			domain:  "-whoisxmlapi.com",
+			wantErr: `invalid argument: "domainName" has a label starting or ending with a hyphen: "-whoisxmlapi"`,
+		},
+		{
+			name:    "invalid character",
+			domain:  "whois!xmlapi.com",
+			wantErr: `invalid argument: "domainName" has an invalid character in the label: "whois!xmlapi"`,
+		},
+		{
+			name:    "invalid punycode",
+			domain:  "xn--a-.com",
+			wantErr: `invalid argument: "domainName" has a label starting or ending with a hyphen: "xn--a-"`,
+		},
+		{
+			name:    "invalid punycode digits",
+			domain:  "xn--99999999999.com",
+			wantErr: `invalid argument: "domainName" has an invalid punycode label: "xn--99999999999"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToASCII(tt.domain)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ToASCII() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ToASCII() = %v, want %v", got, tt.want)
+			}
+
+			if err != nil {
+				var argErr *ArgError
+				if !errors.As(err, &argErr) || argErr.Name != "domainName" {
+					t.Errorf("ToASCII() error = %#v, want ArgError", err)
+				}
+			}
+		})
+	}
+}
+
+// TestToUnicode tests the domain name conversion to the Unicode form.
+func TestToUnicode(t *testing.T) {
+	for ascii, want := range map[string]string{
+		"whoisxmlapi.com":                 "whoisxmlapi.com",
+		"xn--bcher-kva.de":                "bücher.de",
+		"XN--MNCHEN-3YA.de.":              "münchen.de.",
+		"xn--ihqwcrb4cv8a8dqg056pqjye.cn": "他们为什么不说中文.cn",
+		"xn--99999999999.com":             "xn--99999999999.com",
+		"xn--egbpdaj6bu4bxfgehfvwxn.com":  "ليهمابتكلموشعربي؟.com",
+	} {
+		if got := ToUnicode(ascii); got != want {
+			t.Errorf("ToUnicode(%q) = %v, want %v", ascii, got, want)
+		}
+	}
+}
+
+// TestDNSLookupGetIDN tests that Get sends internationalized domain names in the ASCII form.
+func TestDNSLookupGetIDN(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"` + req.URL.Query().Get("domainName") + `","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL})
+
+	resp, _, err := client.Get(context.Background(), "bücher.de")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if resp.DomainName != "xn--bcher-kva.de" || resp.ASCIIDomainName != "xn--bcher-kva.de" ||
+		resp.UnicodeDomainName != "bücher.de" {
+		t.Errorf("Get() domain names = %q, %q, %q", resp.DomainName, resp.ASCIIDomainName, resp.UnicodeDomainName)
+	}
+
+	if _, err = client.GetRaw(context.Background(), "bad name.de"); err == nil {
+		t.Error("GetRaw() expected error for invalid domain name")
+	}
+}
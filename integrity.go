@@ -0,0 +1,61 @@
+package dnslookupapi
+
+import "strings"
+
+// MismatchError is returned by Get with ClientParams.VerifyIntegrity when the response doesn't match
+// the request, e.g. when a caching proxy returned the response of another domain name.
+type MismatchError struct {
+	// DomainName is the domain name sent to the API
+	DomainName string
+
+	// ResponseDomainName is the domain name echoed in the response, empty if it matches
+	ResponseDomainName string
+
+	// Types are the types of the returned records which were not requested, with TypeUnrequested kind
+	Types []TypeMismatch
+
+	// Response is the mismatching response
+	Response *DNSLookupResponse
+}
+
+// Error returns error message as a string.
+func (e *MismatchError) Error() string {
+	var problems []string
+
+	if e.ResponseDomainName != "" {
+		problems = append(problems, "domain name "+quote(e.ResponseDomainName))
+	}
+
+	for _, t := range e.Types {
+		problems = append(problems, "unrequested type "+t.DNSType)
+	}
+
+	return "response mismatch for " + quote(e.DomainName) + ": " + strings.Join(problems, ", ")
+}
+
+// verifyIntegrity checks that the response echoes the queried domain name and holds records
+// of the requested types only. CNAME and DNAME records are accepted for any type.
+func verifyIntegrity(dnsLookupResponse *DNSLookupResponse, opts []Option) error {
+	mismatch := MismatchError{DomainName: dnsLookupResponse.ASCIIDomainName, Response: dnsLookupResponse}
+
+	if echoed := dnsLookupResponse.DomainName; echoed != "" && !EqualNames(echoed, mismatch.DomainName) {
+		if ascii, err := ToASCII(echoed); err != nil || !EqualNames(ascii, mismatch.DomainName) {
+			mismatch.ResponseDomainName = echoed
+		}
+	}
+
+	if types := typesOf(opts); types != nil {
+		requested := make(map[string]bool, len(types))
+		for _, t := range types {
+			requested[t] = true
+		}
+
+		mismatch.Types = unrequestedTypes(dnsLookupResponse.DNSRecords.All, requested)
+	}
+
+	if mismatch.ResponseDomainName == "" && len(mismatch.Types) == 0 {
+		return nil
+	}
+
+	return &mismatch
+}
@@ -0,0 +1,69 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestVerifyIntegrity tests that the mismatching responses are reported with MismatchError.
+func TestVerifyIntegrity(t *testing.T) {
+	const response = `{"DNSData":{"domainName":"%s","dnsTypes":"A","dnsRecords":[` +
+		`{"type":1,"dnsType":"A","name":"example.com.","ttl":60,"rRsetType":1,"rawText":"","address":"192.0.2.1"},` +
+		`{"type":5,"dnsType":"CNAME","name":"example.com.","ttl":60,"rRsetType":5,"rawText":"","target":"a.example."},` +
+		`{"type":15,"dnsType":"MX","name":"example.com.","ttl":60,"rRsetType":15,"rawText":"","priority":10}]}}`
+
+	tests := []struct {
+		name       string
+		domainName string
+		echoed     string
+		opts       []Option
+		wantDomain string
+		wantTypes  int
+	}{
+		{name: "match", domainName: "Example.COM", echoed: "example.com."},
+		{name: "idn", domainName: "bücher.example", echoed: "bücher.example"},
+		{name: "domain", domainName: "example.com", echoed: "example.org", wantDomain: "example.org"},
+		{name: "types", domainName: "example.com", echoed: "example.com", opts: []Option{OptionType("A")}, wantTypes: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				_, _ = fmt.Fprintf(w, response, tt.echoed)
+			}))
+			defer server.Close()
+
+			baseURL, _ := url.Parse(server.URL)
+			client := NewClient(apiKey, ClientParams{
+				HTTPClient:       server.Client(),
+				DNSLookupBaseURL: baseURL,
+				VerifyIntegrity:  true,
+			})
+
+			_, _, err := client.Get(context.Background(), tt.domainName, tt.opts...)
+			if tt.wantDomain == "" && tt.wantTypes == 0 {
+				checkErr(t, err, "")
+				return
+			}
+
+			var mismatch *MismatchError
+			if !errors.As(err, &mismatch) {
+				t.Fatalf("Get() error = %v, want MismatchError", err)
+			}
+
+			if mismatch.ResponseDomainName != tt.wantDomain || len(mismatch.Types) != tt.wantTypes ||
+				mismatch.Response == nil {
+				t.Errorf("Get() error = %+v", mismatch)
+			}
+
+			if tt.wantTypes != 0 && (mismatch.Types[0].DNSType != "MX" || mismatch.Types[0].Count != 1) {
+				t.Errorf("Get() types = %v", mismatch.Types)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+//go:build go1.23
+
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// errStopStream stops GetStream when the consumer of Stream breaks the loop.
+var errStopStream = errors.New("stream stopped")
+
+// Iter returns the iterator over all successfully parsed records in the order returned by the API.
+// The records are typed, e.g. ARecord or MXRecord.
+func (r *DNSRecords) Iter() iter.Seq[Record] {
+	return func(yield func(Record) bool) {
+		r.Each(func(_ DNSRecord, typed interface{}) bool {
+			record, ok := typed.(Record)
+
+			return !ok || yield(record)
+		})
+	}
+}
+
+// IterAll returns the iterator over All including the records which failed to parse, indexed by position.
+func (r *DNSRecords) IterAll() iter.Seq2[int, DNSRecord] {
+	return func(yield func(int, DNSRecord) bool) {
+		for i, record := range r.All {
+			if !yield(i, record) {
+				return
+			}
+		}
+	}
+}
+
+// IterOf returns the iterator over the successfully parsed records of the type T in the order
+// returned by the API. T is a typed record, e.g. ARecord, or an interface, e.g. Record.
+func IterOf[T any](r *DNSRecords) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		r.Each(func(_ DNSRecord, typed interface{}) bool {
+			record, ok := typed.(T)
+
+			return !ok || yield(record)
+		})
+	}
+}
+
+// Stream returns the iterator over the records decoded incrementally by GetStream.
+// Breaking the loop stops decoding and closes the response. If the lookup fails,
+// the error is yielded once as the last element with the zero DNSRecord.
+func (c *Client) Stream(ctx context.Context, domainName string, opts ...Option) iter.Seq2[DNSRecord, error] {
+	return func(yield func(DNSRecord, error) bool) {
+		_, err := c.GetStream(ctx, domainName, func(record DNSRecord) error {
+			if !yield(record, nil) {
+				return errStopStream
+			}
+
+			return nil
+		}, opts...)
+
+		if err != nil && !errors.Is(err, errStopStream) {
+			yield(DNSRecord{}, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+//go:build go1.23
+
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+// TestIter tests iterating over the records.
+func TestIter(t *testing.T) {
+	const records = `[
+		{"type":1,"dnsType":"A","name":"example.com.","address":"192.0.2.1"},
+		{"type":15,"dnsType":"MX","name":"example.com.","target":"mx.example.com.","priority":10},
+		{"type":1,"dnsType":"A","name":"example.com.","address":"192.0.2.2"},
+		{"type":15,"dnsType":"MX","name":"example.com.","priority":"broken"}
+	]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	var types []string
+	for record := range r.Iter() {
+		types = append(types, record.GetDNSType())
+	}
+
+	if len(types) != 3 || types[0] != "A" || types[1] != "MX" || types[2] != "A" {
+		t.Errorf("Iter() = %v", types)
+	}
+
+	var addresses []string
+	for a := range IterOf[ARecord](&r) {
+		addresses = append(addresses, a.Address)
+
+		break
+	}
+
+	if len(addresses) != 1 || addresses[0] != "192.0.2.1" {
+		t.Errorf("IterOf[ARecord]() = %v", addresses)
+	}
+
+	n := 0
+	for i, record := range r.IterAll() {
+		if i == 3 && record.ParseError == nil {
+			t.Error("IterAll() skipped the parse error")
+		}
+
+		n++
+	}
+
+	if n != 4 {
+		t.Errorf("IterAll() yielded %d records, want 4", n)
+	}
+}
+
+// TestStream tests iterating over the streamed records.
+func TestStream(t *testing.T) {
+	const resp = `{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[
+{"type":1,"dnsType":"A","name":"whoisxmlapi.com.","address":"104.26.13.210"},
+{"type":15,"dnsType":"MX","name":"whoisxmlapi.com.","target":"mx.whoisxmlapi.com.","priority":10}
+]}}`
+
+	server := dummyServer(resp, `<>`, `{"ErrorMessage":{"errorCode":"TEST_CODE","msg":"test error message"}}`)
+	defer server.Close()
+
+	var types []string
+	for record, err := range newAPI(server, pathDNSLookupResponseOK).Stream(context.Background(), "whoisxmlapi.com") {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		types = append(types, record.CommonFields.DNSType)
+
+		break
+	}
+
+	if len(types) != 1 || types[0] != "A" {
+		t.Errorf("Stream() = %v", types)
+	}
+
+	var errs int
+	for _, err := range newAPI(server, pathDNSLookupResponseError).Stream(context.Background(), "whoisxmlapi.com") {
+		if err == nil {
+			t.Error("Stream() yielded a record of the failed lookup")
+		}
+
+		errs++
+	}
+
+	if errs != 1 {
+		t.Errorf("Stream() yielded %d errors, want 1", errs)
+	}
+}
@@ -0,0 +1,66 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// ErrInvalidJSONP is returned when the response is not wrapped into the expected JSONP callback call.
+var ErrInvalidJSONP = errors.New("invalid JSONP response")
+
+// StripJSONP returns the JSON wrapped into the JSONP callback call, e.g. `callbackName({...});`.
+// If callbackName is empty then any callback name is accepted.
+func StripJSONP(callbackName string, body []byte) ([]byte, error) {
+	body = bytes.TrimSpace(body)
+
+	open := bytes.IndexByte(body, '(')
+	if open < 0 {
+		return nil, fmt.Errorf("%w: no callback call", ErrInvalidJSONP)
+	}
+
+	name := bytes.TrimSpace(body[:open])
+	if callbackName != "" && string(name) != callbackName {
+		return nil, fmt.Errorf("%w: callback %q, expected %q", ErrInvalidJSONP, name, callbackName)
+	}
+
+	rest := bytes.TrimSpace(bytes.TrimSuffix(body[open+1:], []byte(";")))
+	if !bytes.HasSuffix(rest, []byte(")")) {
+		return nil, fmt.Errorf("%w: unterminated callback call", ErrInvalidJSONP)
+	}
+
+	return bytes.TrimSpace(rest[:len(rest)-1]), nil
+}
+
+// ParseJSONP parses the DNS Lookup API response requested with OptionCallback.
+func ParseJSONP(callbackName string, body []byte) (*DNSLookupResponse, error) {
+	raw, err := StripJSONP(callbackName, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Message != "" || resp.Code != "" {
+		return nil, &ErrorMessage{
+			Code:    resp.Code,
+			Message: resp.Message,
+		}
+	}
+
+	return &resp.DNSLookupResponse, nil
+}
+
+// callbackOf returns the JSONP callback name set by the options.
+func callbackOf(opts []Option) string {
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	return query.Get("callback")
+}
@@ -0,0 +1,92 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestStripJSONP tests stripping the JSONP callback wrapper.
+func TestStripJSONP(t *testing.T) {
+	tests := []struct {
+		name     string
+		callback string
+		body     string
+		want     string
+		wantErr  error
+	}{
+		{name: "plain", callback: "cb", body: `cb({"a":1})`, want: `{"a":1}`},
+		{name: "semicolon and spaces", callback: "cb", body: " cb ( {\"a\":1} );\n", want: `{"a":1}`},
+		{name: "any callback", callback: "", body: `other({"a":1})`, want: `{"a":1}`},
+		{name: "other callback", callback: "cb", body: `other({"a":1})`, wantErr: ErrInvalidJSONP},
+		{name: "not wrapped", callback: "cb", body: `{"a":1}`, wantErr: ErrInvalidJSONP},
+		{name: "unterminated", callback: "cb", body: `cb({"a":1}`, wantErr: ErrInvalidJSONP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StripJSONP(tt.callback, []byte(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StripJSONP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("StripJSONP() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseJSONP tests parsing JSONP responses.
+func TestParseJSONP(t *testing.T) {
+	resp, err := ParseJSONP("cb", []byte(`cb({"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[`+
+		`{"type":1,"dnsType":"A","name":"whoisxmlapi.com.","ttl":300,"address":"104.26.13.210"}]}});`))
+	if err != nil {
+		t.Fatalf("ParseJSONP() error = %v", err)
+	}
+
+	if resp.DomainName != "whoisxmlapi.com" || len(resp.DNSRecords.A) != 1 {
+		t.Errorf("ParseJSONP() = %v", resp)
+	}
+
+	_, err = ParseJSONP("cb", []byte(`cb({"ErrorMessage":{"errorCode":"TEST_CODE","msg":"test error message"}})`))
+
+	var apiErr *ErrorMessage
+	if !errors.As(err, &apiErr) || apiErr.Code != "TEST_CODE" {
+		t.Errorf("ParseJSONP() error = %v, want ErrorMessage", err)
+	}
+}
+
+// TestDNSLookupGetJSONP tests that Get parses responses requested with OptionCallback.
+func TestDNSLookupGetJSONP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body := `{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[]}}`
+		if callback := req.URL.Query().Get("callback"); callback != "" {
+			body = callback + "(" + body + ")"
+		}
+
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL})
+
+	resp, raw, err := client.Get(context.Background(), "whoisxmlapi.com", OptionCallback("handle"))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if resp.DomainName != "whoisxmlapi.com" || string(raw.Body[:7]) != "handle(" {
+		t.Errorf("Get() = %v, body %s", resp, raw.Body)
+	}
+
+	_, err = client.GetStream(context.Background(), "whoisxmlapi.com", func(DNSRecord) error { return nil },
+		OptionCallback("handle"))
+	if err != nil {
+		t.Errorf("GetStream() error = %v", err)
+	}
+}
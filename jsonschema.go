@@ -0,0 +1,368 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// responseSchema is the JSON Schema (draft-07) of the DNS Lookup API response.
+//
+//go:embed schema/response.json
+var responseSchema []byte
+
+// SchemaViolation is a single deviation of the response from the API schema.
+type SchemaViolation struct {
+	// Path is the JSON Pointer of the deviating value, e.g. "/DNSData/dnsRecords/3/priority".
+	Path string
+
+	// Keyword is the schema keyword that failed, e.g. "type" or "additionalProperties".
+	Keyword string
+
+	// Message describes the deviation.
+	Message string
+}
+
+// String returns the violation as "<path>: <message>".
+func (v SchemaViolation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return path + ": " + v.Message
+}
+
+// ResponseSchema returns the embedded JSON Schema of the DNS Lookup API response used by ValidateResponse.
+func ResponseSchema() []byte {
+	return append([]byte(nil), responseSchema...)
+}
+
+var (
+	compiledSchema     *jsonSchema
+	compiledSchemaOnce sync.Once
+)
+
+// ValidateResponse validates the raw JSON response against the embedded API schema.
+// It returns SchemaError with Violations describing new fields, type changes and unknown record types.
+func ValidateResponse(raw []byte) error {
+	compiledSchemaOnce.Do(func() {
+		compiledSchema = &jsonSchema{patterns: make(map[string]*regexp.Regexp)}
+		if err := json.Unmarshal(responseSchema, &compiledSchema.root); err != nil {
+			panic("dnslookupapi: invalid embedded schema: " + err.Error())
+		}
+	})
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var instance interface{}
+	if err := decoder.Decode(&instance); err != nil {
+		return &SchemaError{
+			Errors:     []string{err.Error()},
+			Violations: []SchemaViolation{{Keyword: "json", Message: err.Error()}},
+		}
+	}
+
+	violations := compiledSchema.validate(compiledSchema.root, instance, "")
+	if len(violations) == 0 {
+		return nil
+	}
+
+	errs := make([]string, 0, len(violations))
+	for _, v := range violations {
+		errs = append(errs, v.String())
+	}
+
+	return &SchemaError{Errors: errs, Violations: violations}
+}
+
+// jsonSchema validates values against the subset of JSON Schema used by the embedded schema:
+// $ref to local definitions, type, const, enum, pattern, properties, required, additionalProperties,
+// items, anyOf and oneOf.
+type jsonSchema struct {
+	root map[string]interface{}
+
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+// validate returns the violations of the value at the path.
+func (s *jsonSchema) validate(schema map[string]interface{}, value interface{}, path string) []SchemaViolation {
+	if ref, ok := schema["$ref"].(string); ok {
+		return s.validate(s.resolve(ref), value, path)
+	}
+
+	violation := func(keyword, message string) []SchemaViolation {
+		return []SchemaViolation{{Path: path, Keyword: keyword, Message: message}}
+	}
+
+	if t, ok := schema["type"].(string); ok && !hasJSONType(value, t) {
+		return violation("type", "expected "+t+", got "+jsonTypeOf(value))
+	}
+
+	if c, ok := schema["const"]; ok && !equalJSON(c, value) {
+		return violation("const", "expected "+encodeJSON(c)+", got "+encodeJSON(value))
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsJSON(enum, value) {
+		return violation("enum", "unexpected value "+encodeJSON(value))
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		if str, ok := value.(string); ok && !s.pattern(pattern).MatchString(str) {
+			return violation("pattern", "value "+strconv.Quote(str)+" does not match "+strconv.Quote(pattern))
+		}
+	}
+
+	var violations []SchemaViolation
+
+	if object, ok := value.(map[string]interface{}); ok {
+		violations = append(violations, s.validateObject(schema, object, path)...)
+	}
+
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		if array, ok := value.([]interface{}); ok {
+			for i, item := range array {
+				violations = append(violations, s.validate(items, item, path+"/"+strconv.Itoa(i))...)
+			}
+		}
+	}
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok && !s.matchesAny(anyOf, value, path) {
+		violations = append(violations, violation("anyOf", "value matches none of the alternatives")...)
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		violations = append(violations, s.validateOneOf(oneOf, value, path)...)
+	}
+
+	return violations
+}
+
+// validateObject validates the properties of the object.
+func (s *jsonSchema) validateObject(
+	schema map[string]interface{},
+	object map[string]interface{},
+	path string,
+) []SchemaViolation {
+	var violations []SchemaViolation
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, ok := object[name]; !ok {
+					violations = append(violations, SchemaViolation{
+						Path: path, Keyword: "required", Message: "missing property " + strconv.Quote(name),
+					})
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "/" + escapePointer(name)
+
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			violations = append(violations, s.validate(property, object[name], propertyPath)...)
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				violations = append(violations, SchemaViolation{
+					Path: propertyPath, Keyword: "additionalProperties", Message: "unknown property",
+				})
+			}
+		case map[string]interface{}:
+			violations = append(violations, s.validate(additional, object[name], propertyPath)...)
+		}
+	}
+
+	return violations
+}
+
+// validateOneOf validates the value against exactly one of the alternatives. Alternatives discriminated by
+// a const property, such as dnsType of the records, are chosen by it, so the violations of the matching
+// alternative are reported instead of a generic mismatch.
+func (s *jsonSchema) validateOneOf(oneOf []interface{}, value interface{}, path string) []SchemaViolation {
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, alternative := range oneOf {
+			schema := s.schemaOf(alternative)
+			if name, c, ok := s.discriminator(schema); ok {
+				if equalJSON(c, object[name]) {
+					return s.validate(schema, value, path)
+				}
+			}
+		}
+
+		for _, alternative := range oneOf {
+			if name, _, ok := s.discriminator(s.schemaOf(alternative)); ok {
+				return []SchemaViolation{{
+					Path:    path + "/" + escapePointer(name),
+					Keyword: "oneOf",
+					Message: "no schema for " + name + " " + encodeJSON(object[name]),
+				}}
+			}
+		}
+	}
+
+	matches := 0
+
+	for _, alternative := range oneOf {
+		if len(s.validate(s.schemaOf(alternative), value, path)) == 0 {
+			matches++
+		}
+	}
+
+	if matches != 1 {
+		return []SchemaViolation{{
+			Path: path, Keyword: "oneOf", Message: "value matches " + strconv.Itoa(matches) + " alternatives, want 1",
+		}}
+	}
+
+	return nil
+}
+
+// matchesAny reports whether the value matches any of the alternatives.
+func (s *jsonSchema) matchesAny(anyOf []interface{}, value interface{}, path string) bool {
+	for _, alternative := range anyOf {
+		if len(s.validate(s.schemaOf(alternative), value, path)) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// discriminator returns the property of the schema with the const value.
+func (s *jsonSchema) discriminator(schema map[string]interface{}) (name string, value interface{}, ok bool) {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	for name, property := range properties {
+		if property, ok := property.(map[string]interface{}); ok {
+			if c, ok := property["const"]; ok {
+				return name, c, true
+			}
+		}
+	}
+
+	return "", nil, false
+}
+
+// schemaOf returns the schema with the reference resolved.
+func (s *jsonSchema) schemaOf(v interface{}) map[string]interface{} {
+	schema, _ := v.(map[string]interface{})
+	if ref, ok := schema["$ref"].(string); ok {
+		return s.resolve(ref)
+	}
+
+	return schema
+}
+
+// resolve returns the schema of the local reference, e.g. "#/definitions/audit".
+func (s *jsonSchema) resolve(ref string) map[string]interface{} {
+	var current interface{} = s.root
+
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		object, _ := current.(map[string]interface{})
+		current = object[strings.NewReplacer("~1", "/", "~0", "~").Replace(token)]
+	}
+
+	schema, _ := current.(map[string]interface{})
+
+	return schema
+}
+
+// pattern returns the compiled regular expression.
+func (s *jsonSchema) pattern(pattern string) *regexp.Regexp {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	re, ok := s.patterns[pattern]
+	if !ok {
+		re = regexp.MustCompile(pattern)
+		s.patterns[pattern] = re
+	}
+
+	return re
+}
+
+// hasJSONType reports whether the value decoded with UseNumber is of the JSON Schema type.
+func hasJSONType(value interface{}, t string) bool {
+	actual := jsonTypeOf(value)
+
+	return actual == t || t == "number" && actual == "integer"
+}
+
+// jsonTypeOf returns the JSON Schema type of the value decoded with UseNumber.
+func jsonTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// equalJSON reports whether the decoded JSON values are equal.
+func equalJSON(a, b interface{}) bool {
+	return encodeJSON(a) == encodeJSON(b)
+}
+
+// containsJSON reports whether the decoded JSON value is in the list.
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equalJSON(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encodeJSON returns the JSON encoding of the decoded value.
+func encodeJSON(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "?"
+	}
+
+	return string(b)
+}
+
+// escapePointer escapes the JSON Pointer token.
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,165 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestValidateResponse tests validation against the embedded API schema.
+func TestValidateResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		violations []SchemaViolation
+	}{
+		{
+			name: "valid",
+			raw: `{"DNSData":{"domainName":"example.com","types":[1,15],"dnsTypes":"A,MX",` +
+				`"audit":{"createdDate":"2022-07-12 11:46:25 UTC","updatedDate":""},"dnsRecords":[` +
+				`{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"rRsetType":1,"address":"1.1.1.1"},` +
+				`{"type":15,"dnsType":"MX","target":"mx.example.com.","priority":10}]}}`,
+		},
+		{
+			name: "error message",
+			raw:  `{"ErrorMessage":{"errorCode":"WHOIS_01","msg":"Invalid domain"}}`,
+		},
+		{
+			name: "new fields",
+			raw:  `{"DNSData":{"domainName":"example.com","dnsRecords":[],"region":"eu"},"requestId":"1"}`,
+			violations: []SchemaViolation{
+				{Path: "/DNSData/region", Keyword: "additionalProperties", Message: "unknown property"},
+				{Path: "/requestId", Keyword: "additionalProperties", Message: "unknown property"},
+			},
+		},
+		{
+			name: "type changes",
+			raw: `{"DNSData":{"domainName":"example.com","types":["A"],"audit":{"createdDate":"2022-07-12T11:46:25Z"},` +
+				`"dnsRecords":[{"dnsType":"MX","priority":"10"},{"dnsType":"A","ttl":1.5}]}}`,
+			violations: []SchemaViolation{
+				{Path: "/DNSData/audit/createdDate", Keyword: "pattern",
+					Message: `value "2022-07-12T11:46:25Z" does not match ` +
+						`"^$|^[0-9]{4}-[0-9]{2}-[0-9]{2} [0-9]{2}:[0-9]{2}:[0-9]{2} [A-Z]+$"`},
+				{Path: "/DNSData/dnsRecords/0/priority", Keyword: "type", Message: "expected integer, got string"},
+				{Path: "/DNSData/dnsRecords/1/ttl", Keyword: "type", Message: "expected integer, got number"},
+				{Path: "/DNSData/types/0", Keyword: "type", Message: "expected integer, got string"},
+			},
+		},
+		{
+			name: "unknown record type and missing fields",
+			raw:  `{"DNSData":{"domainName":"example.com","dnsRecords":[{"dnsType":"HTTPS"},{"type":1}]}}`,
+			violations: []SchemaViolation{
+				{Path: "/DNSData/dnsRecords/0/dnsType", Keyword: "oneOf", Message: `no schema for dnsType "HTTPS"`},
+				{Path: "/DNSData/dnsRecords/1", Keyword: "required", Message: `missing property "dnsType"`},
+				{Path: "/DNSData/dnsRecords/1/dnsType", Keyword: "oneOf", Message: "no schema for dnsType null"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResponse([]byte(tt.raw))
+			if tt.violations == nil {
+				if err != nil {
+					t.Fatalf("ValidateResponse() error = %v", err)
+				}
+
+				return
+			}
+
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("ValidateResponse() error = %v, want SchemaError", err)
+			}
+
+			if !reflect.DeepEqual(schemaErr.Violations, tt.violations) {
+				t.Errorf("Violations = %+v, want %+v", schemaErr.Violations, tt.violations)
+			}
+
+			if len(schemaErr.Errors) != len(tt.violations) || schemaErr.Errors[0] != tt.violations[0].String() {
+				t.Errorf("Errors = %v", schemaErr.Errors)
+			}
+		})
+	}
+}
+
+// TestResponseSchemaCoverage tests that the embedded schema describes all record types and fields of the library.
+func TestResponseSchemaCoverage(t *testing.T) {
+	var schema struct {
+		Definitions map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"definitions"`
+	}
+
+	if err := json.Unmarshal(ResponseSchema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dnsType := range rrTypeNames {
+		actual := actualDNSType(dnsType)
+		if actual == nil {
+			continue
+		}
+
+		definition, ok := schema.Definitions["record"+dnsType]
+		if !ok {
+			t.Errorf("no schema definition of %s records", dnsType)
+			continue
+		}
+
+		var fields []string
+
+		var collect func(rt reflect.Type)
+		collect = func(rt reflect.Type) {
+			for i := 0; i < rt.NumField(); i++ {
+				if f := rt.Field(i); f.Anonymous {
+					collect(f.Type)
+				} else {
+					fields = append(fields, strings.Split(f.Tag.Get("json"), ",")[0])
+				}
+			}
+		}
+
+		collect(reflect.TypeOf(actual).Elem())
+
+		if len(fields) != len(definition.Properties) {
+			t.Errorf("%s: schema has %d properties, want %d", dnsType, len(definition.Properties), len(fields))
+		}
+
+		for _, field := range fields {
+			if _, ok := definition.Properties[field]; !ok {
+				t.Errorf("%s: no schema of property %s", dnsType, field)
+			}
+		}
+	}
+}
+
+// TestValidateJSONSchemaOption tests validation of responses by Get.
+func TestValidateJSONSchemaOption(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[{"dnsType":"A","address":1}]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_key", ClientParams{
+		HTTPClient:         server.Client(),
+		DNSLookupBaseURL:   baseURL,
+		ValidateJSONSchema: true,
+	})
+
+	_, _, err := client.Get(context.Background(), "example.com")
+
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || len(schemaErr.Violations) != 1 ||
+		schemaErr.Violations[0].Path != "/DNSData/dnsRecords/0/address" {
+		t.Errorf("Get() error = %v", err)
+	}
+}
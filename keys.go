@@ -0,0 +1,79 @@
+package dnslookupapi
+
+import "sync"
+
+// KeyProvider provides API keys used by the client.
+type KeyProvider interface {
+	// Keys returns the API keys in the order they should be tried.
+	Keys() []string
+}
+
+// StaticKeys is the KeyProvider returning the fixed list of API keys.
+type StaticKeys []string
+
+// Keys returns the API keys.
+func (k StaticKeys) Keys() []string {
+	return k
+}
+
+// keyRing selects the API key for requests and rotates keys on authentication and credit errors.
+type keyRing struct {
+	provider KeyProvider
+
+	mu      sync.Mutex
+	current string
+}
+
+// keys returns the API keys starting from the key which served the last successful request.
+func (r *keyRing) keys() []string {
+	keys := r.provider.Keys()
+
+	r.mu.Lock()
+	current := r.current
+	r.mu.Unlock()
+
+	for i, key := range keys {
+		if key == current && i > 0 {
+			return append(append([]string(nil), keys[i:]...), keys[:i]...)
+		}
+	}
+
+	return keys
+}
+
+// first returns the API key to be used for the next request.
+func (r *keyRing) first() string {
+	keys := r.keys()
+	if len(keys) == 0 {
+		return ""
+	}
+
+	return keys[0]
+}
+
+// use remembers the key which served the successful request.
+func (r *keyRing) use(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.current = key
+}
+
+// shouldRotate reports whether the response means that the API key cannot be used anymore.
+// Only the status code and the error code are trusted, the error message may echo the domain name.
+func shouldRotate(statusCode int, body []byte) bool {
+	code, _ := parseErrorBody(body)
+
+	known, restricted := classifyCode(statusCode, code)
+
+	return restricted || known == ErrAuthentication || known == ErrInsufficientCredits
+}
+
+// maskKey returns the API key with all but the first 3 and the last 4 characters hidden.
+func maskKey(key string) string {
+	if len(key) <= 7 {
+		return "***"
+	}
+
+	return key[:3] + "..." + key[len(key)-4:]
+}
@@ -0,0 +1,129 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// TestKeyRotation tests that the client rotates API keys on authentication and credit errors.
+func TestKeyRotation(t *testing.T) {
+	const resp = `{"DNSData":{"dnsRecords":[]}}`
+
+	var (
+		mu   sync.Mutex
+		used []string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := req.URL.Query().Get("apiKey")
+
+		mu.Lock()
+		used = append(used, key)
+		mu.Unlock()
+
+		switch key {
+		case "at_revokedKey0000":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code":401,"messages":"ApiKey authenticate failed"}`))
+		case "at_emptyBalance00":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":403,"messages":"Access restricted. Check credits balance."}`))
+		default:
+			_, _ = w.Write([]byte(resp))
+		}
+	}))
+	defer server.Close()
+
+	apiURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	api := NewClient("at_revokedKey0000", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: apiURL,
+		APIKeys:          []string{"at_emptyBalance00", "at_workingKey0000"},
+	})
+
+	for i := 0; i < 2; i++ {
+		_, got, err := api.Get(context.Background(), "whoisxmlapi.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.KeyID != "at_...0000" || got.Request.URL.Query().Get("apiKey") != "at_workingKey0000" {
+			t.Errorf("KeyID = %v", got.KeyID)
+		}
+	}
+
+	want := []string{"at_revokedKey0000", "at_emptyBalance00", "at_workingKey0000", "at_workingKey0000"}
+	if len(used) != len(want) {
+		t.Fatalf("used keys = %v, want %v", used, want)
+	}
+
+	for i := range want {
+		if used[i] != want[i] {
+			t.Errorf("used keys = %v, want %v", used, want)
+		}
+	}
+
+	single := NewClient("at_revokedKey0000", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: apiURL,
+	})
+
+	_, err = single.GetRaw(context.Background(), "whoisxmlapi.com")
+	checkErr(t, err, "API failed with status code: 401 (ApiKey authenticate failed)")
+}
+
+// TestShouldRotate tests that only confirmed authentication and credit failures rotate keys.
+func TestShouldRotate(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       bool
+	}{
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"code":401,"messages":"ApiKey authenticate failed"}`,
+			want:       true,
+		},
+		{
+			name:       "restricted",
+			statusCode: http.StatusForbidden,
+			body:       `{"code":403,"messages":"Access restricted. Check credits balance."}`,
+			want:       true,
+		},
+		{
+			name:       "credit code",
+			statusCode: http.StatusOK,
+			body:       `{"code":402,"messages":"Insufficient balance"}`,
+			want:       true,
+		},
+		{
+			name:       "invalid domain echoing credit",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"code":422,"messages":"Invalid domain name: credit-union.com"}`,
+			want:       false,
+		},
+		{
+			name:       "message only",
+			statusCode: http.StatusOK,
+			body:       `{"ErrorMessage":{"errorCode":"DNS_02","msg":"No credits for credit-union.com apikey"}}`,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRotate(tt.statusCode, []byte(tt.body)); got != tt.want {
+				t.Errorf("shouldRotate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// LazyDNSRecords is the alternative to DNSRecords decoding only the common fields of the records eagerly.
+// Typed records are decoded on first access per DNS type, so callers reading All or a single type
+// skip most of the decoding cost of large responses. It is safe for concurrent use after decoding.
+//
+// Get uses it when ClientParams.LazyRecords is set, see DNSLookupResponse.LazyRecords.
+// It can also be used in place of DNSRecords in a custom response struct:
+//
+//	var resp struct {
+//		DNSData struct {
+//			DNSRecords dnslookupapi.LazyDNSRecords `json:"dnsRecords"`
+//		} `json:"DNSData"`
+//	}
+type LazyDNSRecords struct {
+	all   []DNSRecord
+	types map[string]*lazyType
+
+	once    sync.Once
+	records *DNSRecords
+}
+
+// lazyAPIData is the DNSData object of the API response with the records decoded lazily.
+// The DNSRecords field takes precedence over the one of the embedded DNSLookupResponse.
+type lazyAPIData struct {
+	DNSLookupResponse
+
+	DNSRecords *LazyDNSRecords `json:"dnsRecords"`
+}
+
+// parseLazy parses the API response decoding only the common fields of the records.
+// DNSRecords.All of the result is set and the typed records are available through LazyRecords.
+func parseLazy(raw []byte) (*apiResponse, error) {
+	var response struct {
+		DNSData      lazyAPIData  `json:"DNSData"`
+		ErrorMessage ErrorMessage `json:"ErrorMessage"`
+	}
+
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, fmt.Errorf("cannot parse response: %w", err)
+	}
+
+	result := &apiResponse{
+		DNSLookupResponse: response.DNSData.DNSLookupResponse,
+		ErrorMessage:      response.ErrorMessage,
+	}
+
+	if records := response.DNSData.DNSRecords; records != nil {
+		result.DNSRecords.All = records.All()
+		result.LazyRecords = records
+	}
+
+	return result, nil
+}
+
+// ParsedRecords returns the typed records of the response. If the records were decoded lazily,
+// see ClientParams.LazyRecords, they are parsed on the first call and must not be modified.
+func (r *DNSLookupResponse) ParsedRecords() *DNSRecords {
+	if r.LazyRecords != nil {
+		return r.LazyRecords.DNSRecords()
+	}
+
+	return &r.DNSRecords
+}
+
+// lazyType holds the records of a single DNS type decoded on first access.
+type lazyType struct {
+	once    sync.Once
+	indexes []int
+	records []Record
+}
+
+// UnmarshalJSON decodes the JSON array of DNS records, parsing only their common fields.
+func (r *LazyDNSRecords) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	r.all = make([]DNSRecord, 0, len(raw))
+	r.types = make(map[string]*lazyType)
+
+	for i, record := range raw {
+		dnsRecord := DNSRecord{Raw: record}
+
+		if err := json.Unmarshal(record, &dnsRecord.CommonFields); err != nil {
+			dnsRecord.ParseError = newParseError(record, err)
+		} else {
+			common := &dnsRecord.CommonFields
+			common.DNSType = resolveDNSType(common.DNSType, common.Type)
+
+			t, ok := r.types[dnsRecord.CommonFields.DNSType]
+			if !ok {
+				t = &lazyType{}
+				r.types[dnsRecord.CommonFields.DNSType] = t
+			}
+
+			t.indexes = append(t.indexes, i)
+		}
+
+		r.all = append(r.all, dnsRecord)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the records as a JSON array in the original API format.
+func (r *LazyDNSRecords) MarshalJSON() ([]byte, error) {
+	raw := make([]json.RawMessage, 0, len(r.all))
+	for _, record := range r.all {
+		raw = append(raw, record.Raw)
+	}
+
+	return json.Marshal(raw)
+}
+
+// Len returns the number of records.
+func (r *LazyDNSRecords) Len() int {
+	return len(r.all)
+}
+
+// All returns all records with only the common fields parsed. ParseError is set only if
+// the common fields cannot be parsed; errors of typed records are reported by DNSRecords.
+func (r *LazyDNSRecords) All() []DNSRecord {
+	return r.all
+}
+
+// Type returns the successfully parsed typed records of the DNS type, e.g. ARecord values for "A".
+// The records are decoded on the first call for the type.
+func (r *LazyDNSRecords) Type(dnsType string) []Record {
+	t, ok := r.types[dnsType]
+	if !ok {
+		return nil
+	}
+
+	t.once.Do(func() {
+		for _, i := range t.indexes {
+			actual := actualDNSType(dnsType)
+			if actual == nil {
+				return
+			}
+
+			if err := json.Unmarshal(r.all[i].Raw, actual); err != nil {
+				continue
+			}
+
+			actual.(interface{ setDNSType(string) }).setDNSType(dnsType)
+
+			if record, ok := reflect.ValueOf(actual).Elem().Interface().(Record); ok {
+				t.records = append(t.records, record)
+			}
+		}
+	})
+
+	return t.records
+}
+
+// DNSRecords returns the fully parsed records. They are parsed on the first call.
+// The result is shared and must not be modified.
+func (r *LazyDNSRecords) DNSRecords() *DNSRecords {
+	r.once.Do(func() {
+		r.records = &DNSRecords{}
+
+		for _, record := range r.all {
+			r.records.All = append(r.records.All, r.records.parseRecord(record.Raw))
+		}
+	})
+
+	return r.records
+}
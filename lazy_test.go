@@ -0,0 +1,183 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+const lazyTestRecords = `[
+	{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"},
+	{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"target":"mx.example.com.","priority":10},
+	{"type":1,"dnsType":"A","name":"example.com.","ttl":"bad","address":"192.0.2.2"},
+	{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.3"}
+]`
+
+// TestLazyDNSRecords tests lazy decoding of typed records.
+func TestLazyDNSRecords(t *testing.T) {
+	var lazy LazyDNSRecords
+	if err := json.Unmarshal([]byte(lazyTestRecords), &lazy); err != nil {
+		t.Fatal(err)
+	}
+
+	if lazy.Len() != 4 {
+		t.Fatalf("Len() = %d", lazy.Len())
+	}
+
+	if all := lazy.All(); all[1].CommonFields.DNSType != "MX" || all[2].ParseError == nil {
+		t.Errorf("All() = %+v", all)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			records := lazy.Type("A")
+			if len(records) != 2 || records[1].(ARecord).Address != "192.0.2.3" {
+				t.Errorf("Type(A) = %+v", records)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if records := lazy.Type("TXT"); records != nil {
+		t.Errorf("Type(TXT) = %+v", records)
+	}
+
+	records := lazy.DNSRecords()
+	if len(records.A) != 2 || len(records.MX) != 1 || len(records.ParseErrors()) != 1 {
+		t.Errorf("DNSRecords() = %+v", records)
+	}
+
+	b, err := json.Marshal(&lazy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var eager DNSRecords
+	if err = json.Unmarshal(b, &eager); err != nil || len(eager.All) != 4 {
+		t.Errorf("round trip = %+v, %v", eager.All, err)
+	}
+}
+
+// TestGetLazyRecords tests that Get decodes the typed records lazily if LazyRecords is set.
+func TestGetLazyRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsTypes":"_all","dnsRecords":` +
+			lazyTestRecords + `}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_testKey", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		LazyRecords:      true,
+	})
+
+	resp, _, err := client.Get(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if resp.DomainName != "example.com" || resp.DNSTypes != "_all" {
+		t.Errorf("Get() = %+v", resp)
+	}
+
+	if len(resp.DNSRecords.All) != 4 || resp.DNSRecords.A != nil || resp.DNSRecords.MX != nil {
+		t.Errorf("DNSRecords = %+v", resp.DNSRecords)
+	}
+
+	if resp.LazyRecords == nil {
+		t.Fatal("LazyRecords = nil")
+	}
+
+	if mx := resp.LazyRecords.Type("MX"); len(mx) != 1 || mx[0].(MXRecord).Target != "mx.example.com." {
+		t.Errorf("LazyRecords.Type(MX) = %+v", mx)
+	}
+}
+
+// TestGetLazyRecordsTyped tests that the typed getters, the helpers and strict parsing see the typed records
+// when LazyRecords is set.
+func TestGetLazyRecordsTyped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsTypes":"_all","dnsRecords":` +
+			lazyTestRecords + `}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_testKey", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		LazyRecords:      true,
+	})
+
+	a, _, err := client.GetA(context.Background(), "example.com")
+	if err != nil || len(a) != 2 {
+		t.Errorf("GetA() = %+v, %v, want 2 records", a, err)
+	}
+
+	mx, _, err := client.GetMX(context.Background(), "example.com")
+	if err != nil || len(mx) != 1 {
+		t.Errorf("GetMX() = %+v, %v, want 1 record", mx, err)
+	}
+
+	resp, _, err := client.Get(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if records := resp.ParsedRecords(); len(records.A) != 2 || len(records.MX) != 1 {
+		t.Errorf("ParsedRecords() = %+v", records)
+	}
+
+	if summary := resp.Summary(); summary.Counts["MX"] != 1 {
+		t.Errorf("Summary() = %+v", summary)
+	}
+
+	strict := NewClient("at_testKey", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		LazyRecords:      true,
+		StrictParsing:    true,
+	})
+
+	var parseErrs ParseErrors
+	if _, _, err = strict.Get(context.Background(), "example.com"); !errors.As(err, &parseErrs) || len(parseErrs) != 1 {
+		t.Errorf("strict Get() error = %v, want ParseErrors", err)
+	}
+}
+
+// BenchmarkLazyDNSRecords compares reading a single type from lazy and eager records.
+func BenchmarkLazyDNSRecords(b *testing.B) {
+	data := []byte(lazyTestRecords)
+
+	b.Run("eager", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var records DNSRecords
+			_ = json.Unmarshal(data, &records)
+			_ = records.MX
+		}
+	})
+
+	b.Run("lazy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var records LazyDNSRecords
+			_ = json.Unmarshal(data, &records)
+			_ = records.Type("MX")
+		}
+	})
+}
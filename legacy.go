@@ -0,0 +1,264 @@
+package dnslookupapi
+
+// KXRecord is the key exchanger record (RFC 2230).
+type KXRecord struct {
+	commonFields
+
+	// Preference is the preference of the exchanger, lower values are preferred.
+	Preference int `json:"preference"`
+
+	// Exchanger is the domain name of the key exchanger.
+	Exchanger string `json:"exchanger"`
+}
+
+// PXRecord is the X.400 mail mapping record (RFC 2163).
+type PXRecord struct {
+	commonFields
+
+	// Preference is the preference of the mapping, lower values are preferred.
+	Preference int `json:"preference"`
+
+	// Map822 is the RFC 822 part of the mapping.
+	Map822 string `json:"map822"`
+
+	// MapX400 is the X.400 part of the mapping.
+	MapX400 string `json:"mapX400"`
+}
+
+// GPOSRecord is the geographical position record (RFC 1712).
+type GPOSRecord struct {
+	commonFields
+
+	// Longitude is the longitude in degrees.
+	Longitude string `json:"longitude"`
+
+	// Latitude is the latitude in degrees.
+	Latitude string `json:"latitude"`
+
+	// Altitude is the altitude in meters.
+	Altitude string `json:"altitude"`
+}
+
+// APLRecord is the address prefix list record (RFC 3123).
+type APLRecord struct {
+	commonFields
+
+	// Prefixes are the address prefixes, e.g. "1:192.168.32.0/21" or "!1:192.168.38.0/28".
+	Prefixes []string `json:"prefixes"`
+}
+
+// AFSDBRecord is the AFS database location record (RFC 1183).
+type AFSDBRecord struct {
+	commonFields
+
+	// Subtype is the type of the server, 1 for AFS volume location and 2 for DCE authenticated name server.
+	Subtype int `json:"subtype"`
+
+	// Hostname is the domain name of the server.
+	Hostname string `json:"hostname"`
+}
+
+// ISDNRecord is the ISDN address record (RFC 1183).
+type ISDNRecord struct {
+	commonFields
+
+	// Address is the ISDN number.
+	Address string `json:"address"`
+
+	// SubAddress is the optional subaddress.
+	SubAddress string `json:"subAddress"`
+}
+
+// RTRecord is the route through record (RFC 1183).
+type RTRecord struct {
+	commonFields
+
+	// Preference is the preference of the route, lower values are preferred.
+	Preference int `json:"preference"`
+
+	// IntermediateHost is the domain name of the host acting as a forwarder.
+	IntermediateHost string `json:"intermediateHost"`
+}
+
+// X25Record is the X.25 address record (RFC 1183).
+type X25Record struct {
+	commonFields
+
+	// PSDNAddress is the X.121 PSDN address.
+	PSDNAddress string `json:"psdnAddress"`
+}
+
+// WKSRecord is the well known services record (RFC 1035).
+type WKSRecord struct {
+	commonFields
+
+	// Address is the IPv4 address of the host.
+	Address string `json:"address"`
+
+	// Protocol is the IP protocol number, e.g. 6 for TCP.
+	Protocol int `json:"protocol"`
+
+	// Bitmap is the bit map of the services, one bit per port.
+	Bitmap []string `json:"bitmap"`
+}
+
+// LegacyRecords holds the records of the legacy and rarely used DNS types.
+type LegacyRecords struct {
+	// KX is a slice of the parsed KX records.
+	KX []KXRecord
+
+	// PX is a slice of the parsed PX records.
+	PX []PXRecord
+
+	// GPOS is a slice of the parsed GPOS records.
+	GPOS []GPOSRecord
+
+	// APL is a slice of the parsed APL records.
+	APL []APLRecord
+
+	// AFSDB is a slice of the parsed AFSDB records.
+	AFSDB []AFSDBRecord
+
+	// ISDN is a slice of the parsed ISDN records.
+	ISDN []ISDNRecord
+
+	// RT is a slice of the parsed RT records.
+	RT []RTRecord
+
+	// X25 is a slice of the parsed X25 records.
+	X25 []X25Record
+
+	// WKS is a slice of the parsed WKS records.
+	WKS []WKSRecord
+}
+
+var _ = []Record{
+	KXRecord{}, PXRecord{}, GPOSRecord{}, APLRecord{}, AFSDBRecord{}, ISDNRecord{}, RTRecord{}, X25Record{}, WKSRecord{},
+}
+
+// ParseLegacy returns the DecodeHook parsing the records of the legacy DNS types into DNSRecords.Legacy.
+// Without it such records are kept in DNSRecords.Unknown with ErrUnsupportedDNSType, like other unsupported types.
+func ParseLegacy() DecodeHook {
+	return func(resp *DNSLookupResponse) error {
+		if resp.DNSRecords.Legacy != nil {
+			return nil
+		}
+
+		parsed := &DNSRecords{Legacy: &LegacyRecords{}}
+		for _, record := range resp.DNSRecords.All {
+			if len(record.Raw) == 0 {
+				parsed.All = append(parsed.All, record)
+				continue
+			}
+
+			parsed.All = append(parsed.All, parsed.parseRecord(record.Raw))
+		}
+
+		resp.DNSRecords = *parsed
+
+		return nil
+	}
+}
+
+// derive returns empty DNSRecords parsing the legacy DNS types if r does.
+func (r *DNSRecords) derive() *DNSRecords {
+	if r != nil && r.Legacy != nil {
+		return &DNSRecords{Legacy: &LegacyRecords{}}
+	}
+
+	return &DNSRecords{}
+}
+
+// legacyDNSType returns the record of the legacy DNS type to unmarshal into, nil if the type is not legacy.
+func legacyDNSType(dnsType string) interface{} {
+	switch dnsType {
+	case "KX":
+		return &KXRecord{}
+	case "PX":
+		return &PXRecord{}
+	case "GPOS":
+		return &GPOSRecord{}
+	case "APL":
+		return &APLRecord{}
+	case "AFSDB":
+		return &AFSDBRecord{}
+	case "ISDN":
+		return &ISDNRecord{}
+	case "RT":
+		return &RTRecord{}
+	case "X25":
+		return &X25Record{}
+	case "WKS":
+		return &WKSRecord{}
+	}
+
+	return nil
+}
+
+// add appends the parsed record of the legacy DNS type.
+func (r *LegacyRecords) add(dnsType string, actual interface{}) {
+	switch dnsType {
+	case "KX":
+		r.KX = append(r.KX, *actual.(*KXRecord))
+	case "PX":
+		r.PX = append(r.PX, *actual.(*PXRecord))
+	case "GPOS":
+		r.GPOS = append(r.GPOS, *actual.(*GPOSRecord))
+	case "APL":
+		r.APL = append(r.APL, *actual.(*APLRecord))
+	case "AFSDB":
+		r.AFSDB = append(r.AFSDB, *actual.(*AFSDBRecord))
+	case "ISDN":
+		r.ISDN = append(r.ISDN, *actual.(*ISDNRecord))
+	case "RT":
+		r.RT = append(r.RT, *actual.(*RTRecord))
+	case "X25":
+		r.X25 = append(r.X25, *actual.(*X25Record))
+	case "WKS":
+		r.WKS = append(r.WKS, *actual.(*WKSRecord))
+	}
+}
+
+// typedRecord returns the i-th parsed record of the legacy DNS type, nil if there is no such record.
+func (r *LegacyRecords) typedRecord(dnsType string, i int) interface{} {
+	switch dnsType {
+	case "KX":
+		if i < len(r.KX) {
+			return r.KX[i]
+		}
+	case "PX":
+		if i < len(r.PX) {
+			return r.PX[i]
+		}
+	case "GPOS":
+		if i < len(r.GPOS) {
+			return r.GPOS[i]
+		}
+	case "APL":
+		if i < len(r.APL) {
+			return r.APL[i]
+		}
+	case "AFSDB":
+		if i < len(r.AFSDB) {
+			return r.AFSDB[i]
+		}
+	case "ISDN":
+		if i < len(r.ISDN) {
+			return r.ISDN[i]
+		}
+	case "RT":
+		if i < len(r.RT) {
+			return r.RT[i]
+		}
+	case "X25":
+		if i < len(r.X25) {
+			return r.X25[i]
+		}
+	case "WKS":
+		if i < len(r.WKS) {
+			return r.WKS[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestParseLegacy tests that the legacy records are parsed only with the ParseLegacy hook.
+func TestParseLegacy(t *testing.T) {
+	const records = `[
+{"type":36,"dnsType":"KX","name":"example.com.","ttl":300,"preference":10,"exchanger":"kx.example.com."},
+{"type":42,"dnsType":"APL","name":"example.com.","ttl":300,"prefixes":["1:192.168.32.0/21","!1:192.168.38.0/28"]},
+{"type":18,"dnsType":"AFSDB","name":"example.com.","ttl":300,"subtype":1,"hostname":"afs.example.com."},
+{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"}
+]`
+
+	resp := &DNSLookupResponse{}
+	if err := json.Unmarshal([]byte(records), &resp.DNSRecords); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.DNSRecords.Unknown) != 3 || resp.DNSRecords.Legacy != nil ||
+		!errors.Is(resp.DNSRecords.All[0].ParseError, ErrUnsupportedDNSType) {
+		t.Fatalf("records without ParseLegacy = %+v", resp.DNSRecords)
+	}
+
+	if err := ParseLegacy()(resp); err != nil {
+		t.Fatal(err)
+	}
+
+	r := resp.DNSRecords
+	if errs := r.ParseErrors(); len(errs) != 0 || len(r.Unknown) != 0 || len(r.A) != 1 || len(r.All) != 4 {
+		t.Fatalf("ParseErrors() = %v, records = %+v", errs, r)
+	}
+
+	legacy := r.Legacy
+	if len(legacy.KX) != 1 || legacy.KX[0].Exchanger != "kx.example.com." || len(legacy.APL[0].Prefixes) != 2 ||
+		legacy.AFSDB[0].Hostname != "afs.example.com." || legacy.AFSDB[0].GetTypeCode() != 18 {
+		t.Errorf("Legacy = %+v", legacy)
+	}
+
+	if filtered := r.FilterByType("KX"); filtered.Legacy == nil || len(filtered.Legacy.KX) != 1 {
+		t.Errorf("FilterByType() = %+v", filtered)
+	}
+
+	if records := r.Records(); len(records) != 4 || records[0].(KXRecord).Exchanger != "kx.example.com." ||
+		records[2].(AFSDBRecord).Hostname != "afs.example.com." {
+		t.Errorf("Records() = %+v", records)
+	}
+}
@@ -0,0 +1,155 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// TooLargeKind is the kind of the limit exceeded by the response.
+type TooLargeKind string
+
+const (
+	// TooLargeBytes means that the response body exceeds ClientParams.MaxResponseBytes.
+	TooLargeBytes TooLargeKind = "bytes"
+
+	// TooLargeRecords means that the response has more records than ClientParams.MaxRecords.
+	TooLargeRecords TooLargeKind = "records"
+)
+
+// TooLargeError is returned by Get when the response exceeds ClientParams.MaxResponseBytes
+// or ClientParams.MaxRecords. It matches ErrResponseTooLarge with errors.Is.
+type TooLargeError struct {
+	// Kind is the kind of the exceeded limit
+	Kind TooLargeKind
+
+	// Limit is the exceeded limit
+	Limit int64
+
+	// Partial is the response decoded up to the limit if ClientParams.KeepPartialResponse is set, otherwise nil
+	// It holds the first Limit records or the records received completely within Limit bytes
+	Partial *DNSLookupResponse
+}
+
+// Error returns error message as a string.
+func (e *TooLargeError) Error() string {
+	return ErrResponseTooLarge.Error() + ": more than " + strconv.FormatInt(e.Limit, 10) + " " + string(e.Kind)
+}
+
+// Is reports whether the target is ErrResponseTooLarge.
+func (e *TooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// errRecordLimit stops decoding when the record limit is exceeded.
+var errRecordLimit = errors.New("record limit exceeded")
+
+// decodePartial decodes the response body up to max records; non-positive max means no limit.
+// The body may be truncated, the records decoded before the error are returned.
+// If keep is not set, the records are only counted. It reports whether the limit is exceeded.
+func decodePartial(body []byte, max int, keep bool) (resp *DNSLookupResponse, exceeded bool, err error) {
+	resp = &DNSLookupResponse{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	count := 0
+
+	err = decodeObject(decoder, func(key string) error {
+		if key != "DNSData" {
+			return skipValue(decoder)
+		}
+
+		return decodeObject(decoder, func(key string) error {
+			switch key {
+			case "domainName":
+				return decoder.Decode(&resp.DomainName)
+			case "types":
+				return decoder.Decode(&resp.Types)
+			case "dnsTypes":
+				return decoder.Decode(&resp.DNSTypes)
+			case "audit":
+				return decoder.Decode(&resp.Audit)
+			case "dnsRecords":
+				return decodeRawRecords(decoder, func(raw json.RawMessage) error {
+					if count++; max > 0 && count > max {
+						return errRecordLimit
+					}
+
+					if keep {
+						records := &resp.DNSRecords
+						records.All = append(records.All, records.parseRecord(raw))
+					}
+
+					return nil
+				})
+			}
+
+			return skipValue(decoder)
+		})
+	})
+
+	if errors.Is(err, errRecordLimit) {
+		return resp, true, nil
+	}
+
+	return resp, false, err
+}
+
+// decodeRawRecords reads the JSON array of DNS records calling fn for every raw record.
+func decodeRawRecords(decoder *json.Decoder, fn func(json.RawMessage) error) error {
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+
+		if err := fn(raw); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// checkRecordCount returns TooLargeError if the response has more records than the client allows.
+func (c *Client) checkRecordCount(body []byte) error {
+	if c.maxRecords <= 0 {
+		return nil
+	}
+
+	partial, exceeded, err := decodePartial(body, c.maxRecords, c.keepPartial)
+	if err != nil || !exceeded {
+		// malformed bodies are reported by the parser
+		return nil
+	}
+
+	return c.tooLarge(TooLargeRecords, int64(c.maxRecords), partial)
+}
+
+// bodyTooLarge returns TooLargeError with the records received completely within the truncated body.
+func (c *Client) bodyTooLarge(body []byte) error {
+	var partial *DNSLookupResponse
+	if c.keepPartial {
+		limit := int64(len(body))
+		if c.maxResponseBytes > 0 && limit > c.maxResponseBytes {
+			limit = c.maxResponseBytes
+		}
+
+		partial, _, _ = decodePartial(body[:limit], c.maxRecords, true)
+	}
+
+	return c.tooLarge(TooLargeBytes, c.maxResponseBytes, partial)
+}
+
+// tooLarge returns TooLargeError with the partial response attached if it's enabled.
+func (c *Client) tooLarge(kind TooLargeKind, limit int64, partial *DNSLookupResponse) error {
+	err := &TooLargeError{Kind: kind, Limit: limit}
+	if c.keepPartial {
+		err.Partial = partial
+	}
+
+	return err
+}
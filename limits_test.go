@@ -0,0 +1,102 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// manyRecordsResponse returns the response with n A records.
+func manyRecordsResponse(n int) string {
+	records := make([]string, n)
+	for i := range records {
+		address := "192.0.2." + strconv.Itoa(i%250+1)
+		records[i] = `{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"rawText":"example.com. 300 IN A ` +
+			address + `","address":"` + address + `"}`
+	}
+
+	return `{"DNSData":{"domainName":"example.com","types":[1],"dnsTypes":"A",` +
+		`"audit":{"createdDate":"2022-07-12 11:46:25 UTC","updatedDate":"2022-07-12 11:46:25 UTC"},` +
+		`"dnsRecords":[` + strings.Join(records, ",") + `]}}`
+}
+
+// TestResponseLimits tests that Get returns TooLargeError with the partial response attached.
+func TestResponseLimits(t *testing.T) {
+	body := manyRecordsResponse(100)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	tests := []struct {
+		name        string
+		params      ClientParams
+		wantKind    TooLargeKind
+		wantPartial int
+	}{
+		{
+			name:     "records",
+			params:   ClientParams{MaxRecords: 10},
+			wantKind: TooLargeRecords,
+		},
+		{
+			name:        "records with partial response",
+			params:      ClientParams{MaxRecords: 10, KeepPartialResponse: true},
+			wantKind:    TooLargeRecords,
+			wantPartial: 10,
+		},
+		{
+			name:        "bytes with partial response",
+			params:      ClientParams{MaxResponseBytes: int64(len(body) / 2), KeepPartialResponse: true},
+			wantKind:    TooLargeBytes,
+			wantPartial: -1,
+		},
+		{
+			name:   "within limits",
+			params: ClientParams{MaxRecords: 100, MaxResponseBytes: int64(len(body))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.HTTPClient, tt.params.DNSLookupBaseURL = server.Client(), baseURL
+			client := NewClient(apiKey, tt.params)
+
+			resp, _, err := client.Get(context.Background(), "example.com")
+			if tt.wantKind == "" {
+				if err != nil || len(resp.DNSRecords.A) != 100 {
+					t.Errorf("Get() error = %v", err)
+				}
+
+				return
+			}
+
+			var tooLarge *TooLargeError
+			if !errors.As(err, &tooLarge) || !errors.Is(err, ErrResponseTooLarge) || tooLarge.Kind != tt.wantKind {
+				t.Fatalf("Get() error = %v, want %v TooLargeError", err, tt.wantKind)
+			}
+
+			switch {
+			case tt.wantPartial == 0:
+				if tooLarge.Partial != nil {
+					t.Errorf("Get() partial response = %+v, want nil", tooLarge.Partial)
+				}
+			case tooLarge.Partial == nil:
+				t.Errorf("Get() partial response = nil")
+			case tt.wantPartial > 0 && len(tooLarge.Partial.DNSRecords.A) != tt.wantPartial,
+				tt.wantPartial < 0 && (len(tooLarge.Partial.DNSRecords.A) == 0 || len(tooLarge.Partial.DNSRecords.A) >= 100):
+				t.Errorf("Get() partial response has %d records", len(tooLarge.Partial.DNSRecords.A))
+			case tooLarge.Partial.DomainName != "example.com" || tooLarge.Partial.RequestedDomainName != "example.com":
+				t.Errorf("Get() partial response = %+v", tooLarge.Partial)
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+// Package lint checks the quality of the DNS records returned by DNS Lookup API with pluggable rules,
+// e.g. low TTLs, CNAME records at the zone apex or SPF policies exceeding the DNS lookup limit.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/report"
+	"github.com/whois-api-llc/dns-lookup-go/spf"
+)
+
+// Severity is the severity of a finding, shared with the report package.
+type Severity = report.Severity
+
+// Severities of the findings.
+const (
+	SeverityInfo   = report.SeverityInfo
+	SeverityLow    = report.SeverityLow
+	SeverityMedium = report.SeverityMedium
+	SeverityHigh   = report.SeverityHigh
+)
+
+// Default TTL bounds of the TTL rule, in seconds.
+const (
+	DefaultMinTTL = 60
+	DefaultMaxTTL = 7 * 24 * 60 * 60
+)
+
+// Finding is a single result of a rule, shared with the report package.
+// ID is the stable identifier of the finding, e.g. "ttl-too-low".
+type Finding = report.Finding
+
+// Rule is a named check of the response.
+type Rule struct {
+	// Name identifies the rule, e.g. to replace it with Linter.Register.
+	Name string
+
+	// Check returns the findings for the response.
+	Check func(ctx context.Context, response *dnslookupapi.DNSLookupResponse) []Finding
+}
+
+// Linter evaluates the rules against the responses.
+type Linter struct {
+	rules []Rule
+}
+
+// New creates Linter with the rules. If no rules are given, DefaultRules without the service are used.
+func New(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules(nil)
+	}
+
+	l := &Linter{}
+	for _, rule := range rules {
+		l.Register(rule)
+	}
+
+	return l
+}
+
+// Register adds the custom rule. A rule with the same name is replaced.
+func (l *Linter) Register(rule Rule) {
+	for i := range l.rules {
+		if l.rules[i].Name == rule.Name {
+			l.rules[i] = rule
+			return
+		}
+	}
+
+	l.rules = append(l.rules, rule)
+}
+
+// Unregister removes the rule by name.
+func (l *Linter) Unregister(name string) {
+	for i := range l.rules {
+		if l.rules[i].Name == name {
+			l.rules = append(l.rules[:i], l.rules[i+1:]...)
+			return
+		}
+	}
+}
+
+// Rules returns the names of the registered rules in the order of evaluation.
+func (l *Linter) Rules() []string {
+	names := make([]string, 0, len(l.rules))
+	for _, rule := range l.rules {
+		names = append(names, rule.Name)
+	}
+
+	return names
+}
+
+// Lint evaluates the rules against the response and returns the findings sorted by severity,
+// the most severe first. Findings of the same severity are kept in the order of the rules.
+func (l *Linter) Lint(ctx context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+	var findings []Finding
+
+	for _, rule := range l.rules {
+		findings = append(findings, rule.Check(ctx, response)...)
+	}
+
+	report.SortFindings(findings)
+
+	return findings
+}
+
+// DefaultRules returns the built-in rules with the default settings. The service is used by DanglingTargets
+// to resolve the targets outside the response; if it's nil, only the targets equal to the domain are checked.
+func DefaultRules(service dnslookupapi.DNSLookupService) []Rule {
+	return []Rule{
+		MissingAAAA(),
+		TTLRange(DefaultMinTTL, DefaultMaxTTL),
+		CNAMEAtApex(),
+		SPFLookupLimit(),
+		DanglingTargets(service),
+		DuplicateRecords(),
+	}
+}
+
+// MissingAAAA reports the domain having A records without AAAA records, i.e. unreachable over IPv6.
+// It's skipped if the AAAA records were not requested.
+func MissingAAAA() Rule {
+	return Rule{
+		Name: "missing-aaaa",
+		Check: func(_ context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			records := response.ParsedRecords()
+			if len(records.A) == 0 || len(records.AAAA) != 0 || !requested(response, "AAAA") {
+				return nil
+			}
+
+			return []Finding{{
+				ID:       "missing-aaaa",
+				Severity: SeverityLow,
+				Message:  "the domain has A records but no AAAA records, it is not reachable over IPv6",
+				Name:     response.DomainName,
+			}}
+		},
+	}
+}
+
+// TTLRange reports the records with TTL below min or above max seconds.
+// Low TTLs increase the resolver load, high TTLs delay the propagation of changes.
+func TTLRange(min, max int) Rule {
+	return Rule{
+		Name: "ttl-range",
+		Check: func(_ context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			var findings []Finding
+
+			for _, record := range response.ParsedRecords().All {
+				common := record.CommonFields
+
+				switch {
+				case common.TTL < min:
+					findings = append(findings, Finding{
+						ID:       "ttl-too-low",
+						Severity: SeverityLow,
+						Message:  fmt.Sprintf("%s record TTL %d is below %d seconds", common.DNSType, common.TTL, min),
+						Name:     common.Name,
+					})
+				case max > 0 && common.TTL > max:
+					findings = append(findings, Finding{
+						ID:       "ttl-too-high",
+						Severity: SeverityInfo,
+						Message:  fmt.Sprintf("%s record TTL %d is above %d seconds", common.DNSType, common.TTL, max),
+						Name:     common.Name,
+					})
+				}
+			}
+
+			return findings
+		},
+	}
+}
+
+// CNAMEAtApex reports CNAME records at the zone apex, which conflict with the SOA and NS records
+// required there (RFC 1034 section 3.6.2). The owners of the SOA and NS records of the response are the apexes;
+// if there are none, the registrable domain is assumed to be the apex.
+func CNAMEAtApex() Rule {
+	return Rule{
+		Name: "cname-at-apex",
+		Check: func(_ context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			var findings []Finding
+
+			records := response.ParsedRecords()
+			apexes := zoneApexes(records)
+
+			for _, record := range records.CNAME {
+				if !isApex(apexes, record.Name) {
+					continue
+				}
+
+				findings = append(findings, Finding{
+					ID:       "cname-at-apex",
+					Severity: SeverityHigh,
+					Message:  "CNAME record at the zone apex to " + record.Target + " conflicts with the SOA and NS records",
+					Name:     record.Name,
+				})
+			}
+
+			return findings
+		},
+	}
+}
+
+// zoneApexes returns the normalized owner names of the SOA and NS records.
+func zoneApexes(records *dnslookupapi.DNSRecords) map[string]bool {
+	apexes := make(map[string]bool)
+
+	for _, record := range records.SOA {
+		apexes[dnslookupapi.NormalizeName(record.Name)] = true
+	}
+
+	for _, record := range records.NS {
+		apexes[dnslookupapi.NormalizeName(record.Name)] = true
+	}
+
+	return apexes
+}
+
+// isApex reports whether the name is one of the apexes or, if there are none, the registrable domain.
+func isApex(apexes map[string]bool, name string) bool {
+	if len(apexes) != 0 {
+		return apexes[dnslookupapi.NormalizeName(name)]
+	}
+
+	apex, err := dnslookupapi.RegistrableDomain(name)
+
+	return err == nil && dnslookupapi.EqualNames(apex, name)
+}
+
+// SPFLookupLimit reports the SPF policy exceeding the limit of 10 DNS lookups, which makes SPF evaluation fail.
+func SPFLookupLimit() Rule {
+	return Rule{
+		Name: "spf-lookup-limit",
+		Check: func(_ context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			record, err := spf.FromTXT(response.ParsedRecords().TXT)
+			if err != nil || !record.ExceedsLookupLimit() {
+				return nil
+			}
+
+			return []Finding{{
+				ID:       "spf-lookup-limit",
+				Severity: SeverityMedium,
+				Message: fmt.Sprintf("SPF record has %d DNS lookups, more than the limit of %d",
+					record.DNSLookups(), spf.MaxDNSLookups),
+				Name: response.DomainName,
+			}}
+		},
+	}
+}
+
+// DanglingTargets reports the MX and NS targets which are IP addresses or have no A and AAAA records.
+// Targets equal to the domain are checked against the response, other targets are looked up with the service
+// if it's not nil. Targets which failed to be looked up are not reported.
+func DanglingTargets(service dnslookupapi.DNSLookupService) Rule {
+	return Rule{
+		Name: "dangling-targets",
+		Check: func(ctx context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			var targets []target
+
+			records := response.ParsedRecords()
+
+			for _, record := range records.MX {
+				// the null MX (RFC 7505) has no target
+				if record.Target != "." && record.Target != "" {
+					targets = append(targets, target{dnsType: "MX", name: record.Target, owner: record.Name})
+				}
+			}
+
+			for _, record := range records.NS {
+				targets = append(targets, target{dnsType: "NS", name: record.Target, owner: record.Name})
+			}
+
+			var findings []Finding
+
+			resolved := make(map[string]bool)
+
+			for _, t := range targets {
+				ok, known := resolved[dnslookupapi.NormalizeName(t.name)]
+				if !known {
+					ok, known = resolves(ctx, service, response, t.name)
+					if !known {
+						continue
+					}
+
+					resolved[dnslookupapi.NormalizeName(t.name)] = ok
+				}
+
+				if !ok {
+					findings = append(findings, Finding{
+						ID:       "dangling-" + strings.ToLower(t.dnsType),
+						Severity: SeverityHigh,
+						Message:  t.dnsType + " target " + t.name + " does not resolve to an address",
+						Name:     t.owner,
+					})
+				}
+			}
+
+			return findings
+		},
+	}
+}
+
+// target is the MX or NS target.
+type target struct {
+	dnsType string
+	name    string
+	owner   string
+}
+
+// resolves reports whether the target has address records. It returns false as the second value
+// if it cannot be determined.
+func resolves(
+	ctx context.Context,
+	service dnslookupapi.DNSLookupService,
+	response *dnslookupapi.DNSLookupResponse,
+	name string,
+) (ok, known bool) {
+	if net.ParseIP(strings.TrimSuffix(name, ".")) != nil {
+		return false, true
+	}
+
+	records := response.ParsedRecords()
+
+	if !dnslookupapi.EqualNames(name, response.DomainName) {
+		if service == nil {
+			return false, false
+		}
+
+		resp, _, err := service.Get(ctx, name, dnslookupapi.OptionType("A,AAAA,CNAME"))
+		if err != nil {
+			return false, false
+		}
+
+		records = resp.ParsedRecords()
+	} else if !requested(response, "A") || !requested(response, "AAAA") {
+		return false, false
+	}
+
+	return len(records.A) != 0 || len(records.AAAA) != 0 || len(records.CNAME) != 0, true
+}
+
+// DuplicateRecords reports the records equal to a previous record of the response ignoring TTL.
+func DuplicateRecords() Rule {
+	return Rule{
+		Name: "duplicate-records",
+		Check: func(_ context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			var findings []Finding
+
+			records := response.ParsedRecords()
+			seen := make(map[string]bool, len(records.All))
+
+			for i := range records.All {
+				record := &records.All[i]
+
+				key := record.Key()
+				if !seen[key] {
+					seen[key] = true
+					continue
+				}
+
+				findings = append(findings, Finding{
+					ID:       "duplicate-record",
+					Severity: SeverityLow,
+					Message:  "duplicate " + record.CommonFields.DNSType + " record",
+					Name:     record.CommonFields.Name,
+				})
+			}
+
+			return findings
+		},
+	}
+}
+
+// requested reports whether the records of the type were requested. All types are requested
+// if the response doesn't report the requested types.
+func requested(response *dnslookupapi.DNSLookupResponse, dnsType string) bool {
+	if response.DNSTypes == "" {
+		return true
+	}
+
+	for _, t := range strings.Split(response.DNSTypes, ",") {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == dnsType || t == "_ALL" {
+			return true
+		}
+	}
+
+	return false
+}
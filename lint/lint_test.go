@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// fakeService returns prepared DNS records per domain name.
+type fakeService map[string]string
+
+// Get returns the prepared records of the domain name.
+func (f fakeService) Get(_ context.Context, domainName string, _ ...dnslookupapi.Option) (
+	*dnslookupapi.DNSLookupResponse, *dnslookupapi.Response, error) {
+	records, ok := f[domainName]
+	if !ok {
+		records = "[]"
+	}
+
+	return parse(records), nil, nil
+}
+
+// GetRaw is not used.
+func (f fakeService) GetRaw(context.Context, string, ...dnslookupapi.Option) (*dnslookupapi.Response, error) {
+	panic("not implemented")
+}
+
+// parse returns the response for example.com with the records.
+func parse(records string) *dnslookupapi.DNSLookupResponse {
+	var resp dnslookupapi.DNSLookupResponse
+	if err := json.Unmarshal([]byte(`{"domainName":"example.com","dnsRecords":`+records+`}`), &resp); err != nil {
+		panic(err)
+	}
+
+	return &resp
+}
+
+// ids returns the IDs of the findings.
+func ids(findings []Finding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, f.ID)
+	}
+
+	return result
+}
+
+// TestLint tests the default rules.
+func TestLint(t *testing.T) {
+	service := fakeService{
+		"mx1.example.net.": `[{"type":1,"dnsType":"A","name":"mx1.example.net.","ttl":300,"address":"192.0.2.10"}]`,
+	}
+
+	tests := []struct {
+		name    string
+		records string
+		want    []string
+	}{
+		{
+			name: "clean",
+			records: `[{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"},
+				{"type":28,"dnsType":"AAAA","name":"example.com.","ttl":300,"address":"2001:db8::1"},
+				{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"target":"mx1.example.net.","priority":10},
+				{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"target":"example.com.","priority":20},
+				{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"target":"mx1.EXAMPLE.net","priority":30}]`,
+		},
+		{
+			name: "findings",
+			records: `[{"type":1,"dnsType":"A","name":"example.com.","ttl":30,"address":"192.0.2.1"},
+				{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"},
+				{"type":5,"dnsType":"CNAME","name":"example.com.","ttl":999999,"target":"example.net."},
+				{"type":2,"dnsType":"NS","name":"example.com.","ttl":300,"target":"ns.example.org."},
+				{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"target":"192.0.2.25","priority":10},
+				{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"strings":["v=spf1 include:a include:b ",
+					"include:c include:d include:e include:f include:g include:h include:i include:j a -all"]}]`,
+			want: []string{"cname-at-apex", "dangling-mx", "dangling-ns", "spf-lookup-limit", "missing-aaaa",
+				"ttl-too-low", "duplicate-record", "ttl-too-high"},
+		},
+	}
+
+	linter := New(DefaultRules(service)...)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := linter.Lint(context.Background(), parse(tt.records))
+			if got := ids(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCNAMEAtApex tests detecting the zone apex from the SOA and NS records.
+func TestCNAMEAtApex(t *testing.T) {
+	tests := []struct {
+		name    string
+		records string
+		want    []string
+	}{
+		{
+			name:    "registrable domain",
+			records: `[{"type":5,"dnsType":"CNAME","name":"example.com.","ttl":300,"target":"example.net."}]`,
+			want:    []string{"cname-at-apex"},
+		},
+		{
+			name:    "subdomain",
+			records: `[{"type":5,"dnsType":"CNAME","name":"www.example.com.","ttl":300,"target":"example.net."}]`,
+		},
+		{
+			name: "delegated subdomain",
+			records: `[{"type":5,"dnsType":"CNAME","name":"shop.example.com.","ttl":300,"target":"example.net."},
+				{"type":2,"dnsType":"NS","name":"Shop.Example.com","ttl":300,"target":"ns.example.org."}]`,
+			want: []string{"cname-at-apex"},
+		},
+		{
+			name: "soa of the parent zone",
+			records: `[{"type":5,"dnsType":"CNAME","name":"example.com.","ttl":300,"target":"example.net."},
+				{"type":6,"dnsType":"SOA","name":"com.","ttl":300,"admin":"hostmaster.com.","host":"a.gtld-servers.net."}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := New(CNAMEAtApex()).Lint(context.Background(), parse(tt.records))
+			if got := ids(findings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lint() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRegister tests registering the custom rules.
+func TestRegister(t *testing.T) {
+	linter := New()
+	linter.Unregister("missing-aaaa")
+	linter.Register(TTLRange(3600, 0))
+	linter.Register(Rule{
+		Name: "no-caa",
+		Check: func(_ context.Context, response *dnslookupapi.DNSLookupResponse) []Finding {
+			if len(response.DNSRecords.CAA) != 0 {
+				return nil
+			}
+
+			return []Finding{{ID: "caa-missing", Severity: SeverityInfo, Message: "no CAA records"}}
+		},
+	})
+
+	want := []string{"ttl-range", "cname-at-apex", "spf-lookup-limit", "dangling-targets", "duplicate-records", "no-caa"}
+	if got := linter.Rules(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Rules() = %v, want %v", got, want)
+	}
+
+	findings := linter.Lint(context.Background(),
+		parse(`[{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"}]`))
+	if got := ids(findings); !reflect.DeepEqual(got, []string{"ttl-too-low", "caa-missing"}) {
+		t.Errorf("Lint() = %v", got)
+	}
+}
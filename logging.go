@@ -0,0 +1,137 @@
+package dnslookupapi
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogEventKind is the kind of the request lifecycle event.
+type LogEventKind string
+
+const (
+	// LogRequest is logged before the request is sent.
+	LogRequest LogEventKind = "request"
+
+	// LogResponse is logged after the response is read or the request failed.
+	LogResponse LogEventKind = "response"
+
+	// LogRetry is logged when the request is repeated with the next API key or after a transient failure,
+	// see ClientParams.Retry.
+	LogRetry LogEventKind = "retry"
+
+	// LogParseError is logged when the response or some of its records cannot be parsed.
+	LogParseError LogEventKind = "parse_error"
+)
+
+// LogEvent is the request lifecycle event. The URL never contains the API key.
+type LogEvent struct {
+	Kind LogEventKind
+
+	// URL is the request URL with the API key redacted
+	URL string
+
+	// KeyID is the masked API key used for the request
+	KeyID string
+
+	// StatusCode is the response status code, zero if there is no response
+	StatusCode int
+
+	// Duration is the time elapsed from sending the request
+	Duration time.Duration
+
+	// Attempt is the number of the attempt starting from 1
+	Attempt int
+
+	// Err is the error of the failed request or the parse error
+	Err error
+
+	// RequestID is the request ID set by WithRequestID
+	RequestID string
+
+	// Priority is the call priority set by WithPriority
+	Priority Priority
+
+	// Tags are the call tags set by WithTag
+	Tags map[string]string
+}
+
+// String returns the event as a logfmt line.
+func (e LogEvent) String() string {
+	var b strings.Builder
+
+	b.WriteString("event=" + string(e.Kind))
+
+	if e.URL != "" {
+		b.WriteString(" url=" + strconv.Quote(e.URL))
+	}
+
+	if e.KeyID != "" {
+		b.WriteString(" key=" + e.KeyID)
+	}
+
+	if e.Attempt != 0 {
+		b.WriteString(" attempt=" + strconv.Itoa(e.Attempt))
+	}
+
+	if e.StatusCode != 0 {
+		b.WriteString(" status=" + strconv.Itoa(e.StatusCode))
+	}
+
+	if e.Duration != 0 {
+		b.WriteString(" duration=" + e.Duration.String())
+	}
+
+	if e.RequestID != "" {
+		b.WriteString(" request_id=" + strconv.Quote(e.RequestID))
+	}
+
+	if e.Priority != "" {
+		b.WriteString(" priority=" + string(e.Priority))
+	}
+
+	for _, k := range sortedTags(e.Tags) {
+		b.WriteString(" tag." + k + "=" + strconv.Quote(e.Tags[k]))
+	}
+
+	if e.Err != nil {
+		b.WriteString(" error=" + strconv.Quote(e.Err.Error()))
+	}
+
+	return b.String()
+}
+
+// Logger receives the request lifecycle events.
+// It must be safe for concurrent use if the client is used concurrently.
+type Logger interface {
+	Log(event LogEvent)
+}
+
+// LoggerFunc is the function adapter for Logger.
+type LoggerFunc func(event LogEvent)
+
+// Log calls f(event).
+func (f LoggerFunc) Log(event LogEvent) {
+	f(event)
+}
+
+// NewStdLogger creates Logger writing events to the standard library logger.
+// If logger is nil then the standard logger is used.
+func NewStdLogger(logger *log.Logger) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return LoggerFunc(func(event LogEvent) {
+		logger.Println("dnslookupapi: " + event.String())
+	})
+}
+
+// log sends the event with the attribution of the call context to the logger if it's set.
+func (c *Client) log(ctx context.Context, event LogEvent) {
+	if c.logger != nil {
+		c.logger.Log(withCallContext(ctx, event))
+	}
+}
@@ -0,0 +1,137 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// eventRecorder is the Logger collecting events for testing.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []LogEvent
+}
+
+// Log records the event.
+func (r *eventRecorder) Log(event LogEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+// TestLogger tests the request lifecycle events.
+func TestLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("apiKey") {
+		case "at_firstKeyWithoutCredits":
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"code":402,"messages":"Insufficient balance"}`))
+		default:
+			_, _ = w.Write([]byte(`{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[` +
+				`{"type":15,"dnsType":"MX","name":"whoisxmlapi.com.","ttl":"bad"}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	recorder := &eventRecorder{}
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_firstKeyWithoutCredits", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		APIKeys:          []string{"at_secondKeyWithCredits"},
+		Logger:           recorder,
+	})
+
+	if _, _, err := client.Get(context.Background(), "whoisxmlapi.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	wantKinds := []LogEventKind{LogRequest, LogResponse, LogRetry, LogRequest, LogResponse, LogParseError}
+	if len(recorder.events) != len(wantKinds) {
+		t.Fatalf("got %d events %v, want %v", len(recorder.events), recorder.events, wantKinds)
+	}
+
+	for i, event := range recorder.events {
+		if event.Kind != wantKinds[i] {
+			t.Errorf("event %d kind = %v, want %v", i, event.Kind, wantKinds[i])
+		}
+
+		if strings.Contains(event.URL, "KeyWith") || strings.Contains(event.String(), "KeyWith") {
+			t.Errorf("event %d leaks the API key: %v", i, event)
+		}
+	}
+
+	if got := recorder.events[1]; got.StatusCode != http.StatusPaymentRequired || got.Attempt != 1 {
+		t.Errorf("first response event = %v", got)
+	}
+
+	if got := recorder.events[4]; got.StatusCode != http.StatusOK || got.Attempt != 2 || got.KeyID != "at_...dits" {
+		t.Errorf("second response event = %v", got)
+	}
+
+	var parseErrs ParseErrors
+	if !errors.As(recorder.events[5].Err, &parseErrs) {
+		t.Errorf("parse error event error = %v, want ParseErrors", recorder.events[5].Err)
+	}
+}
+
+// TestNewStdLogger tests writing events to the standard library logger.
+func TestNewStdLogger(t *testing.T) {
+	var b bytes.Buffer
+
+	NewStdLogger(log.New(&b, "", 0)).Log(LogEvent{
+		Kind:       LogResponse,
+		URL:        "https://www.whoisxmlapi.com/whoisserver/DNSService?apiKey=REDACTED",
+		StatusCode: 500,
+		Attempt:    1,
+		Err:        errors.New("failed"),
+	})
+
+	want := `dnslookupapi: event=response url="https://www.whoisxmlapi.com/whoisserver/DNSService?apiKey=REDACTED" ` +
+		"attempt=1 status=500 error=\"failed\"\n"
+	if b.String() != want {
+		t.Errorf("NewStdLogger() wrote %q, want %q", b.String(), want)
+	}
+}
+
+// TestLoggerTransportError tests that transport errors don't leak the API key.
+func TestLoggerTransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	baseURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	recorder := &eventRecorder{}
+
+	client := NewClient("at_SECRETKEY123456", ClientParams{
+		DNSLookupBaseURL: baseURL,
+		Logger:           recorder,
+	})
+
+	_, _, err := client.Get(context.Background(), "whoisxmlapi.com")
+	if err == nil {
+		t.Fatal("Get() error = nil, want transport error")
+	}
+
+	if strings.Contains(err.Error(), "SECRETKEY") {
+		t.Errorf("Get() error leaks the API key: %v", err)
+	}
+
+	if len(recorder.events) == 0 {
+		t.Fatal("no events logged")
+	}
+
+	for i, event := range recorder.events {
+		if strings.Contains(event.String(), "SECRETKEY") {
+			t.Errorf("event %d leaks the API key: %v", i, event)
+		}
+	}
+}
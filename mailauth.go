@@ -0,0 +1,261 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNoDMARCRecord is returned when there is no DMARC record for the domain.
+	ErrNoDMARCRecord = errors.New("no DMARC record")
+
+	// ErrMultipleDMARCRecords is returned when there is more than one DMARC record for the domain.
+	ErrMultipleDMARCRecords = errors.New("multiple DMARC records")
+
+	// ErrNoDKIMRecord is returned when there is no DKIM key record for the selector.
+	ErrNoDKIMRecord = errors.New("no DKIM record")
+)
+
+// DMARCPolicy is the parsed DMARC record (RFC 7489).
+type DMARCPolicy struct {
+	// Raw is the DMARC record as a string.
+	Raw string
+
+	// Policy is the requested policy for the domain: none, quarantine or reject.
+	Policy string
+
+	// SubdomainPolicy is the requested policy for subdomains. Defaults to Policy.
+	SubdomainPolicy string
+
+	// Percent is the percentage of messages the policy applies to. Default: 100.
+	Percent int
+
+	// AggregateReportURIs are the addresses for aggregate reports (rua).
+	AggregateReportURIs []string
+
+	// FailureReportURIs are the addresses for failure reports (ruf).
+	FailureReportURIs []string
+
+	// DKIMAlignment is the DKIM identifier alignment mode: r (relaxed) or s (strict). Default: r.
+	DKIMAlignment string
+
+	// SPFAlignment is the SPF identifier alignment mode: r (relaxed) or s (strict). Default: r.
+	SPFAlignment string
+
+	// FailureOptions are the failure reporting options (fo). Default: 0.
+	FailureOptions string
+
+	// ReportInterval is the requested interval between aggregate reports in seconds. Default: 86400.
+	ReportInterval int
+
+	// Tags are all tags of the record.
+	Tags map[string]string
+}
+
+// DKIMKey is the parsed DKIM key record (RFC 6376).
+type DKIMKey struct {
+	// Raw is the DKIM key record as a string.
+	Raw string
+
+	// KeyType is the key type. Default: rsa.
+	KeyType string
+
+	// PublicKey is the decoded public key. Empty key means that the key has been revoked.
+	PublicKey []byte
+
+	// HashAlgorithms are the acceptable hash algorithms (h). Empty means all algorithms are allowed.
+	HashAlgorithms []string
+
+	// ServiceTypes are the service types the key applies to (s). Default: *.
+	ServiceTypes []string
+
+	// Flags are the flags of the key (t), e.g. y for testing mode.
+	Flags []string
+
+	// Notes are notes for humans (n).
+	Notes string
+
+	// Tags are all tags of the record.
+	Tags map[string]string
+}
+
+// Revoked reports whether the key has been revoked.
+func (k *DKIMKey) Revoked() bool {
+	return len(k.PublicKey) == 0
+}
+
+// Testing reports whether the domain is testing DKIM.
+func (k *DKIMKey) Testing() bool {
+	for _, flag := range k.Flags {
+		if flag == "y" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitList splits the separated list, e.g. a tag value, dropping empty items.
+func splitList(value, sep string) []string {
+	var result []string
+
+	for _, v := range strings.Split(value, sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// isDMARC reports whether the string starts with the DMARC version.
+func isDMARC(txt string) bool {
+	tags := strings.SplitN(txt, ";", 2)
+	v := strings.ReplaceAll(tags[0], " ", "")
+
+	return v == "v=DMARC1"
+}
+
+// ParseDMARC parses the DMARC record.
+func ParseDMARC(txt string) (*DMARCPolicy, error) {
+	if !isDMARC(txt) {
+		return nil, ErrNoDMARCRecord
+	}
+
+	tags, err := ParseTags(txt)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &DMARCPolicy{
+		Raw:             txt,
+		Policy:          strings.ToLower(tags["p"]),
+		SubdomainPolicy: strings.ToLower(tags["sp"]),
+		Percent:         100,
+		DKIMAlignment:   "r",
+		SPFAlignment:    "r",
+		FailureOptions:  "0",
+		ReportInterval:  86400,
+		Tags:            tags,
+	}
+
+	if policy.SubdomainPolicy == "" {
+		policy.SubdomainPolicy = policy.Policy
+	}
+
+	if v, ok := tags["pct"]; ok {
+		if policy.Percent, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New(`invalid tag "pct=` + v + `"`)
+		}
+	}
+
+	if v, ok := tags["ri"]; ok {
+		if policy.ReportInterval, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New(`invalid tag "ri=` + v + `"`)
+		}
+	}
+
+	if v, ok := tags["adkim"]; ok {
+		policy.DKIMAlignment = strings.ToLower(v)
+	}
+
+	if v, ok := tags["aspf"]; ok {
+		policy.SPFAlignment = strings.ToLower(v)
+	}
+
+	if v, ok := tags["fo"]; ok {
+		policy.FailureOptions = v
+	}
+
+	policy.AggregateReportURIs = splitList(tags["rua"], ",")
+	policy.FailureReportURIs = splitList(tags["ruf"], ",")
+
+	return policy, nil
+}
+
+// ParseDKIM parses the DKIM key record.
+func ParseDKIM(txt string) (*DKIMKey, error) {
+	tags, err := ParseTags(txt)
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := tags["v"]; ok && v != "DKIM1" {
+		return nil, ErrNoDKIMRecord
+	}
+
+	p, ok := tags["p"]
+	if !ok {
+		return nil, ErrNoDKIMRecord
+	}
+
+	key := &DKIMKey{
+		Raw:            txt,
+		KeyType:        "rsa",
+		HashAlgorithms: splitList(tags["h"], ":"),
+		ServiceTypes:   []string{"*"},
+		Flags:          splitList(tags["t"], ":"),
+		Notes:          tags["n"],
+		Tags:           tags,
+	}
+
+	if v, ok := tags["k"]; ok {
+		key.KeyType = strings.ToLower(v)
+	}
+
+	if v, ok := tags["s"]; ok {
+		key.ServiceTypes = splitList(v, ":")
+	}
+
+	key.PublicKey, err = base64.StdEncoding.DecodeString(strings.Join(strings.Fields(p), ""))
+	if err != nil {
+		return nil, errors.New("invalid public key: " + err.Error())
+	}
+
+	return key, nil
+}
+
+// GetDMARC returns the parsed DMARC policy of the domain from the _dmarc.<domain> TXT record.
+func (c *Client) GetDMARC(ctx context.Context, domainName string, opts ...Option) (*DMARCPolicy, *Response, error) {
+	records, resp, err := c.GetTXT(ctx, "_dmarc."+domainName, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	var found []string
+
+	for _, record := range records {
+		if txt := record.Value(); isDMARC(txt) {
+			found = append(found, txt)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, resp, ErrNoDMARCRecord
+	case 1:
+		policy, err := ParseDMARC(found[0])
+		return policy, resp, err
+	}
+
+	return nil, resp, ErrMultipleDMARCRecords
+}
+
+// GetDKIM returns the parsed DKIM key from the <selector>._domainkey.<domain> TXT record.
+func (c *Client) GetDKIM(ctx context.Context, selector, domainName string, opts ...Option) (*DKIMKey, *Response, error) {
+	records, resp, err := c.GetTXT(ctx, selector+"._domainkey."+domainName, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for _, record := range records {
+		if key, err := ParseDKIM(record.Value()); err == nil {
+			return key, resp, nil
+		}
+	}
+
+	return nil, resp, ErrNoDKIMRecord
+}
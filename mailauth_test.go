@@ -0,0 +1,183 @@
+package dnslookupapi
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestParseDMARC tests the ParseDMARC function.
+func TestParseDMARC(t *testing.T) {
+	tests := []struct {
+		name    string
+		txt     string
+		want    *DMARCPolicy
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			txt:  "v=DMARC1; p=reject",
+			want: &DMARCPolicy{
+				Raw:             "v=DMARC1; p=reject",
+				Policy:          "reject",
+				SubdomainPolicy: "reject",
+				Percent:         100,
+				DKIMAlignment:   "r",
+				SPFAlignment:    "r",
+				FailureOptions:  "0",
+				ReportInterval:  86400,
+				Tags:            map[string]string{"v": "DMARC1", "p": "reject"},
+			},
+		},
+		{
+			name: "all tags",
+			txt: "v=DMARC1;p=quarantine;sp=none;pct=50;adkim=s;aspf=s;fo=1;ri=3600;" +
+				"rua=mailto:a@example.com,mailto:b@example.com;ruf=mailto:f@example.com;",
+			want: &DMARCPolicy{
+				Policy:              "quarantine",
+				SubdomainPolicy:     "none",
+				Percent:             50,
+				AggregateReportURIs: []string{"mailto:a@example.com", "mailto:b@example.com"},
+				FailureReportURIs:   []string{"mailto:f@example.com"},
+				DKIMAlignment:       "s",
+				SPFAlignment:        "s",
+				FailureOptions:      "1",
+				ReportInterval:      3600,
+			},
+		},
+		{
+			name:    "not DMARC",
+			txt:     "v=spf1 -all",
+			wantErr: "no DMARC record",
+		},
+		{
+			name:    "invalid pct",
+			txt:     "v=DMARC1; p=none; pct=all",
+			wantErr: `invalid tag "pct=all"`,
+		},
+		{
+			name:    "invalid tag",
+			txt:     "v=DMARC1; p=none; foo",
+			wantErr: `invalid tag "foo"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDMARC(tt.txt)
+			checkErr(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+
+			if tt.want.Raw == "" {
+				got.Raw, got.Tags = "", nil
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDMARC() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseDKIM tests the ParseDKIM function.
+func TestParseDKIM(t *testing.T) {
+	tests := []struct {
+		name        string
+		txt         string
+		keyType     string
+		key         string
+		services    []string
+		hashes      []string
+		wantRevoked bool
+		wantTesting bool
+		wantErr     string
+	}{
+		{
+			name:     "rsa",
+			txt:      "v=DKIM1; k=rsa; p=a2V5",
+			keyType:  "rsa",
+			key:      "key",
+			services: []string{"*"},
+		},
+		{
+			name:        "ed25519 in testing mode",
+			txt:         "k=ed25519; h=sha256; s=email; t=y:s; p=a2 V5",
+			keyType:     "ed25519",
+			key:         "key",
+			services:    []string{"email"},
+			hashes:      []string{"sha256"},
+			wantTesting: true,
+		},
+		{
+			name:        "revoked",
+			txt:         "v=DKIM1; p=",
+			keyType:     "rsa",
+			key:         "",
+			services:    []string{"*"},
+			wantRevoked: true,
+		},
+		{
+			name:    "no key",
+			txt:     "v=DKIM1; k=rsa",
+			wantErr: "no DKIM record",
+		},
+		{
+			name:    "invalid key",
+			txt:     "p=!!!",
+			wantErr: "invalid public key: illegal base64 data at input byte 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDKIM(tt.txt)
+			checkErr(t, err, tt.wantErr)
+			if tt.wantErr != "" {
+				return
+			}
+
+			if got.KeyType != tt.keyType || string(got.PublicKey) != tt.key {
+				t.Errorf("ParseDKIM() = %v %q, want %v %q", got.KeyType, got.PublicKey, tt.keyType, tt.key)
+			}
+
+			if !reflect.DeepEqual(got.ServiceTypes, tt.services) || !reflect.DeepEqual(got.HashAlgorithms, tt.hashes) {
+				t.Errorf("ParseDKIM() = %v %v, want %v %v", got.ServiceTypes, got.HashAlgorithms, tt.services, tt.hashes)
+			}
+
+			if got.Revoked() != tt.wantRevoked || got.Testing() != tt.wantTesting {
+				t.Errorf("Revoked() = %v, Testing() = %v", got.Revoked(), got.Testing())
+			}
+		})
+	}
+}
+
+// TestGetDMARC tests the GetDMARC and GetDKIM functions.
+func TestGetDMARC(t *testing.T) {
+	const resp = `{"DNSData":{"dnsRecords":[
+{"type":16,"dnsType":"TXT","strings":["v=DMARC1; p=reject; ","rua=mailto:d@example.com"]},
+{"type":16,"dnsType":"TXT","strings":["v=DKIM1; p=a2V5"]}
+]}}`
+
+	server := dummyServer(resp, resp, resp)
+	defer server.Close()
+
+	api := newAPI(server, pathDNSLookupResponseOK)
+
+	policy, _, err := api.GetDMARC(context.Background(), "whoisxmlapi.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policy.Policy != "reject" || !reflect.DeepEqual(policy.AggregateReportURIs, []string{"mailto:d@example.com"}) {
+		t.Errorf("GetDMARC() = %+v", policy)
+	}
+
+	key, _, err := api.GetDKIM(context.Background(), "selector1", "whoisxmlapi.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(key.PublicKey) != "key" {
+		t.Errorf("GetDKIM() = %+v", key)
+	}
+}
@@ -0,0 +1,82 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MarshalAPI encodes the records as a JSON array in the original API format.
+// Parsed records are encoded from the typed records, so changes made to them are kept.
+// Records which failed to parse are written as received.
+func (r *DNSRecords) MarshalAPI() ([]byte, error) {
+	typed := make([]interface{}, len(r.All))
+
+	r.each(func(i int, _ DNSRecord, record interface{}) bool {
+		typed[i] = record
+		return true
+	})
+
+	var b bytes.Buffer
+
+	b.WriteByte('[')
+
+	for i, record := range r.All {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		if (typed[i] == nil || record.ParseError != nil) && len(record.Raw) != 0 {
+			if err := json.Compact(&b, record.Raw); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		value := typed[i]
+		if value == nil {
+			value = record.CommonFields
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(raw)
+	}
+
+	b.WriteByte(']')
+
+	return b.Bytes(), nil
+}
+
+// MarshalAPI encodes the response in the original API format, as returned by GetRaw.
+func (r *DNSLookupResponse) MarshalAPI() ([]byte, error) {
+	records, err := r.DNSRecords.MarshalAPI()
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		DNSData struct {
+			DomainName string          `json:"domainName"`
+			Types      []int           `json:"types"`
+			DNSTypes   string          `json:"dnsTypes"`
+			Audit      Audit           `json:"audit"`
+			DNSRecords json.RawMessage `json:"dnsRecords"`
+		} `json:"DNSData"`
+	}{}
+
+	response.DNSData.DomainName = r.DomainName
+	response.DNSData.Types = r.Types
+	response.DNSData.DNSTypes = r.DNSTypes
+	response.DNSData.Audit = r.Audit
+	response.DNSData.DNSRecords = records
+
+	if response.DNSData.Types == nil {
+		response.DNSData.Types = []int{}
+	}
+
+	return json.Marshal(response)
+}
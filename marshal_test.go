@@ -0,0 +1,48 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestMarshalAPI tests the MarshalAPI functions.
+func TestMarshalAPI(t *testing.T) {
+	const resp = `{"DNSData":{"domainName":"whoisxmlapi.com","types":[1,65534],"dnsTypes":"A,FUTURE",` +
+		`"audit":{"createdDate":"2022-07-12 11:46:25 UTC","updatedDate":"2022-07-12 11:46:25 UTC"},` +
+		`"dnsRecords":[{"type":1,"dnsType":"A","name":"whoisxmlapi.com.","ttl":300,"rRsetType":1,` +
+		`"rawText":"whoisxmlapi.com.\t300\tIN\tA\t104.26.13.210","address":"104.26.13.210"},` +
+		`{"type":65534,"dnsType":"FUTURE","data":"x"}]}}`
+
+	parsed, err := parse([]byte(resp))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := parsed.DNSLookupResponse.MarshalAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want bytes.Buffer
+	if err = json.Compact(&want, []byte(resp)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("MarshalAPI() = %s", got)
+		t.Errorf("want          %s", want.Bytes())
+	}
+
+	parsed.DNSRecords.A[0].Address = "192.0.2.1"
+
+	records, err := parsed.DNSRecords.MarshalAPI()
+	if err != nil || !bytes.Contains(records, []byte(`"address":"192.0.2.1"`)) {
+		t.Errorf("MarshalAPI() after change = %s, %v", records, err)
+	}
+
+	empty, err := (&DNSRecords{}).MarshalAPI()
+	if err != nil || string(empty) != "[]" {
+		t.Errorf("MarshalAPI() = %s, %v", empty, err)
+	}
+}
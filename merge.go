@@ -0,0 +1,108 @@
+package dnslookupapi
+
+// mergeOptions configures merging of DNS records.
+type mergeOptions struct {
+	ignoreTTL bool
+	equal     func(a, b *DNSRecord) bool
+}
+
+// MergeOption configures Merge and Dedupe.
+type MergeOption func(o *mergeOptions)
+
+// MergeIgnoreTTL makes records which differ only in TTL duplicates. The first of them is kept.
+func MergeIgnoreTTL() MergeOption {
+	return func(o *mergeOptions) {
+		o.ignoreTTL = true
+	}
+}
+
+// MergeEqualFunc makes records for which equal returns true duplicates. The first of them is kept.
+// It replaces the default comparison by type, owner name, data and TTL.
+func MergeEqualFunc(equal func(a, b *DNSRecord) bool) MergeOption {
+	return func(o *mergeOptions) {
+		o.equal = equal
+	}
+}
+
+// Merge returns a new DNSRecords holding the records of both sets without duplicates,
+// e.g. to reassemble the results of lookups issued separately per DNS type.
+// The records of a come first, followed by the records of b not present in a.
+// Owner names are compared case-insensitively. Either set may be nil.
+func Merge(a, b *DNSRecords, opts ...MergeOption) *DNSRecords {
+	var (
+		all    []DNSRecord
+		result = a.derive()
+	)
+
+	for _, records := range []*DNSRecords{a, b} {
+		if records != nil {
+			all = append(all, records.All...)
+
+			if records.Legacy != nil {
+				result = records.derive()
+			}
+		}
+	}
+
+	return dedupe(result, all, opts)
+}
+
+// Dedupe removes duplicate records keeping the first of them. Typed slices are rebuilt.
+// It returns the number of removed records.
+func Dedupe(r *DNSRecords, opts ...MergeOption) int {
+	n := len(r.All)
+	*r = *dedupe(r.derive(), r.All, opts)
+
+	return n - len(r.All)
+}
+
+// dedupe adds the records without duplicates to the empty result and returns it.
+func dedupe(result *DNSRecords, all []DNSRecord, opts []MergeOption) *DNSRecords {
+	var o mergeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	seen := make(map[string]bool, len(all))
+
+	for i := range all {
+		record := &all[i]
+
+		if o.equal != nil {
+			if containsRecord(result.All, record, o.equal) {
+				continue
+			}
+		} else {
+			key := recordKey(record)
+			if !o.ignoreTTL {
+				key = record.Key(CompareTTL())
+			}
+
+			if seen[key] {
+				continue
+			}
+
+			seen[key] = true
+		}
+
+		if len(record.Raw) == 0 {
+			result.All = append(result.All, *record)
+			continue
+		}
+
+		result.All = append(result.All, result.parseRecord(record.Raw))
+	}
+
+	return result
+}
+
+// containsRecord reports whether any of the records is equal to the record.
+func containsRecord(records []DNSRecord, record *DNSRecord, equal func(a, b *DNSRecord) bool) bool {
+	for i := range records {
+		if equal(&records[i], record) {
+			return true
+		}
+	}
+
+	return false
+}
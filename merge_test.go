@@ -0,0 +1,68 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestMerge tests merging of records of separate lookups.
+func TestMerge(t *testing.T) {
+	parse := func(s string) *DNSRecords {
+		var r DNSRecords
+		if err := json.Unmarshal([]byte(s), &r); err != nil {
+			t.Fatal(err)
+		}
+
+		return &r
+	}
+
+	a := parse(`[
+		{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"rawText":"example.com.\t300\tIN\tA\t192.0.2.1","address":"192.0.2.1"},
+		{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"rawText":"example.com.\t300\tIN\tMX\t10 mx.example.com.",
+			"target":"mx.example.com.","priority":10}
+	]`)
+	b := parse(`[
+		{"type":1,"dnsType":"A","name":"EXAMPLE.com.","ttl":300,"rawText":"EXAMPLE.com.\t300\tIN\tA\t192.0.2.1","address":"192.0.2.1"},
+		{"type":1,"dnsType":"A","name":"example.com.","ttl":120,"rawText":"example.com.\t120\tIN\tA\t192.0.2.1","address":"192.0.2.1"},
+		{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"rawText":"example.com.\t300\tIN\tTXT\t\"v=spf1 -all\"",
+			"strings":["v=spf1 -all"]}
+	]`)
+
+	tests := []struct {
+		name  string
+		opts  []MergeOption
+		count int
+		a     int
+	}{
+		{"exact", nil, 4, 2},
+		{"ignore TTL", []MergeOption{MergeIgnoreTTL()}, 3, 1},
+		{"by type", []MergeOption{MergeEqualFunc(func(x, y *DNSRecord) bool {
+			return x.CommonFields.DNSType == y.CommonFields.DNSType
+		})}, 3, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := Merge(a, b, tt.opts...)
+			if merged.Count() != tt.count || len(merged.A) != tt.a || len(merged.MX) != 1 || len(merged.TXT) != 1 {
+				t.Errorf("Merge() = %d records, %d A", merged.Count(), len(merged.A))
+			}
+
+			if merged.All[0].CommonFields.TTL != 300 || !strings.Contains(merged.All[1].CommonFields.RawText, "MX") {
+				t.Errorf("Merge() order = %+v", merged.All)
+			}
+		})
+	}
+
+	if merged := Merge(nil, a); merged.Count() != 2 {
+		t.Errorf("Merge(nil, a) = %d records", merged.Count())
+	}
+
+	all := Merge(a, nil)
+	all.All = append(all.All, b.All...)
+
+	if removed := Dedupe(all, MergeIgnoreTTL()); removed != 2 || len(all.A) != 1 || len(all.TXT) != 1 {
+		t.Errorf("Dedupe() = %d, records %d", removed, all.Count())
+	}
+}
@@ -1,6 +1,7 @@
 package dnslookupapi
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,14 +20,36 @@ func unmarshalString(raw json.RawMessage) (string, error) {
 	return val, nil
 }
 
+// unmarshalTimeString returns the JSON string of printable ASCII characters without escapes as is
+// and parses other values as a string.
+func unmarshalTimeString(raw []byte) (string, error) {
+	n := len(raw)
+	if n < 2 || raw[0] != '"' || raw[n-1] != '"' {
+		return unmarshalString(raw)
+	}
+
+	for _, c := range raw[1 : n-1] {
+		if c < 0x20 || c > 0x7e || c == '"' || c == '\\' {
+			return unmarshalString(raw)
+		}
+	}
+
+	return string(raw[1 : n-1]), nil
+}
+
 // Time is a helper wrapper on time.Time.
 type Time time.Time
 
 var emptyTime Time
 
 // UnmarshalJSON decodes time as DNS Lookup API does.
+// The string is parsed with the layouts registered by RegisterTimeLayout, see SetTimeLayouts.
 func (t *Time) UnmarshalJSON(b []byte) error {
-	str, err := unmarshalString(b)
+	if len(b) != 0 && (b[0] == '-' || '0' <= b[0] && b[0] <= '9') && acceptsEpochSeconds() {
+		return t.parseEpochSeconds(string(b))
+	}
+
+	str, err := unmarshalTimeString(b)
 	if err != nil {
 		return err
 	}
@@ -34,12 +57,7 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 		*t = emptyTime
 		return nil
 	}
-	v, err := time.Parse("2006-01-02 15:04:05 MST", str)
-	if err != nil {
-		return err
-	}
-	*t = Time(v)
-	return nil
+	return t.parse(str)
 }
 
 // MarshalJSON encodes time as DNS Lookup API does.
@@ -47,7 +65,7 @@ func (t Time) MarshalJSON() ([]byte, error) {
 	if t == emptyTime {
 		return []byte(`""`), nil
 	}
-	return []byte(`"` + time.Time(t).Format("2006-01-02 15:04:05 MST") + `"`), nil
+	return []byte(`"` + time.Time(t).Format(TimeLayout) + `"`), nil
 }
 
 type commonFields struct {
@@ -406,6 +424,58 @@ type TLSARecord struct {
 	Selector int `json:"selector"`
 }
 
+type URIRecord struct {
+	commonFields
+
+	// Priority is the priority of the target URI, lower values are preferred.
+	Priority int `json:"priority"`
+
+	// Weight is the relative weight for entries with the same priority.
+	Weight int `json:"weight"`
+
+	// Target is the URI.
+	Target string `json:"target"`
+}
+
+type CERTRecord struct {
+	commonFields
+
+	// CertificateType is the type of the certificate, e.g. 1 for X.509.
+	CertificateType int `json:"certificateType"`
+
+	// KeyTag is the key tag of the key the certificate is signed with.
+	KeyTag int `json:"keyTag"`
+
+	// Algorithm is the algorithm of the key the certificate is signed with.
+	Algorithm int `json:"algorithm"`
+
+	// Certificate is the base64-encoded certificate or CRL.
+	Certificate []string `json:"certificate"`
+}
+
+type SMIMEARecord struct {
+	commonFields
+
+	// CertificateAssociationData specifies the "certificate association data" to be matched.
+	CertificateAssociationData []string `json:"certificateAssociationData"`
+
+	// CertificateUsage specifies the provided association that will be used to match the S/MIME certificate.
+	CertificateUsage int `json:"certificateUsage"`
+
+	// MatchingType specifies how the certificate association is presented.
+	MatchingType int `json:"matchingType"`
+
+	// Selector specifies which part of the certificate will be matched against the association data.
+	Selector int `json:"selector"`
+}
+
+type OPENPGPKEYRecord struct {
+	commonFields
+
+	// PublicKey is the base64-encoded OpenPGP transferable public key.
+	PublicKey []string `json:"publicKey"`
+}
+
 type NSAPRecord struct {
 	commonFields
 
@@ -420,6 +490,14 @@ type NULLRecord struct {
 	Data []string `json:"data"`
 }
 
+// UnknownRecord is the record of a DNS type not supported by the library.
+type UnknownRecord struct {
+	commonFields
+
+	// Fields holds all fields of the record, numbers are stored as json.Number.
+	Fields map[string]interface{}
+}
+
 type DNSRecord struct {
 	CommonFields commonFields
 
@@ -427,7 +505,7 @@ type DNSRecord struct {
 	Raw json.RawMessage `json:"raw"`
 
 	// ParseError is the error that occurred during parsing.
-	ParseError error `json:"parseError"`
+	ParseError *ParseError `json:"parseError"`
 }
 
 // DNSRecords is the struct where returned DNS records are stored.
@@ -516,8 +594,27 @@ type DNSRecords struct {
 	// NSAP is a slice of the parsed NSAP records.
 	NSAP []NSAPRecord
 
+	// URI is a slice of the parsed URI records.
+	URI []URIRecord
+
+	// CERT is a slice of the parsed CERT records.
+	CERT []CERTRecord
+
+	// SMIMEA is a slice of the parsed SMIMEA records.
+	SMIMEA []SMIMEARecord
+
+	// OPENPGPKEY is a slice of the parsed OPENPGPKEY records.
+	OPENPGPKEY []OPENPGPKEYRecord
+
 	// NULL is a slice of the parsed NULL records.
 	NULL []NULLRecord
+
+	// Unknown is a slice of the records of unsupported DNS types.
+	Unknown []UnknownRecord
+
+	// Legacy holds the records of the legacy DNS types, e.g. KX or APL. It's nil unless ParseLegacy is applied
+	// and such records are kept in Unknown then.
+	Legacy *LegacyRecords
 }
 
 // UnmarshalJSON decodes DNS records and returns them as a DNSRecords struct.
@@ -529,6 +626,10 @@ func (r *DNSRecords) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if r.All == nil {
+		r.All = make([]DNSRecord, 0, len(raw))
+	}
+
 	for _, record := range raw {
 		r.All = append(r.All, r.parseRecord(record))
 	}
@@ -536,37 +637,64 @@ func (r *DNSRecords) UnmarshalJSON(data []byte) error {
 }
 
 func (r *DNSRecords) parseRecord(record json.RawMessage) DNSRecord {
-	var obj struct {
-		commonFields
+	// only the type is probed first, so the record is fully decoded just once when it's well-formed
+	var probe struct {
+		Type    int    `json:"type"`
+		DNSType string `json:"dnsType"`
 	}
 
-	if err := json.Unmarshal(record, &obj); err != nil {
-		return DNSRecord{
-			CommonFields: commonFields{},
-			Raw:          record,
-			ParseError:   err,
-		}
+	if err := json.Unmarshal(record, &probe); err != nil {
+		return DNSRecord{Raw: record, ParseError: newParseError(record, err)}
 	}
 
-	dnsRecord := DNSRecord{
-		CommonFields: obj.commonFields,
-		Raw:          record,
-		ParseError:   nil,
+	// missing or nonstandard type names are resolved from the type code
+	dnsType := resolveDNSType(probe.DNSType, probe.Type)
+
+	actual := actualDNSType(dnsType)
+	if actual == nil && r.Legacy != nil {
+		actual = legacyDNSType(dnsType)
 	}
 
-	// unmarshal again into the correct type
-	actual := actualDNSType(obj.DNSType)
 	if actual == nil {
-		dnsRecord.ParseError = ErrUnsupportedDNSType
-		return dnsRecord
+		var obj commonFields
+		if err := json.Unmarshal(record, &obj); err != nil {
+			return DNSRecord{Raw: record, ParseError: newParseError(record, err)}
+		}
+
+		obj.DNSType = dnsType
+
+		unknown := UnknownRecord{commonFields: obj}
+
+		decoder := json.NewDecoder(bytes.NewReader(record))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&unknown.Fields); err == nil {
+			r.Unknown = append(r.Unknown, unknown)
+		}
+
+		return DNSRecord{CommonFields: obj, Raw: record, ParseError: newParseError(record, ErrUnsupportedDNSType)}
 	}
 
 	if err := json.Unmarshal(record, actual); err != nil {
-		dnsRecord.ParseError = err
-		return dnsRecord
+		// the common fields are reported if they are valid on their own
+		var obj commonFields
+		if cerr := json.Unmarshal(record, &obj); cerr != nil {
+			return DNSRecord{Raw: record, ParseError: newParseError(record, cerr)}
+		}
+
+		obj.DNSType = dnsType
+
+		return DNSRecord{CommonFields: obj, Raw: record, ParseError: newParseError(record, err)}
 	}
 
-	switch obj.DNSType {
+	actual.(interface{ setDNSType(string) }).setDNSType(dnsType)
+
+	dnsRecord := DNSRecord{
+		CommonFields: actual.(interface{ common() commonFields }).common(),
+		Raw:          record,
+	}
+
+	switch dnsType {
 	case "A":
 		r.A = append(r.A, *actual.(*ARecord))
 	case "AAAA":
@@ -621,8 +749,18 @@ func (r *DNSRecords) parseRecord(record json.RawMessage) DNSRecord {
 		r.TLSA = append(r.TLSA, *actual.(*TLSARecord))
 	case "NSAP":
 		r.NSAP = append(r.NSAP, *actual.(*NSAPRecord))
+	case "URI":
+		r.URI = append(r.URI, *actual.(*URIRecord))
+	case "CERT":
+		r.CERT = append(r.CERT, *actual.(*CERTRecord))
+	case "SMIMEA":
+		r.SMIMEA = append(r.SMIMEA, *actual.(*SMIMEARecord))
+	case "OPENPGPKEY":
+		r.OPENPGPKEY = append(r.OPENPGPKEY, *actual.(*OPENPGPKEYRecord))
 	case "NULL":
 		r.NULL = append(r.NULL, *actual.(*NULLRecord))
+	default:
+		r.Legacy.add(dnsType, actual)
 	}
 
 	return dnsRecord
@@ -697,6 +835,14 @@ func actualDNSType(dnsType string) interface{} {
 		return &TLSARecord{}
 	case "NSAP":
 		return &NSAPRecord{}
+	case "URI":
+		return &URIRecord{}
+	case "CERT":
+		return &CERTRecord{}
+	case "SMIMEA":
+		return &SMIMEARecord{}
+	case "OPENPGPKEY":
+		return &OPENPGPKEYRecord{}
 	case "NULL":
 		return &NULLRecord{}
 	}
@@ -718,6 +864,16 @@ type DNSLookupResponse struct {
 	// DomainName is a domain name.
 	DomainName string `json:"domainName"`
 
+	// RequestedDomainName is the domain name as passed to Get.
+	RequestedDomainName string `json:"-"`
+
+	// ASCIIDomainName is the requested domain name in the ASCII form (A-labels) sent to the API.
+	// It's the registrable domain of RequestedDomainName if ClientParams.RegistrableDomain is set.
+	ASCIIDomainName string `json:"-"`
+
+	// UnicodeDomainName is the requested domain name in the Unicode form (U-labels).
+	UnicodeDomainName string `json:"-"`
+
 	// Types are codes of the requested DNS record types.
 	Types []int `json:"types"`
 
@@ -729,7 +885,12 @@ type DNSLookupResponse struct {
 	Audit Audit `json:"audit"`
 
 	// DNSRecords is the struct where returned DNS records are stored.
+	// Only All is set if the records were decoded lazily, see ParsedRecords.
 	DNSRecords DNSRecords `json:"dnsRecords"`
+
+	// LazyRecords holds the records with typed records decoded on first access.
+	// It's set by Get only if ClientParams.LazyRecords is set.
+	LazyRecords *LazyDNSRecords `json:"-"`
 }
 
 // ErrorMessage is an error message.
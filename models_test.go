@@ -132,3 +132,41 @@ func checkErr(t *testing.T, err error, want string) {
 		t.Errorf("error = %v, wantErr %v", err, want)
 	}
 }
+
+// TestSecurityRecords tests parsing of URI, CERT, SMIMEA and OPENPGPKEY records.
+func TestSecurityRecords(t *testing.T) {
+	const records = `[
+{"type":256,"dnsType":"URI","name":"_ftp._tcp.example.com.","ttl":300,"priority":10,"weight":1,` +
+		`"target":"ftp://ftp.example.com/public"},
+{"type":37,"dnsType":"CERT","name":"example.com.","ttl":300,"certificateType":1,"keyTag":12345,"algorithm":8,` +
+		`"certificate":["MIIB","AQAB"]},
+{"type":53,"dnsType":"SMIMEA","name":"c93f._smimecert.example.com.","ttl":300,"certificateUsage":3,` +
+		`"selector":1,"matchingType":1,"certificateAssociationData":["d2abde240d7cd3ee6b4b28c54df034b9"]},
+{"type":61,"dnsType":"OPENPGPKEY","name":"c93f._openpgpkey.example.com.","ttl":300,"publicKey":["mQENBF","AQAB"]}
+]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if errs := r.ParseErrors(); len(errs) != 0 || len(r.Unknown) != 0 {
+		t.Fatalf("ParseErrors() = %v, Unknown = %v", errs, r.Unknown)
+	}
+
+	if len(r.URI) != 1 || r.URI[0].Priority != 10 || r.URI[0].Target != "ftp://ftp.example.com/public" {
+		t.Errorf("URI = %+v", r.URI)
+	}
+
+	if len(r.CERT) != 1 || r.CERT[0].KeyTag != 12345 || len(r.CERT[0].Certificate) != 2 {
+		t.Errorf("CERT = %+v", r.CERT)
+	}
+
+	if len(r.SMIMEA) != 1 || r.SMIMEA[0].CertificateUsage != 3 || r.SMIMEA[0].GetTypeCode() != 53 {
+		t.Errorf("SMIMEA = %+v", r.SMIMEA)
+	}
+
+	if len(r.OPENPGPKEY) != 1 || r.OPENPGPKEY[0].PublicKey[0] != "mQENBF" {
+		t.Errorf("OPENPGPKEY = %+v", r.OPENPGPKEY)
+	}
+}
@@ -0,0 +1,273 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// defaultMonitorInterval is the default polling interval of the Monitor.
+const defaultMonitorInterval = time.Hour
+
+// ErrNoState is returned by StateStore.Load when there is no saved state for the domain.
+var ErrNoState = errors.New("no saved state")
+
+// ChangeKind is the kind of a DNS record change.
+type ChangeKind string
+
+// Kinds of DNS record changes.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// RecordChange is a change of a single DNS record.
+type RecordChange struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind
+
+	// Old is the previous record. It's nil for added records.
+	Old *DNSRecord
+
+	// New is the current record. It's nil for removed records.
+	New *DNSRecord
+}
+
+// ChangeEvent is delivered by the Monitor when DNS records of the domain change or the lookup fails.
+type ChangeEvent struct {
+	// DomainName is the monitored domain name.
+	DomainName string
+
+	// Time is the time of the lookup.
+	Time time.Time
+
+	// Changes are the changes since the previous lookup.
+	Changes []RecordChange
+
+	// Err is the lookup error.
+	Err error
+}
+
+// StateStore persists the last seen DNS records of monitored domains.
+// The state is the JSON array of raw DNS records as returned by the API.
+type StateStore interface {
+	// Load returns the saved state or ErrNoState.
+	Load(domainName string) ([]byte, error)
+
+	// Save saves the state.
+	Save(domainName string, state []byte) error
+}
+
+// MemoryStateStore is the in-memory StateStore. The zero value is ready to use.
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string][]byte
+}
+
+// Load returns the saved state or ErrNoState.
+func (s *MemoryStateStore) Load(domainName string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, ok := s.states[domainName]
+	if !ok {
+		return nil, ErrNoState
+	}
+
+	return state, nil
+}
+
+// Save saves the state.
+func (s *MemoryStateStore) Save(domainName string, state []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.states == nil {
+		s.states = make(map[string][]byte)
+	}
+
+	s.states[domainName] = state
+
+	return nil
+}
+
+// MonitorParams is used to create Monitor. None of parameters are mandatory.
+type MonitorParams struct {
+	// Interval is the polling interval
+	// If it's zero then domains are polled every hour
+	Interval time.Duration
+
+	// Options are the options passed to every lookup
+	Options []Option
+
+	// Store persists the last seen records between runs
+	// If it's nil then the state is kept in memory
+	Store StateStore
+
+	// OnChange is called for every event
+	// If it's nil and there are no Sinks then events are delivered over the Events channel
+	OnChange func(ChangeEvent)
+
+	// Sinks receive every event, e.g. WebhookSink pushes events to incident tooling
+	Sinks []EventSink
+
+	// OnSinkError is called when a sink fails to deliver the event
+	// If it's nil then sink errors are ignored
+	OnSinkError func(sink EventSink, event ChangeEvent, err error)
+}
+
+// Monitor polls domains on an interval and reports changes of their DNS records.
+type Monitor struct {
+	service DNSLookupService
+	domains []string
+	params  MonitorParams
+	events  chan ChangeEvent
+}
+
+// NewMonitor creates Monitor for the domains.
+func NewMonitor(service DNSLookupService, domains []string, params MonitorParams) *Monitor {
+	if params.Interval <= 0 {
+		params.Interval = defaultMonitorInterval
+	}
+
+	if params.Store == nil {
+		params.Store = &MemoryStateStore{}
+	}
+
+	return &Monitor{
+		service: service,
+		domains: append([]string(nil), domains...),
+		params:  params,
+		events:  make(chan ChangeEvent, len(domains)),
+	}
+}
+
+// Events returns the channel of events. It's closed when Run returns.
+// It's not used if MonitorParams.OnChange or MonitorParams.Sinks are set.
+func (m *Monitor) Events() <-chan ChangeEvent {
+	return m.events
+}
+
+// Run polls the domains until the context is done. The first lookup of a domain without
+// a saved state only records the baseline.
+func (m *Monitor) Run(ctx context.Context) error {
+	defer close(m.events)
+
+	ticker := time.NewTicker(m.params.Interval)
+	defer ticker.Stop()
+
+	for {
+		for _, domainName := range m.domains {
+			if err := m.poll(ctx, domainName); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll looks up the domain and delivers the event if records changed.
+func (m *Monitor) poll(ctx context.Context, domainName string) error {
+	event := ChangeEvent{
+		DomainName: domainName,
+		Time:       time.Now(),
+	}
+
+	current, err := m.lookup(ctx, domainName)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		event.Err = err
+
+		return m.deliver(ctx, event)
+	}
+
+	state, err := m.params.Store.Load(domainName)
+	switch {
+	case errors.Is(err, ErrNoState):
+	case err != nil:
+		event.Err = err
+		return m.deliver(ctx, event)
+	default:
+		var previous DNSRecords
+		if err = json.Unmarshal(state, &previous); err != nil {
+			event.Err = err
+			return m.deliver(ctx, event)
+		}
+
+		event.Changes = Diff(&previous, current, DiffIgnoreOrder()).Changes()
+	}
+
+	if err = m.params.Store.Save(domainName, rawRecords(current)); err != nil {
+		event.Err = err
+	}
+
+	if len(event.Changes) == 0 && event.Err == nil {
+		return nil
+	}
+
+	return m.deliver(ctx, event)
+}
+
+// lookup returns the current DNS records of the domain.
+func (m *Monitor) lookup(ctx context.Context, domainName string) (*DNSRecords, error) {
+	resp, _, err := m.service.Get(ctx, domainName, m.params.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ParsedRecords(), nil
+}
+
+// deliver delivers the event to the sinks and to the callback or the channel.
+func (m *Monitor) deliver(ctx context.Context, event ChangeEvent) error {
+	for _, sink := range m.params.Sinks {
+		if err := sink.Send(ctx, event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if m.params.OnSinkError != nil {
+				m.params.OnSinkError(sink, event, err)
+			}
+		}
+	}
+
+	if m.params.OnChange != nil {
+		m.params.OnChange(event)
+		return nil
+	}
+
+	if len(m.params.Sinks) != 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case m.events <- event:
+		return nil
+	}
+}
+
+// rawRecords returns the JSON array of raw DNS records.
+func rawRecords(records *DNSRecords) []byte {
+	raw := make([]json.RawMessage, 0, len(records.All))
+	for _, record := range records.All {
+		raw = append(raw, record.Raw)
+	}
+
+	b, _ := json.Marshal(raw)
+
+	return b
+}
@@ -0,0 +1,122 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sequenceService is the DNSLookupService returning prepared DNS records one by one.
+type sequenceService struct {
+	mu      sync.Mutex
+	records []string
+	calls   int
+}
+
+// Get returns the next prepared DNS records.
+func (s *sequenceService) Get(context.Context, string, ...Option) (*DNSLookupResponse, *Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.calls
+	if i >= len(s.records) {
+		i = len(s.records) - 1
+	}
+	s.calls++
+
+	if s.records[i] == "" {
+		return nil, nil, errors.New("lookup failed")
+	}
+
+	var resp DNSLookupResponse
+	if err := json.Unmarshal([]byte(`{"dnsRecords":`+s.records[i]+`}`), &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, nil, nil
+}
+
+// GetRaw is not used by the monitor.
+func (s *sequenceService) GetRaw(context.Context, string, ...Option) (*Response, error) {
+	panic("not implemented")
+}
+
+// changeKinds returns kinds and record keys of the changes.
+func changeKinds(changes []RecordChange) []string {
+	var result []string
+	for _, c := range changes {
+		record := c.New
+		if record == nil {
+			record = c.Old
+		}
+		result = append(result, string(c.Kind)+" "+recordKey(record))
+	}
+
+	return result
+}
+
+// TestMonitor tests the Monitor.
+func TestMonitor(t *testing.T) {
+	const (
+		a1    = `{"dnsType":"A","name":"example.com.","rawText":"example.com.\t300\tIN\tA\t1.1.1.1","address":"1.1.1.1","ttl":300}`
+		a1TTL = `{"dnsType":"A","name":"example.com.","rawText":"example.com.\t600\tIN\tA\t1.1.1.1","address":"1.1.1.1","ttl":600}`
+		a2    = `{"dnsType":"A","name":"example.com.","rawText":"example.com.\t300\tIN\tA\t2.2.2.2","address":"2.2.2.2","ttl":300}`
+	)
+
+	service := &sequenceService{records: []string{
+		"[" + a1 + "]",
+		"[ " + a1 + " ]",
+		"[" + a1TTL + "," + a2 + "]",
+		"",
+		"[]",
+	}}
+
+	store := &MemoryStateStore{}
+	monitor := NewMonitor(service, []string{"example.com"}, MonitorParams{
+		Interval: time.Millisecond,
+		Store:    store,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error)
+	go func() {
+		done <- monitor.Run(ctx)
+	}()
+
+	want := [][]string{
+		{`added A|example.com|{"address":"2.2.2.2"}`, `modified A|example.com|{"address":"1.1.1.1"}`},
+		nil,
+		{`removed A|example.com|{"address":"1.1.1.1"}`, `removed A|example.com|{"address":"2.2.2.2"}`},
+	}
+
+	for i, w := range want {
+		event := <-monitor.Events()
+		if event.DomainName != "example.com" {
+			t.Errorf("DomainName = %v", event.DomainName)
+		}
+
+		if (event.Err != nil) != (w == nil) {
+			t.Errorf("event %d: Err = %v", i, event.Err)
+		}
+
+		if got := changeKinds(event.Changes); !reflect.DeepEqual(got, w) {
+			t.Errorf("event %d: Changes = %v, want %v", i, got, w)
+		}
+	}
+
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v", err)
+	}
+
+	if state, err := store.Load("example.com"); err != nil || string(state) != "[]" {
+		t.Errorf("Load() = %s, %v", state, err)
+	}
+}
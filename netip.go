@@ -0,0 +1,73 @@
+//go:build go1.18
+
+package dnslookupapi
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// ErrInvalidAddress is returned when the address of A or AAAA record is malformed.
+var ErrInvalidAddress = errors.New("invalid IP address")
+
+// Addr returns the IPv4 address of the record.
+// It returns ErrInvalidAddress if the address is malformed or is not an IPv4 address.
+func (r ARecord) Addr() (netip.Addr, error) {
+	addr, err := netip.ParseAddr(r.Address)
+	if err != nil || !addr.Is4() {
+		return netip.Addr{}, fmt.Errorf("%w in A record: %q", ErrInvalidAddress, r.Address)
+	}
+
+	return addr, nil
+}
+
+// Addr returns the IPv6 address of the record.
+// It returns ErrInvalidAddress if the address is malformed or is not an IPv6 address.
+func (r AAAARecord) Addr() (netip.Addr, error) {
+	addr, err := netip.ParseAddr(r.Address)
+	if err != nil || !addr.Is6() || addr.Zone() != "" {
+		return netip.Addr{}, fmt.Errorf("%w in AAAA record: %q", ErrInvalidAddress, r.Address)
+	}
+
+	return addr, nil
+}
+
+// IPs returns the addresses of all A and AAAA records in the order returned by the API.
+// Malformed addresses are skipped and the first of their errors is returned.
+func (r *DNSRecords) IPs() ([]netip.Addr, error) {
+	var (
+		addrs    []netip.Addr
+		firstErr error
+	)
+
+	r.Each(func(_ DNSRecord, typed interface{}) bool {
+		var (
+			addr netip.Addr
+			err  error
+		)
+
+		switch record := typed.(type) {
+		case ARecord:
+			addr, err = record.Addr()
+		case AAAARecord:
+			addr, err = record.Addr()
+		default:
+			return true
+		}
+
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+
+			return true
+		}
+
+		addrs = append(addrs, addr)
+
+		return true
+	})
+
+	return addrs, firstErr
+}
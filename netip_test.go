@@ -0,0 +1,83 @@
+//go:build go1.18
+
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+// TestRecordAddr tests the address accessors of A and AAAA records.
+func TestRecordAddr(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    func() (netip.Addr, error)
+		want    netip.Addr
+		wantErr bool
+	}{
+		{name: "A", addr: ARecord{Address: "104.26.13.210"}.Addr, want: netip.MustParseAddr("104.26.13.210")},
+		{name: "A malformed", addr: ARecord{Address: "104.26.13"}.Addr, wantErr: true},
+		{name: "A with IPv6", addr: ARecord{Address: "2606:4700::1"}.Addr, wantErr: true},
+		{name: "AAAA", addr: AAAARecord{Address: "2606:4700:20::681a:dd2"}.Addr,
+			want: netip.MustParseAddr("2606:4700:20::681a:dd2")},
+		{name: "AAAA with IPv4", addr: AAAARecord{Address: "104.26.13.210"}.Addr, wantErr: true},
+		{name: "AAAA with zone", addr: AAAARecord{Address: "fe80::1%eth0"}.Addr, wantErr: true},
+		{name: "AAAA empty", addr: AAAARecord{}.Addr, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.addr()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Addr() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil && !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("Addr() error = %v, want %v", err, ErrInvalidAddress)
+			}
+
+			if got != tt.want {
+				t.Errorf("Addr() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDNSRecordsIPs tests collecting addresses of all A and AAAA records.
+func TestDNSRecordsIPs(t *testing.T) {
+	const records = `[
+{"type":1,"dnsType":"A","name":"example.com.","address":"1.1.1.1"},
+{"type":28,"dnsType":"AAAA","name":"example.com.","address":"2606:4700::1"},
+{"type":15,"dnsType":"MX","name":"example.com.","target":"mx.example.com.","priority":10},
+{"type":1,"dnsType":"A","name":"example.com.","address":"1.1.1"},
+{"type":1,"dnsType":"A","name":"www.example.com.","address":"2.2.2.2"}
+]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	ips, err := r.IPs()
+	if !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("IPs() error = %v, want %v", err, ErrInvalidAddress)
+	}
+
+	want := []netip.Addr{
+		netip.MustParseAddr("1.1.1.1"),
+		netip.MustParseAddr("2606:4700::1"),
+		netip.MustParseAddr("2.2.2.2"),
+	}
+
+	if len(ips) != len(want) {
+		t.Fatalf("IPs() = %v, want %v", ips, want)
+	}
+
+	for i := range want {
+		if ips[i] != want[i] {
+			t.Errorf("IPs() = %v, want %v", ips, want)
+		}
+	}
+}
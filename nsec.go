@@ -0,0 +1,317 @@
+package dnslookupapi
+
+import (
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// NSEC3HashSHA1 is the only NSEC3 hash algorithm defined (RFC 5155).
+const NSEC3HashSHA1 = 1
+
+const (
+	// maxNSEC3Iterations is the maximum number of NSEC3 iterations allowed for any key size (RFC 5155).
+	maxNSEC3Iterations = 2500
+
+	// maxNSEC3Salt is the maximum size of the NSEC3 salt.
+	maxNSEC3Salt = 255
+)
+
+// ErrUnsupportedNSEC3Hash is returned when the NSEC3 hash algorithm is not supported.
+var ErrUnsupportedNSEC3Hash = errors.New("unsupported NSEC3 hash algorithm")
+
+// nsec3Encoding is the base32 encoding with extended hex alphabet of NSEC3 owner names.
+var nsec3Encoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// Denial is the kind of the authenticated denial of existence proven by NSEC records.
+type Denial int
+
+const (
+	// DenialNone means the records don't prove the non-existence.
+	DenialNone Denial = iota
+
+	// DenialNoData means the name exists but has no records of the type.
+	DenialNoData
+
+	// DenialNameError means the name doesn't exist and no wildcard could synthesize it.
+	DenialNameError
+)
+
+// String returns the name of the denial.
+func (d Denial) String() string {
+	switch d {
+	case DenialNoData:
+		return "NODATA"
+	case DenialNameError:
+		return "NXDOMAIN"
+	default:
+		return "none"
+	}
+}
+
+// TypeNames returns the names of the types in the type bitmap, see RRTypeName.
+func (r NSECRecord) TypeNames() []string {
+	names := make([]string, 0, len(r.Types))
+	for _, code := range r.Types {
+		names = append(names, RRTypeName(code))
+	}
+
+	return names
+}
+
+// HasType reports whether the type bitmap has the DNS type, given as a name, e.g. "MX", or "TYPEnnn".
+func (r NSECRecord) HasType(dnsType string) bool {
+	code, ok := RRTypeCode(dnsType)
+	if !ok {
+		return false
+	}
+
+	for _, t := range r.Types {
+		if t == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bitmap returns the wire format of the type bitmap (RFC 4034 section 4.1.2).
+func (r NSECRecord) Bitmap() []byte {
+	return EncodeTypeBitmap(r.Types)
+}
+
+// Covers reports whether the name falls strictly between the owner name and the next name
+// in the canonical order, i.e. the record proves the name doesn't exist.
+// The last record of the chain, whose next name is the zone apex, covers all names after its owner.
+func (r NSECRecord) Covers(name string) bool {
+	afterOwner := CompareNames(r.Name, name) < 0
+
+	if CompareNames(r.Name, r.Next) >= 0 {
+		// the last record of the chain wraps around to the apex
+		return afterOwner && IsSubdomain(name, r.Next)
+	}
+
+	return afterOwner && CompareNames(name, r.Next) < 0
+}
+
+// EncodeTypeBitmap encodes the type codes as the type bitmap of NSEC and NSEC3 records.
+func EncodeTypeBitmap(types []int) []byte {
+	codes := append([]int(nil), types...)
+	sort.Ints(codes)
+
+	var (
+		wire   []byte
+		window = -1
+		bitmap []byte
+	)
+
+	flush := func() {
+		if window >= 0 {
+			wire = append(wire, byte(window), byte(len(bitmap)))
+			wire = append(wire, bitmap...)
+		}
+	}
+
+	for _, code := range codes {
+		if code < 0 || code > 0xffff {
+			continue
+		}
+
+		if code>>8 != window {
+			flush()
+
+			window, bitmap = code>>8, nil
+		}
+
+		octet := (code & 0xff) / 8
+		for len(bitmap) <= octet {
+			bitmap = append(bitmap, 0)
+		}
+
+		bitmap[octet] |= 0x80 >> (code % 8)
+	}
+
+	flush()
+
+	return wire
+}
+
+// DecodeTypeBitmap decodes the type bitmap of NSEC and NSEC3 records into type codes in ascending order.
+func DecodeTypeBitmap(wire []byte) ([]int, error) {
+	var types []int
+
+	last := -1
+
+	for len(wire) != 0 {
+		if len(wire) < 2 {
+			return nil, errors.New("truncated type bitmap")
+		}
+
+		window, length := int(wire[0]), int(wire[1])
+		if window <= last || length == 0 || length > 32 || len(wire) < 2+length {
+			return nil, errors.New("invalid type bitmap window")
+		}
+
+		for i, octet := range wire[2 : 2+length] {
+			for bit := 0; bit < 8; bit++ {
+				if octet&(0x80>>bit) != 0 {
+					types = append(types, window<<8|i*8+bit)
+				}
+			}
+		}
+
+		last = window
+		wire = wire[2+length:]
+	}
+
+	return types, nil
+}
+
+// CompareNames compares the domain names in the canonical DNS order (RFC 4034 section 6.1):
+// label by label from the rightmost one, case-insensitively. It returns -1, 0 or 1.
+func CompareNames(a, b string) int {
+	al, bl := nameLabels(a), nameLabels(b)
+
+	for i := 1; i <= len(al) && i <= len(bl); i++ {
+		if c := strings.Compare(al[len(al)-i], bl[len(bl)-i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(al) < len(bl):
+		return -1
+	case len(al) > len(bl):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// nameLabels returns the lower-cased labels of the domain name.
+func nameLabels(name string) []string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "" {
+		return nil
+	}
+
+	return strings.Split(name, ".")
+}
+
+// NSECDenial returns the kind of the denial of existence of the DNS type at the name proven by the NSEC
+// records. A name error requires the records covering both the name and the wildcard at its closest encloser.
+// The signatures of the records are not verified.
+func (r *DNSRecords) NSECDenial(name, dnsType string) Denial {
+	for _, nsec := range r.NSEC {
+		if EqualNames(nsec.Name, name) {
+			if !nsec.HasType(dnsType) && !nsec.HasType("CNAME") {
+				return DenialNoData
+			}
+
+			return DenialNone
+		}
+	}
+
+	for _, nsec := range r.NSEC {
+		if !nsec.Covers(name) {
+			continue
+		}
+
+		wildcard := "*." + closestEncloser(name, nsec.Name, nsec.Next)
+
+		for _, w := range r.NSEC {
+			if w.Covers(wildcard) || EqualNames(w.Name, wildcard) && !w.HasType(dnsType) && !w.HasType("CNAME") {
+				return DenialNameError
+			}
+		}
+	}
+
+	return DenialNone
+}
+
+// closestEncloser returns the longest ancestor of the name shared with the owner or the next name of
+// the covering NSEC record.
+func closestEncloser(name, owner, next string) string {
+	labels := nameLabels(name)
+
+	common := func(other string) int {
+		ol := nameLabels(other)
+
+		n := 0
+		for n < len(labels) && n < len(ol) && labels[len(labels)-1-n] == ol[len(ol)-1-n] {
+			n++
+		}
+
+		return n
+	}
+
+	n := common(owner)
+	if m := common(next); m > n {
+		n = m
+	}
+
+	return strings.Join(labels[len(labels)-n:], ".")
+}
+
+// SaltBytes returns the decoded salt. The salt "-" is empty. Errors are returned as *FieldError.
+func (r NSEC3PARAMRecord) SaltBytes() ([]byte, error) {
+	salt := strings.Join(r.Salt, "")
+	if salt == "-" {
+		return nil, nil
+	}
+
+	if len(salt) > 2*maxNSEC3Salt {
+		return nil, &FieldError{DNSType: "NSEC3PARAM", Field: "salt", Err: ErrFieldTooLarge}
+	}
+
+	decoded, err := hex.DecodeString(salt)
+	if err != nil {
+		return nil, &FieldError{DNSType: "NSEC3PARAM", Field: "salt", Err: err}
+	}
+
+	return decoded, nil
+}
+
+// Hash returns the NSEC3 hashed owner label of the name with the parameters of the record.
+func (r NSEC3PARAMRecord) Hash(name string) (string, error) {
+	salt, err := r.SaltBytes()
+	if err != nil {
+		return "", err
+	}
+
+	return NSEC3Hash(name, r.HashAlgorithm, r.Iterations, salt)
+}
+
+// NSEC3Hash returns the NSEC3 hashed owner label of the name (RFC 5155 section 5), encoded as upper-case
+// base32 with extended hex alphabet, e.g. "CK0POJMG874LJREF7EFN8430QVIT8BSM" for "com".
+func NSEC3Hash(name string, hashAlgorithm, iterations int, salt []byte) (string, error) {
+	if hashAlgorithm != NSEC3HashSHA1 {
+		return "", ErrUnsupportedNSEC3Hash
+	}
+
+	if iterations < 0 || iterations > maxNSEC3Iterations || len(salt) > maxNSEC3Salt {
+		return "", ErrFieldTooLarge
+	}
+
+	wire, err := canonicalName(name)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha1.New()
+	h.Write(wire)
+	h.Write(salt)
+	digest := h.Sum(nil)
+
+	for i := 0; i < iterations; i++ {
+		h.Reset()
+		h.Write(digest)
+		h.Write(salt)
+		digest = h.Sum(digest[:0])
+	}
+
+	return nsec3Encoding.EncodeToString(digest), nil
+}
@@ -0,0 +1,135 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestTypeBitmap tests encoding and decoding of the type bitmap.
+func TestTypeBitmap(t *testing.T) {
+	// the bitmap of "A MX RRSIG NSEC TYPE1234" from RFC 4034 section 4.3 extended with TYPE1234
+	types := []int{1, 15, 46, 47, 1234}
+	wire := []byte{
+		0x00, 0x06, 0x40, 0x01, 0x00, 0x00, 0x00, 0x03,
+		0x04, 0x1b, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x20,
+	}
+
+	if got := (NSECRecord{Types: []int{1234, 47, 1, 46, 15}}).Bitmap(); !reflect.DeepEqual(got, wire) {
+		t.Errorf("Bitmap() = % x, want % x", got, wire)
+	}
+
+	got, err := DecodeTypeBitmap(wire)
+	if err != nil || !reflect.DeepEqual(got, types) {
+		t.Errorf("DecodeTypeBitmap() = %v, %v, want %v", got, err, types)
+	}
+
+	for _, invalid := range [][]byte{{0x00}, {0x00, 0x00}, {0x00, 0x02, 0x40}, {0x01, 0x01, 0x40, 0x00, 0x01, 0x40}} {
+		if _, err := DecodeTypeBitmap(invalid); err == nil {
+			t.Errorf("DecodeTypeBitmap(% x) error = nil", invalid)
+		}
+	}
+
+	nsec := NSECRecord{Types: types}
+	if names := nsec.TypeNames(); !reflect.DeepEqual(names, []string{"A", "MX", "RRSIG", "NSEC", "TYPE1234"}) {
+		t.Errorf("TypeNames() = %v", names)
+	}
+
+	if !nsec.HasType("mx") || !nsec.HasType("TYPE1234") || nsec.HasType("AAAA") || nsec.HasType("BOGUS") {
+		t.Error("HasType() mismatch")
+	}
+}
+
+// TestCompareNames tests the canonical order of names from RFC 4034 section 6.1.
+func TestCompareNames(t *testing.T) {
+	ordered := []string{
+		"example.", "a.example.", "yljkjljk.a.example.", "Z.a.example.", "zABC.a.EXAMPLE.",
+		"z.example.", "\001.z.example.", "*.z.example.", "\200.z.example.",
+	}
+
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			if i < j {
+				want = -1
+			} else if i > j {
+				want = 1
+			}
+
+			if got := CompareNames(ordered[i], ordered[j]); got != want {
+				t.Errorf("CompareNames(%q, %q) = %d, want %d", ordered[i], ordered[j], got, want)
+			}
+		}
+	}
+}
+
+// TestNSECDenial tests the denial of existence proven by NSEC records.
+func TestNSECDenial(t *testing.T) {
+	records := &DNSRecords{NSEC: []NSECRecord{
+		{commonFields: commonFields{Name: "example.com."}, Next: "a.example.com.", Types: []int{1, 2, 6, 46, 47}},
+		{commonFields: commonFields{Name: "a.example.com."}, Next: "mail.example.com.", Types: []int{1, 46, 47}},
+		{commonFields: commonFields{Name: "mail.example.com."}, Next: "example.com.", Types: []int{5, 46, 47}},
+	}}
+
+	tests := []struct {
+		name, dnsType string
+		want          Denial
+	}{
+		{"example.com.", "MX", DenialNoData},
+		{"example.com", "A", DenialNone},
+		{"A.example.com.", "AAAA", DenialNoData},
+		{"mail.example.com.", "MX", DenialNone},
+		{"b.example.com.", "A", DenialNameError},
+		{"zzz.example.com.", "A", DenialNameError},
+		{"other.org.", "A", DenialNone},
+	}
+
+	for _, tt := range tests {
+		if got := records.NSECDenial(tt.name, tt.dnsType); got != tt.want {
+			t.Errorf("NSECDenial(%q, %q) = %v, want %v", tt.name, tt.dnsType, got, tt.want)
+		}
+	}
+
+	wildcard := &DNSRecords{NSEC: []NSECRecord{
+		{commonFields: commonFields{Name: "*.example.com."}, Next: "example.com.", Types: []int{1, 46, 47}},
+		{commonFields: commonFields{Name: "a.example.com."}, Next: "*.example.com.", Types: []int{1}},
+	}}
+
+	if got := wildcard.NSECDenial("b.example.com.", "A"); got != DenialNone {
+		t.Errorf("NSECDenial() with wildcard = %v, want none", got)
+	}
+}
+
+// TestNSEC3Hash tests NSEC3 hashing with the examples of RFC 5155 appendix A.
+func TestNSEC3Hash(t *testing.T) {
+	param := NSEC3PARAMRecord{HashAlgorithm: NSEC3HashSHA1, Iterations: 12, Salt: []string{"aabbccdd"}}
+
+	for name, want := range map[string]string{
+		"example":      "0P9MHAVEQVM6T7VBL5LOP2U3T2RP3TOM",
+		"a.example":    "35MTHGPGCU1QG68FAB165KLNSNK3DPVL",
+		"ns1.example.": "2T7B4G4VSA5SMI47K61MV5BV1A22BOJR",
+		"xx.EXAMPLE.":  "T644EBQK9BIBCNA874GIVR6JOJ62MLHV",
+		"*.w.example":  "R53BQ7CC2UVMUBFU5OCMM6PERS9TK9EN",
+	} {
+		if got, err := param.Hash(name); err != nil || got != want {
+			t.Errorf("Hash(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+
+	if got, err := NSEC3Hash("com", NSEC3HashSHA1, 0, nil); err != nil || got != "CK0POJMG874LJREF7EFN8430QVIT8BSM" {
+		t.Errorf("NSEC3Hash(com) = %v, %v", got, err)
+	}
+
+	if salt, err := (NSEC3PARAMRecord{Salt: []string{"-"}}).SaltBytes(); err != nil || len(salt) != 0 {
+		t.Errorf("SaltBytes(-) = %v, %v", salt, err)
+	}
+
+	if _, err := NSEC3Hash("example", 2, 0, nil); !errors.Is(err, ErrUnsupportedNSEC3Hash) {
+		t.Errorf("NSEC3Hash() error = %v", err)
+	}
+
+	if _, err := NSEC3Hash("example", NSEC3HashSHA1, maxNSEC3Iterations+1, nil); !errors.Is(err, ErrFieldTooLarge) {
+		t.Errorf("NSEC3Hash() error = %v", err)
+	}
+}
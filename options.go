@@ -32,6 +32,7 @@ func OptionType(value string) Option {
 
 // OptionCallback sets a javascript function used when outputFormat is JSON;
 // this is an implementation known as JSONP which invokes the callback on the returned response.
+// Get strips the callback wrapper before parsing; use ParseJSONP to parse bodies returned by GetRaw.
 func OptionCallback(value string) Option {
 	return func(v url.Values) {
 		v.Set("callback", value)
@@ -0,0 +1,48 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// baseURLKey is the context key of the base URL override.
+type baseURLKey struct{}
+
+// httpClientKey is the context key of the HTTP client override.
+type httpClientKey struct{}
+
+// WithBaseURL returns the context making requests go to the base URL instead of the endpoint the client
+// was created with. Mirrors are not used for such requests.
+func WithBaseURL(ctx context.Context, baseURL *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLKey{}, baseURL)
+}
+
+// WithHTTPClient returns the context making requests go through the HTTP client instead of the one
+// the client was created with, e.g. to route some lookups through a different proxy.
+// The redirect policy of the client is still applied.
+func WithHTTPClient(ctx context.Context, httpClient *http.Client) context.Context {
+	return context.WithValue(ctx, httpClientKey{}, httpClient)
+}
+
+// baseURLFromContext returns the base URL override or nil.
+func baseURLFromContext(ctx context.Context) *url.URL {
+	u, _ := ctx.Value(baseURLKey{}).(*url.URL)
+	return u
+}
+
+// httpClient returns the HTTP client for the request context.
+func (c *Client) httpClient(ctx context.Context) *http.Client {
+	override, ok := ctx.Value(httpClientKey{}).(*http.Client)
+	if !ok || override == nil {
+		return c.client
+	}
+
+	httpClient := *override
+
+	policy := c.redirects
+	policy.next = httpClient.CheckRedirect
+	httpClient.CheckRedirect = policy.checkRedirect
+
+	return &httpClient
+}
@@ -0,0 +1,75 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// recordingTransport is the http.RoundTripper counting requests for testing.
+type recordingTransport struct {
+	requests int
+}
+
+// RoundTrip counts the request and sends it with the default transport.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestRequestOverrides tests overriding the base URL and the HTTP client per request.
+func TestRequestOverrides(t *testing.T) {
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/redirect" {
+				http.Redirect(w, req, "/DNSService", http.StatusFound)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"DNSData":{"domainName":"` + name + `","dnsRecords":[]}}`))
+		}))
+	}
+
+	primary := newServer("primary")
+	defer primary.Close()
+
+	other := newServer("other")
+	defer other.Close()
+
+	primaryURL, _ := url.Parse(primary.URL)
+	otherURL, _ := url.Parse(other.URL + "/redirect")
+
+	client := NewClient(apiKey, ClientParams{DNSLookupBaseURL: primaryURL, MaxRedirects: -1})
+	transport := &recordingTransport{}
+
+	ctx := WithHTTPClient(WithBaseURL(context.Background(), otherURL), &http.Client{Transport: transport})
+
+	resp, err := client.GetRaw(ctx, "whoisxmlapi.com")
+	if resp == nil || resp.StatusCode != http.StatusFound {
+		t.Fatalf("GetRaw() = %v, %v, want the redirect not followed", resp, err)
+	}
+
+	if transport.requests != 1 {
+		t.Errorf("override transport requests = %d, want 1", transport.requests)
+	}
+
+	otherURL.Path = "/DNSService"
+
+	got, _, err := client.Get(WithBaseURL(context.Background(), otherURL), "whoisxmlapi.com")
+	if err != nil || got.DomainName != "other" {
+		t.Errorf("Get() with base URL override = %v, %v", got, err)
+	}
+
+	_, err = client.GetStream(WithBaseURL(context.Background(), otherURL), "whoisxmlapi.com",
+		func(DNSRecord) error { return nil })
+	if err != nil {
+		t.Errorf("GetStream() with base URL override error = %v", err)
+	}
+
+	got, _, err = client.Get(context.Background(), "whoisxmlapi.com")
+	if err != nil || got.DomainName != "primary" {
+		t.Errorf("Get() without override = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,147 @@
+package dnslookupapi
+
+import (
+	"sort"
+	"strings"
+)
+
+// RecordScope is the position of the record owner name relative to the queried domain.
+type RecordScope int
+
+const (
+	// ScopeExternal is the owner name outside the queried domain, e.g. the target of a CNAME chain.
+	ScopeExternal RecordScope = iota
+
+	// ScopeApex is the owner name equal to the queried domain.
+	ScopeApex
+
+	// ScopeSubdomain is the owner name below the queried domain.
+	ScopeSubdomain
+)
+
+// String returns the name of the scope.
+func (s RecordScope) String() string {
+	switch s {
+	case ScopeApex:
+		return "apex"
+	case ScopeSubdomain:
+		return "subdomain"
+	default:
+		return "external"
+	}
+}
+
+// NormalizeName returns the domain name in the canonical form used for comparison: lower case,
+// Unicode labels encoded as punycode and without the trailing dot. The root name is returned as ".".
+func NormalizeName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "." {
+		return name
+	}
+
+	if !isASCII(name) {
+		if encoded, err := ToASCII(name); err == nil {
+			name = encoded
+		}
+	}
+
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// FQDN returns the normalized domain name with the trailing dot.
+func FQDN(name string) string {
+	if name = NormalizeName(name); name == "." || name == "" {
+		return "."
+	}
+
+	return name + "."
+}
+
+// IsFQDN reports whether the domain name ends with the dot.
+func IsFQDN(name string) bool {
+	return strings.HasSuffix(name, ".")
+}
+
+// EqualNames reports whether the domain names are equal ignoring case and the trailing dot.
+func EqualNames(a, b string) bool {
+	return NormalizeName(a) == NormalizeName(b)
+}
+
+// ScopeOf returns the scope of the owner name relative to the apex domain name.
+func ScopeOf(name, apex string) RecordScope {
+	name, apex = NormalizeName(name), NormalizeName(apex)
+
+	switch {
+	case name == apex:
+		return ScopeApex
+	case apex == "." || strings.HasSuffix(name, "."+apex):
+		return ScopeSubdomain
+	default:
+		return ScopeExternal
+	}
+}
+
+// IsSubdomain reports whether the domain name is equal to or below the apex domain name.
+func IsSubdomain(name, apex string) bool {
+	return ScopeOf(name, apex) != ScopeExternal
+}
+
+// RelativeName returns the labels of the domain name below the apex, e.g. "www" for "www.example.com."
+// and "example.com". It returns "@" for the apex itself and false if the name is outside the apex.
+func RelativeName(name, apex string) (string, bool) {
+	switch ScopeOf(name, apex) {
+	case ScopeApex:
+		return "@", true
+	case ScopeSubdomain:
+		name, apex = NormalizeName(name), NormalizeName(apex)
+		if apex == "." {
+			return name, true
+		}
+
+		return strings.TrimSuffix(name, "."+apex), true
+	default:
+		return "", false
+	}
+}
+
+// Owner returns the normalized owner name of the record, see NormalizeName.
+func (c commonFields) Owner() string {
+	return NormalizeName(c.Name)
+}
+
+// Scope returns the scope of the record owner name relative to the requested domain name.
+func (r *DNSLookupResponse) Scope(record Record) RecordScope {
+	apex := r.ASCIIDomainName
+	if apex == "" {
+		apex = r.DomainName
+	}
+
+	return ScopeOf(record.GetName(), apex)
+}
+
+// GroupByOwner returns the records grouped by the normalized owner name.
+// The records of a group keep the order of All.
+func (r *DNSRecords) GroupByOwner() map[string][]DNSRecord {
+	groups := make(map[string][]DNSRecord)
+
+	for _, record := range r.All {
+		owner := record.CommonFields.Owner()
+		groups[owner] = append(groups[owner], record)
+	}
+
+	return groups
+}
+
+// Owners returns the distinct normalized owner names of the records in ascending order.
+func (r *DNSRecords) Owners() []string {
+	groups := r.GroupByOwner()
+
+	owners := make([]string, 0, len(groups))
+	for owner := range groups {
+		owners = append(owners, owner)
+	}
+
+	sort.Strings(owners)
+
+	return owners
+}
@@ -0,0 +1,84 @@
+package dnslookupapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNormalizeName tests normalization of domain names.
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name, normalized, fqdn string
+	}{
+		{"Example.COM.", "example.com", "example.com."},
+		{"example.com", "example.com", "example.com."},
+		{" WWW.Example.com ", "www.example.com", "www.example.com."},
+		{"BÜCHER.example.", "xn--bcher-kva.example", "xn--bcher-kva.example."},
+		{".", ".", "."},
+		{"", "", "."},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeName(tt.name); got != tt.normalized {
+			t.Errorf("NormalizeName(%q) = %q, want %q", tt.name, got, tt.normalized)
+		}
+
+		if got := FQDN(tt.name); got != tt.fqdn {
+			t.Errorf("FQDN(%q) = %q, want %q", tt.name, got, tt.fqdn)
+		}
+	}
+
+	if !EqualNames("Example.com.", "example.COM") || EqualNames("example.com", "example.org") {
+		t.Error("EqualNames() mismatch")
+	}
+}
+
+// TestScopeOf tests the scope of owner names.
+func TestScopeOf(t *testing.T) {
+	tests := []struct {
+		name, apex string
+		scope      RecordScope
+		relative   string
+	}{
+		{"example.com.", "Example.com", ScopeApex, "@"},
+		{"www.example.com.", "example.com", ScopeSubdomain, "www"},
+		{"a.b.Example.com", "example.com.", ScopeSubdomain, "a.b"},
+		{"badexample.com.", "example.com", ScopeExternal, ""},
+		{"cdn.example.net.", "example.com", ScopeExternal, ""},
+		{"example.com.", ".", ScopeSubdomain, "example.com"},
+	}
+
+	for _, tt := range tests {
+		if got := ScopeOf(tt.name, tt.apex); got != tt.scope {
+			t.Errorf("ScopeOf(%q, %q) = %v, want %v", tt.name, tt.apex, got, tt.scope)
+		}
+
+		relative, ok := RelativeName(tt.name, tt.apex)
+		if relative != tt.relative || ok != (tt.scope != ScopeExternal) {
+			t.Errorf("RelativeName(%q, %q) = %q, %v", tt.name, tt.apex, relative, ok)
+		}
+	}
+}
+
+// TestGroupByOwner tests grouping of records by owner name.
+func TestGroupByOwner(t *testing.T) {
+	records := DNSRecords{All: []DNSRecord{
+		{CommonFields: commonFields{Name: "example.com.", DNSType: "A"}},
+		{CommonFields: commonFields{Name: "WWW.example.com.", DNSType: "CNAME"}},
+		{CommonFields: commonFields{Name: "Example.com", DNSType: "MX"}},
+	}}
+
+	groups := records.GroupByOwner()
+	if len(groups) != 2 || len(groups["example.com"]) != 2 || groups["example.com"][1].CommonFields.DNSType != "MX" {
+		t.Errorf("GroupByOwner() = %+v", groups)
+	}
+
+	if owners := records.Owners(); !reflect.DeepEqual(owners, []string{"example.com", "www.example.com"}) {
+		t.Errorf("Owners() = %v", owners)
+	}
+
+	resp := &DNSLookupResponse{DomainName: "example.com"}
+	if scope := resp.Scope(records.All[1].CommonFields); scope != ScopeSubdomain {
+		t.Errorf("Scope() = %v, want subdomain", scope)
+	}
+}
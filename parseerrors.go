@@ -0,0 +1,163 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// parseErrorSnippetLen is the maximum length of ParseError.Snippet.
+const parseErrorSnippetLen = 64
+
+// parseErrorCauses are the known causes of ParseError by their codes.
+var parseErrorCauses = map[string]error{
+	"unsupported-dns-type": ErrUnsupportedDNSType,
+}
+
+// ParseError is the error that occurred during parsing of a DNS record.
+// Unlike the cause, it is kept when the record is encoded to JSON and decoded back.
+type ParseError struct {
+	// Message is the error message.
+	Message string `json:"message"`
+
+	// Field is the offending field of the record, if it's known.
+	Field string `json:"field,omitempty"`
+
+	// Snippet is the part of the raw record around the error.
+	Snippet string `json:"snippet,omitempty"`
+
+	// Code identifies the known cause of the error, e.g. "unsupported-dns-type" for ErrUnsupportedDNSType,
+	// so it's still matched by errors.Is after JSON decoding.
+	Code string `json:"code,omitempty"`
+
+	// err is the cause of the error. Only the known causes are restored after JSON decoding.
+	err error
+}
+
+// newParseError returns ParseError of the record caused by err.
+func newParseError(record json.RawMessage, err error) *ParseError {
+	e := &ParseError{Message: err.Error(), err: err}
+
+	for code, cause := range parseErrorCauses {
+		if errors.Is(err, cause) {
+			e.Code = code
+		}
+	}
+
+	offset := int64(-1)
+
+	var (
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+	)
+
+	switch {
+	case errors.As(err, &typeErr):
+		e.Field = typeErr.Field
+		offset = typeErr.Offset
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	}
+
+	e.Snippet = snippet(record, offset)
+
+	return e
+}
+
+// snippet returns the part of the record ending at the offset, or the beginning of the record if the offset is unknown.
+func snippet(record []byte, offset int64) string {
+	if offset < 0 || offset > int64(len(record)) {
+		offset = 0
+	}
+
+	start := int(offset) - parseErrorSnippetLen/2
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + parseErrorSnippetLen
+	if end > len(record) {
+		end = len(record)
+	}
+
+	return string(record[start:end])
+}
+
+// Error returns error message as a string.
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the cause of the error. If the error was decoded from JSON, it's the known cause
+// identified by Code or nil.
+func (e *ParseError) Unwrap() error {
+	return e.err
+}
+
+// UnmarshalJSON decodes the error restoring its known cause.
+func (e *ParseError) UnmarshalJSON(data []byte) error {
+	type parseError ParseError
+
+	var decoded parseError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*e = ParseError(decoded)
+	e.err = parseErrorCauses[e.Code]
+
+	return nil
+}
+
+// RecordParseError is the error that occurred during parsing of a single DNS record.
+type RecordParseError struct {
+	// Index is the index of the record in DNSRecords.All.
+	Index int
+
+	// DNSType is the DNS record type.
+	DNSType string
+
+	// Err is the cause of the error.
+	Err error
+}
+
+// Error returns error message as a string.
+func (e *RecordParseError) Error() string {
+	return "record " + strconv.Itoa(e.Index) + " (" + e.DNSType + "): " + e.Err.Error()
+}
+
+// Unwrap returns the cause of the error.
+func (e *RecordParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is the list of record-level parse errors. It is returned by Get in the strict parsing mode.
+type ParseErrors []*RecordParseError
+
+// Error returns error message as a string.
+func (e ParseErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return "cannot parse " + strconv.Itoa(len(e)) + " record(s): " + strings.Join(msgs, "; ")
+}
+
+// ParseErrors returns errors of all records which failed to parse.
+func (r *DNSRecords) ParseErrors() ParseErrors {
+	var errs ParseErrors
+
+	for i, record := range r.All {
+		if record.ParseError != nil {
+			errs = append(errs, &RecordParseError{
+				Index:   i,
+				DNSType: record.CommonFields.DNSType,
+				Err:     record.ParseError,
+			})
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,99 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+)
+
+// TestParseErrors tests the strict parsing mode and the ParseErrors function.
+func TestParseErrors(t *testing.T) {
+	const resp = `{"DNSData":{"dnsRecords":[
+{"type":1,"dnsType":"A","address":"1.1.1.1"},
+{"type":65535,"dnsType":"UNKNOWN"},
+{"type":15,"dnsType":"MX","priority":"high"}
+]}}`
+
+	server := dummyServer(resp, resp, resp)
+	defer server.Close()
+
+	apiURL, err := url.Parse(server.URL + pathDNSLookupResponseOK)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lenient := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: apiURL})
+
+	got, _, err := lenient.Get(context.Background(), "whoisxmlapi.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const wantErr = "cannot parse 2 record(s): record 1 (UNKNOWN): unknown DNS type; " +
+		"record 2 (MX): json: cannot unmarshal string into Go struct field MXRecord.priority of type int"
+
+	errs := got.DNSRecords.ParseErrors()
+	checkErr(t, errs, wantErr)
+
+	if !errors.Is(errs[0], ErrUnsupportedDNSType) || errs[1].Index != 2 || errs[1].DNSType != "MX" {
+		t.Errorf("ParseErrors() = %v", errs)
+	}
+
+	strict := NewClient(apiKey, ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: apiURL,
+		StrictParsing:    true,
+	})
+
+	got, _, err = strict.Get(context.Background(), "whoisxmlapi.com")
+	checkErr(t, err, wantErr)
+
+	var parseErrs ParseErrors
+	if !errors.As(err, &parseErrs) || len(parseErrs) != 2 || got != nil {
+		t.Errorf("Get() = %v, %v", got, err)
+	}
+}
+
+// TestParseErrorJSON tests that the parse errors are kept after the records are encoded to JSON and decoded back.
+func TestParseErrorJSON(t *testing.T) {
+	var records DNSRecords
+
+	err := json.Unmarshal([]byte(`[{"type":1,"dnsType":"A","name":"example.com.","ttl":"300"},`+
+		`{"type":65534,"dnsType":"FUTURE","name":"example.com.","ttl":300}]`), &records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(records.All)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []DNSRecord
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded) != 2 || decoded[0].ParseError == nil || decoded[1].ParseError == nil {
+		t.Fatalf("decoded = %+v", decoded)
+	}
+
+	if got, want := *decoded[0].ParseError, *records.All[0].ParseError; got.Field != "ttl" ||
+		got.Error() != want.Error() || got.Snippet == "" || got.Snippet != want.Snippet {
+		t.Errorf("ParseError = %+v, want %+v", got, want)
+	}
+
+	if !errors.Is(decoded[1].ParseError, ErrUnsupportedDNSType) {
+		t.Errorf("ParseError = %v, want ErrUnsupportedDNSType", decoded[1].ParseError)
+	}
+
+	if errors.Is(decoded[0].ParseError, records.All[0].ParseError) {
+		t.Errorf("decoded ParseError %v matches the original one by the message", decoded[0].ParseError)
+	}
+
+	if errors.Is(&ParseError{Message: ErrUnsupportedDNSType.Error()}, ErrUnsupportedDNSType) {
+		t.Error("ParseError without the cause matches ErrUnsupportedDNSType by the message")
+	}
+}
@@ -0,0 +1,204 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownFormat is returned by ParseResponse if the body is neither JSON nor XML.
+var ErrUnknownFormat = errors.New("unknown response format")
+
+// ParseResponse parses the response body stored from GetRaw, e.g. to re-parse archived bodies with a newer
+// version of the library. It accepts the DNSData envelope in the JSON and XML output formats and the bare
+// JSON array of records. The API error message is returned as ErrorMessage.
+func ParseResponse(body []byte) (*DNSLookupResponse, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, ErrUnknownFormat
+	}
+
+	switch trimmed[0] {
+	case '[':
+		response := &DNSLookupResponse{}
+		if err := json.Unmarshal(trimmed, &response.DNSRecords); err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	case '<':
+		var err error
+		if trimmed, err = xmlToJSON(trimmed); err != nil {
+			return nil, err
+		}
+	case '{':
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	response, err := parse(trimmed)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Message != "" || response.Code != "" {
+		return nil, &response.ErrorMessage
+	}
+
+	return &response.DNSLookupResponse, nil
+}
+
+// xmlNode is the generic XML element.
+type xmlNode struct {
+	XMLName xml.Name
+	Content string    `xml:",chardata"`
+	Nodes   []xmlNode `xml:",any"`
+}
+
+// xmlToJSON converts the XML response to the JSON output format.
+func xmlToJSON(body []byte) ([]byte, error) {
+	var root xmlNode
+	if err := xml.Unmarshal(body, &root); err != nil {
+		return nil, err
+	}
+
+	switch root.XMLName.Local {
+	case "ErrorMessage":
+		return json.Marshal(map[string]interface{}{"ErrorMessage": root.object(nil)})
+	case "DNSData":
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	data := root.object(map[string]reflect.Type{
+		"types":      reflect.TypeOf([]int(nil)),
+		"dnsRecords": reflect.TypeOf([]json.RawMessage(nil)),
+	})
+
+	records := make([]interface{}, 0)
+
+	for _, node := range root.Nodes {
+		if node.XMLName.Local != "dnsRecords" || len(node.Nodes) == 0 {
+			continue
+		}
+
+		// the records are either wrapped into the list element or the list element is repeated
+		if node.Nodes[0].Nodes == nil {
+			records = append(records, node.record())
+			continue
+		}
+
+		for _, record := range node.Nodes {
+			records = append(records, record.record())
+		}
+	}
+
+	data["dnsRecords"] = records
+
+	return json.Marshal(map[string]interface{}{"DNSData": data})
+}
+
+// record converts the record element to the JSON object, using the field kinds of the typed record.
+func (n xmlNode) record() map[string]interface{} {
+	var dnsType string
+
+	for _, node := range n.Nodes {
+		if node.XMLName.Local == "dnsType" {
+			dnsType = strings.TrimSpace(node.Content)
+		}
+	}
+
+	fields := make(map[string]reflect.Type)
+
+	model := actualDNSType(dnsType)
+	if model == nil {
+		model = legacyDNSType(dnsType)
+	}
+
+	if model == nil {
+		model = &commonFields{}
+	}
+
+	fieldTypes(reflect.TypeOf(model).Elem(), fields)
+
+	return n.object(fields)
+}
+
+// object converts the element to the JSON object. The leaves are converted to the types of the fields,
+// the fields without the type are kept as strings.
+func (n xmlNode) object(fields map[string]reflect.Type) map[string]interface{} {
+	object := make(map[string]interface{})
+
+	for _, node := range n.Nodes {
+		name := node.XMLName.Local
+		t := fields[name]
+
+		switch {
+		case t != nil && t.Kind() == reflect.Slice:
+			values, _ := object[name].([]interface{})
+			for _, value := range node.leaves() {
+				values = append(values, leaf(value, t.Elem().Kind()))
+			}
+
+			object[name] = values
+		case len(node.Nodes) != 0:
+			object[name] = node.object(nil)
+		case t != nil:
+			object[name] = leaf(strings.TrimSpace(node.Content), t.Kind())
+		default:
+			object[name] = strings.TrimSpace(node.Content)
+		}
+	}
+
+	return object
+}
+
+// leaves returns the texts of the child elements, or the text of the element if it has no children.
+func (n xmlNode) leaves() []string {
+	if len(n.Nodes) == 0 {
+		return []string{n.Content}
+	}
+
+	values := make([]string, 0, len(n.Nodes))
+	for _, node := range n.Nodes {
+		values = append(values, node.Content)
+	}
+
+	return values
+}
+
+// leaf converts the text to the JSON value of the kind. The text is kept if it doesn't match the kind.
+func leaf(text string, kind reflect.Kind) interface{} {
+	switch kind {
+	case reflect.Int:
+		if n, err := strconv.Atoi(strings.TrimSpace(text)); err == nil {
+			return n
+		}
+	case reflect.Float64:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			return f
+		}
+	}
+
+	return text
+}
+
+// fieldTypes collects the types of the JSON fields of the struct type, including the embedded ones.
+func fieldTypes(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fieldTypes(field.Type, fields)
+			continue
+		}
+
+		if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			fields[name] = field.Type
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestParseResponse tests that the JSON and XML bodies and the bare arrays of records are parsed alike.
+func TestParseResponse(t *testing.T) {
+	const jsonBody = `{"DNSData":{"domainName":"example.com","types":[1,16,47],"dnsTypes":"A,TXT,NSEC",` +
+		`"audit":{"createdDate":"2022-07-12 10:00:00 UTC","updatedDate":"2022-07-12 10:00:00 UTC"},"dnsRecords":[` +
+		`{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"rRsetType":1,"rawText":"","address":"192.0.2.1"},` +
+		`{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"rRsetType":16,"rawText":"",` +
+		`"strings":["v=spf1"," -all"]},` +
+		`{"type":47,"dnsType":"NSEC","name":"example.com.","ttl":300,"rRsetType":47,"rawText":"",` +
+		`"next":"a.example.com.","types":[1,16]}]}}`
+
+	const xmlBody = `<?xml version="1.0" encoding="utf-8"?>
+<DNSData>
+  <domainName>example.com</domainName>
+  <types><type>1</type><type>16</type><type>47</type></types>
+  <dnsTypes>A,TXT,NSEC</dnsTypes>
+  <audit><createdDate>2022-07-12 10:00:00 UTC</createdDate><updatedDate>2022-07-12 10:00:00 UTC</updatedDate></audit>
+  <dnsRecords>
+    <dnsRecord><type>1</type><dnsType>A</dnsType><name>example.com.</name><ttl>300</ttl><rRsetType>1</rRsetType>` +
+		`<rawText></rawText><address>192.0.2.1</address></dnsRecord>
+    <dnsRecord><type>16</type><dnsType>TXT</dnsType><name>example.com.</name><ttl>300</ttl><rRsetType>16</rRsetType>` +
+		`<rawText></rawText><strings><string>v=spf1</string><string> -all</string></strings></dnsRecord>
+    <dnsRecord><type>47</type><dnsType>NSEC</dnsType><name>example.com.</name><ttl>300</ttl><rRsetType>47</rRsetType>` +
+		`<rawText></rawText><next>a.example.com.</next><types>1</types><types>16</types></dnsRecord>
+  </dnsRecords>
+</DNSData>`
+
+	fromJSON, err := ParseResponse([]byte(jsonBody))
+	if err != nil {
+		t.Fatalf("ParseResponse(JSON) error = %v", err)
+	}
+
+	fromXML, err := ParseResponse([]byte(xmlBody))
+	if err != nil {
+		t.Fatalf("ParseResponse(XML) error = %v", err)
+	}
+
+	if len(fromXML.DNSRecords.All) != 3 || fromXML.DNSRecords.ParseErrors() != nil ||
+		!reflect.DeepEqual(fromXML.DNSRecords.NSEC[0].Types, []int{1, 16}) {
+		t.Fatalf("ParseResponse(XML) = %+v", fromXML.DNSRecords)
+	}
+
+	// the raw records differ in the key order only
+	for i := range fromXML.DNSRecords.All {
+		fromXML.DNSRecords.All[i].Raw = fromJSON.DNSRecords.All[i].Raw
+	}
+
+	if !reflect.DeepEqual(fromXML, fromJSON) {
+		t.Errorf("ParseResponse(XML) = %+v, want %+v", fromXML, fromJSON)
+	}
+
+	bare, err := ParseResponse([]byte(`[{"type":1,"dnsType":"A","name":"example.com.","address":"192.0.2.1"}]`))
+	if err != nil || len(bare.DNSRecords.A) != 1 {
+		t.Errorf("ParseResponse(array) = %+v, %v", bare, err)
+	}
+
+	var apiErr *ErrorMessage
+
+	_, err = ParseResponse([]byte(`<ErrorMessage><errorCode>WHOIS_01</errorCode><msg>Bad key</msg></ErrorMessage>`))
+	if !errors.As(err, &apiErr) || apiErr.Code != "WHOIS_01" || apiErr.Message != "Bad key" {
+		t.Errorf("ParseResponse(XML error) error = %v", err)
+	}
+
+	if _, err = ParseResponse([]byte("OK")); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("ParseResponse(text) error = %v, want ErrUnknownFormat", err)
+	}
+}
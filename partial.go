@@ -0,0 +1,129 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxPartialSamples is the maximum number of PartialParseError.Samples.
+const maxPartialSamples = 5
+
+// PartialParseError is returned by Get in the partial parsing mode along with the response
+// if some of the records or response fields failed to parse. The response holds the parsed data,
+// the failed records are kept in DNSRecords.All with ParseError set.
+type PartialParseError struct {
+	// Total is the number of records in the response.
+	Total int
+
+	// Failed is the number of records which failed to parse.
+	Failed int
+
+	// FailedByType is the number of failed records per DNS type.
+	FailedByType map[string]int
+
+	// Samples are the errors of the first failed records, at most 5.
+	Samples ParseErrors
+
+	// Fields are the errors of the response fields other than the records, e.g. "types",
+	// which failed to decode and were left empty.
+	Fields []*ParseError
+}
+
+// newPartialParseError returns PartialParseError of the records and fields, or nil if nothing failed.
+func newPartialParseError(records *DNSRecords, fields []*ParseError) *PartialParseError {
+	errs := records.ParseErrors()
+	if len(errs) == 0 && len(fields) == 0 {
+		return nil
+	}
+
+	e := &PartialParseError{
+		Total:        len(records.All),
+		Failed:       len(errs),
+		FailedByType: make(map[string]int),
+		Fields:       fields,
+	}
+
+	for _, err := range errs {
+		e.FailedByType[err.DNSType]++
+	}
+
+	if len(errs) > maxPartialSamples {
+		errs = errs[:maxPartialSamples]
+	}
+
+	e.Samples = errs
+
+	return e
+}
+
+// Error returns error message as a string.
+func (e *PartialParseError) Error() string {
+	msg := "partially parsed response: " + strconv.Itoa(e.Failed) + " of " + strconv.Itoa(e.Total) +
+		" record(s) failed"
+
+	if len(e.Fields) != 0 {
+		names := make([]string, 0, len(e.Fields))
+		for _, field := range e.Fields {
+			names = append(names, field.Field)
+		}
+
+		msg += ", invalid fields: " + strings.Join(names, ", ")
+	}
+
+	return msg
+}
+
+// Unwrap returns the sample record errors, or the first field error if no records failed.
+func (e *PartialParseError) Unwrap() error {
+	switch {
+	case len(e.Samples) != 0:
+		return e.Samples
+	case len(e.Fields) != 0:
+		return e.Fields[0]
+	}
+
+	return nil
+}
+
+// parseLenient parses the response field by field, so the fields which fail to decode, e.g. a malformed
+// list of types, don't prevent decoding the others. It returns nil if the body has no DNSData object.
+func parseLenient(raw []byte) (*apiResponse, []*ParseError) {
+	var envelope struct {
+		DNSData map[string]json.RawMessage `json:"DNSData"`
+	}
+
+	if err := json.Unmarshal(raw, &envelope); err != nil || envelope.DNSData == nil {
+		return nil, nil
+	}
+
+	var (
+		response apiResponse
+		errs     []*ParseError
+	)
+
+	v := reflect.ValueOf(&response.DNSLookupResponse).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+
+		value, ok := envelope.DNSData[name]
+		if !ok || name == "" || name == "-" {
+			continue
+		}
+
+		if err := json.Unmarshal(value, v.Field(i).Addr().Interface()); err != nil {
+			e := newParseError(value, err)
+			e.Field = strings.TrimSuffix(name+"."+e.Field, ".")
+			errs = append(errs, e)
+		}
+	}
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+
+	return &response, errs
+}
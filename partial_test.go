@@ -0,0 +1,109 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+)
+
+// TestPartialParsing tests that the parsed data is returned along with PartialParseError.
+func TestPartialParsing(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       string
+		wantErr    string
+		wantA      int
+		wantFailed map[string]int
+		wantFields []string
+	}{
+		{
+			name: "records",
+			resp: `{"DNSData":{"domainName":"example.com","dnsRecords":[
+				{"type":1,"dnsType":"A","address":"192.0.2.1"},
+				{"type":15,"dnsType":"MX","priority":"high"},
+				{"type":15,"dnsType":"MX","priority":"low"},
+				{"type":1,"dnsType":"A","address":"192.0.2.2"}]}}`,
+			wantErr:    "partially parsed response: 2 of 4 record(s) failed",
+			wantA:      2,
+			wantFailed: map[string]int{"MX": 2},
+		},
+		{
+			name: "envelope",
+			resp: `{"DNSData":{"domainName":"example.com","types":"1,15","audit":{"createdDate":1},"dnsRecords":[
+				{"type":1,"dnsType":"A","address":"192.0.2.1"}]}}`,
+			wantErr:    "partially parsed response: 0 of 1 record(s) failed, invalid fields: audit, types",
+			wantA:      1,
+			wantFailed: map[string]int{},
+			wantFields: []string{"audit", "types"},
+		},
+		{
+			name:  "clean",
+			resp:  `{"DNSData":{"domainName":"example.com","dnsRecords":[{"type":1,"dnsType":"A","address":"192.0.2.1"}]}}`,
+			wantA: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := dummyServer(tt.resp, tt.resp, tt.resp)
+			defer server.Close()
+
+			apiURL, _ := url.Parse(server.URL + pathDNSLookupResponseOK)
+			client := NewClient(apiKey, ClientParams{
+				HTTPClient:       server.Client(),
+				DNSLookupBaseURL: apiURL,
+				PartialParsing:   true,
+			})
+
+			got, _, err := client.Get(context.Background(), "example.com")
+			checkErr(t, err, tt.wantErr)
+
+			if got == nil || len(got.DNSRecords.A) != tt.wantA || got.DomainName != "example.com" {
+				t.Fatalf("Get() = %+v", got)
+			}
+
+			if tt.wantErr == "" {
+				return
+			}
+
+			var partial *PartialParseError
+			if !errors.As(err, &partial) {
+				t.Fatalf("Get() error = %T, want *PartialParseError", err)
+			}
+
+			if len(partial.FailedByType) != len(tt.wantFailed) || partial.FailedByType["MX"] != tt.wantFailed["MX"] ||
+				len(partial.Samples) != partial.Failed {
+				t.Errorf("PartialParseError = %+v", partial)
+			}
+
+			for i, field := range partial.Fields {
+				if field.Field != tt.wantFields[i] {
+					t.Errorf("Fields[%d] = %v, want %v", i, field.Field, tt.wantFields[i])
+				}
+			}
+		})
+	}
+}
+
+// TestPartialParsingSamples tests that the number of samples is limited.
+func TestPartialParsingSamples(t *testing.T) {
+	var records DNSRecords
+	for i := 0; i < 8; i++ {
+		records.All = append(records.All, records.parseRecord([]byte(`{"type":15,"dnsType":"MX","priority":"x"}`)))
+	}
+
+	e := newPartialParseError(&records, nil)
+	if e.Failed != 8 || len(e.Samples) != maxPartialSamples || e.Samples[4].Index != 4 {
+		t.Errorf("newPartialParseError() = %+v", e)
+	}
+
+	var parseErrs ParseErrors
+	if !errors.As(e, &parseErrs) {
+		t.Error("errors.As(ParseErrors) = false")
+	}
+
+	if newPartialParseError(&DNSRecords{}, nil) != nil {
+		t.Error("newPartialParseError() != nil for the clean records")
+	}
+}
@@ -0,0 +1,146 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxPingBody is the maximum number of bytes of the response read by Ping.
+const maxPingBody = 1 << 16
+
+// PingStatus is the status of the API reported by Ping.
+type PingStatus string
+
+const (
+	// PingOK means that the API is reachable and accepts the API key.
+	PingOK PingStatus = "ok"
+
+	// PingUnauthorized means that the API key is missing or invalid.
+	PingUnauthorized PingStatus = "unauthorized"
+
+	// PingNoCredits means that the API key is valid but the account has run out of credits.
+	PingNoCredits PingStatus = "no_credits"
+
+	// PingThrottled means that the request rate limit is exceeded.
+	PingThrottled PingStatus = "throttled"
+
+	// PingUnavailable means that the API responded with a server error.
+	PingUnavailable PingStatus = "unavailable"
+
+	// PingUnreachable means that the request failed before a response was received.
+	PingUnreachable PingStatus = "unreachable"
+
+	// PingUnexpected means that the response is neither a success nor the expected rejection
+	// of the missing domain name, e.g. 404 because of a wrong base URL or a proxy.
+	PingUnexpected PingStatus = "unexpected"
+)
+
+// PingResult is the result of Ping.
+type PingResult struct {
+	// Status is the status of the API.
+	Status PingStatus
+
+	// Latency is the time elapsed from sending the request to reading the response.
+	Latency time.Duration
+
+	// StatusCode is the HTTP status code, zero if no response was received.
+	StatusCode int
+
+	// RateLimit is the rate-limit and credit metadata parsed from the response headers.
+	RateLimit RateLimit
+
+	// KeyID is the masked API key which was checked.
+	KeyID string
+}
+
+// Ready reports whether the API is ready to serve lookups.
+func (r *PingResult) Ready() bool {
+	return r.Status == PingOK
+}
+
+// Ping checks the connectivity to the API and the validity of the first API key and measures the latency,
+// e.g. for readiness probes. The request is made without a domain name, so no lookup is performed
+// and no credits are spent: the API checks the key before rejecting the missing domain name,
+// and the rejection is reported as PingOK.
+// The result is returned even if the status is not PingOK, along with the error describing the failure.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	service, ok := c.DNSLookupService.(*dnsLookupServiceOp)
+	if !ok {
+		return nil, errors.New("cannot ping: DNSLookupService is not the default implementation")
+	}
+
+	key := c.keys.first()
+
+	req, err := service.newRequest(key)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("outputFormat", "JSON")
+	req.URL.RawQuery = q.Encode()
+
+	if base := baseURLFromContext(ctx); base != nil {
+		req = withBaseURL(ctx, req, base)
+	}
+
+	result := &PingResult{KeyID: maskKey(key)}
+
+	event := LogEvent{Kind: LogRequest, URL: RedactURL(req.URL), KeyID: result.KeyID, Attempt: 1}
+	c.log(ctx, event)
+
+	defer func() {
+		event.Kind, event.StatusCode, event.Duration, event.Err = LogResponse, result.StatusCode, result.Latency, err
+		c.log(ctx, event)
+	}()
+
+	start := time.Now()
+
+	resp, err := c.send(req.WithContext(ctx))
+	if err != nil {
+		result.Status, result.Latency = PingUnreachable, time.Since(start)
+		err = fmt.Errorf("cannot ping: %w", err)
+
+		return result, err
+	}
+
+	defer resp.Body.Close()
+
+	var body []byte
+
+	if decoded, decodeErr := decodeBody(resp); decodeErr == nil {
+		body, _ = io.ReadAll(io.LimitReader(decoded, maxPingBody))
+	}
+
+	result.Latency = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	result.RateLimit = parseRateLimit(resp.Header, time.Now())
+
+	code, message := parseErrorBody(body)
+
+	switch known := classifyError(resp.StatusCode, code, message); {
+	case known == ErrAuthentication:
+		result.Status = PingUnauthorized
+	case known == ErrInsufficientCredits:
+		result.Status = PingNoCredits
+	case known == ErrThrottled:
+		result.Status = PingThrottled
+	case resp.StatusCode >= http.StatusInternalServerError:
+		result.Status = PingUnavailable
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299 || known == ErrInvalidDomain:
+		result.Status = PingOK
+		return result, nil
+	default:
+		result.Status = PingUnexpected
+	}
+
+	if err = checkResponse(resp, body); err == nil {
+		err = &ErrorMessage{Code: code, Message: message}
+	}
+
+	return result, err
+}
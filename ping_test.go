@@ -0,0 +1,120 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestPing tests the status reported by Ping for the API responses.
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       PingStatus
+		wantErr    error
+	}{
+		{
+			name:       "missing domain name",
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"code":422,"messages":"domainName is required"}`,
+			want:       PingOK,
+		},
+		{
+			name:       "ok",
+			statusCode: http.StatusOK,
+			body:       `{"DNSData":{"domainName":"","dnsRecords":[]}}`,
+			want:       PingOK,
+		},
+		{
+			name:       "no credits",
+			statusCode: http.StatusForbidden,
+			body:       `{"code":403,"messages":"Access restricted. Check the credits balance or enter the correct API key."}`,
+			want:       PingNoCredits,
+			wantErr:    ErrInsufficientCredits,
+		},
+		{
+			name:       "invalid key in body",
+			statusCode: http.StatusOK,
+			body:       `{"ErrorMessage":{"errorCode":"WHOIS_01","msg":"ApiKey authenticate failed"}}`,
+			want:       PingUnauthorized,
+			wantErr:    ErrAuthentication,
+		},
+		{
+			name:       "throttled",
+			statusCode: http.StatusTooManyRequests,
+			want:       PingThrottled,
+			wantErr:    ErrThrottled,
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusBadGateway,
+			body:       "<html><title>502 Bad Gateway</title></html>",
+			want:       PingUnavailable,
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+			body:       "404 page not found",
+			want:       PingUnexpected,
+		},
+		{
+			name:       "redirect",
+			statusCode: http.StatusNotModified,
+			want:       PingUnexpected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Query().Get("domainName") != "" || req.URL.Query().Get("apiKey") != apiKey {
+					t.Errorf("Ping() query = %v", req.URL.Query())
+				}
+
+				w.Header().Set("X-Credits-Remaining", "42")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			baseURL, _ := url.Parse(server.URL)
+			client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL})
+
+			result, err := client.Ping(context.Background())
+			if result == nil {
+				t.Fatalf("Ping() result = nil, error = %v", err)
+			}
+
+			if result.Status != tt.want || result.StatusCode != tt.statusCode || result.RateLimit.CreditsRemaining != 42 {
+				t.Errorf("Ping() = %+v, want status %v", result, tt.want)
+			}
+
+			if result.Ready() != (tt.want == PingOK) || (err == nil) != (tt.want == PingOK) {
+				t.Errorf("Ping() ready = %v, error = %v", result.Ready(), err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ping() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestPingUnreachable tests that transport errors are reported as PingUnreachable.
+func TestPingUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	baseURL, _ := url.Parse(server.URL)
+	server.Close()
+
+	client := NewClient(apiKey, ClientParams{DNSLookupBaseURL: baseURL})
+
+	result, err := client.Ping(context.Background())
+	if err == nil || result == nil || result.Status != PingUnreachable || result.StatusCode != 0 {
+		t.Errorf("Ping() = %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,50 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// group is a collection of goroutines working on subtasks of the same task,
+// modeled after golang.org/x/sync/errgroup, which the module doesn't depend on.
+// The first goroutine returning an error cancels the group context.
+type group struct {
+	cancel func()
+
+	wg sync.WaitGroup
+
+	once sync.Once
+	err  error
+}
+
+// newGroup returns the group and the context derived from ctx, canceled when a goroutine of the group
+// returns an error or Wait returns.
+func newGroup(ctx context.Context) (*group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &group{cancel: cancel}, ctx
+}
+
+// Go calls fn in a new goroutine.
+func (g *group) Go(fn func() error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		if err := fn(); err != nil {
+			g.once.Do(func() {
+				g.err = err
+				g.cancel()
+			})
+		}
+	}()
+}
+
+// Wait blocks until all goroutines return and returns the first error.
+func (g *group) Wait() error {
+	g.wg.Wait()
+	g.cancel()
+
+	return g.err
+}
@@ -0,0 +1,173 @@
+// Package pipeline composes domain ingestion, lookup and export into a concurrent pipeline:
+// a Producer stage emits domain names, a pool of lookup workers queries the DNS Lookup API
+// and a Consumer stage receives the results. The stages are connected with bounded channels,
+// so a slow consumer slows the lookups and the producer down instead of buffering results in memory.
+// The first error of any stage cancels the others and is returned by Run.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// defaultWorkers is the number of lookup workers if not specified.
+const defaultWorkers = 4
+
+// Producer sends domain names to out until the input is exhausted or the context is done.
+// It must not close out.
+type Producer func(ctx context.Context, out chan<- string) error
+
+// Consumer receives the result of every lookup. Results are received by one goroutine
+// in the order the lookups finish. Failed lookups are passed as results with Err set.
+type Consumer func(ctx context.Context, result dnslookupapi.LookupResult) error
+
+// Pipeline is the lookup stage configuration.
+type Pipeline struct {
+	// Service makes the lookups.
+	Service dnslookupapi.DNSLookupService
+
+	// Workers is the number of concurrent lookups
+	// If it's zero then it's 4
+	Workers int
+
+	// Buffer is the capacity of the channels between the stages
+	// If it's zero then it's equal to Workers
+	Buffer int
+
+	// Options are applied to every lookup
+	Options []dnslookupapi.Option
+}
+
+// Run runs the producer, the lookup workers and the consumer until the producer returns
+// and all the results are consumed. It returns the first error returned by a stage, or the context error
+// if the context is done before the pipeline finishes.
+func (p Pipeline) Run(ctx context.Context, produce Producer, consume Consumer) error {
+	workers := p.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	buffer := p.Buffer
+	if buffer <= 0 {
+		buffer = workers
+	}
+
+	g, groupCtx := newGroup(ctx)
+
+	names := make(chan string, buffer)
+	results := make(chan dnslookupapi.LookupResult, buffer)
+
+	g.Go(func() error {
+		defer close(names)
+		return produce(groupCtx, names)
+	})
+
+	lookups, _ := newGroup(groupCtx)
+
+	for i := 0; i < workers; i++ {
+		lookups.Go(func() error {
+			return p.lookup(groupCtx, names, results)
+		})
+	}
+
+	g.Go(func() error {
+		defer close(results)
+		return lookups.Wait()
+	})
+
+	g.Go(func() error {
+		for result := range results {
+			if err := consume(groupCtx, result); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+// lookup looks up the domain names received from names and sends the results.
+func (p Pipeline) lookup(ctx context.Context, names <-chan string, results chan<- dnslookupapi.LookupResult) error {
+	for name := range names {
+		result := dnslookupapi.LookupResult{DomainName: name}
+		result.Response, result.Raw, result.Err = p.Service.Get(ctx, name, p.Options...)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case results <- result:
+		}
+	}
+
+	return nil
+}
+
+// FromSlice returns the Producer sending the domain names.
+func FromSlice(domainNames []string) Producer {
+	return func(ctx context.Context, out chan<- string) error {
+		for _, name := range domainNames {
+			if err := send(ctx, out, name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// FromReader returns the Producer sending the domain names read from src.
+func FromReader(src *dnslookupapi.DomainReader) Producer {
+	return func(ctx context.Context, out chan<- string) error {
+		for {
+			name, err := src.Next()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err = send(ctx, out, name); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends the domain name to out, blocking until it's received or the context is done.
+func send(ctx context.Context, out chan<- string, name string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case out <- name:
+		return nil
+	}
+}
+
+// ToExporter returns the Consumer exporting every result. The exporter must be flushed after Run returns.
+func ToExporter(exporter dnslookupapi.Exporter) Consumer {
+	return func(_ context.Context, result dnslookupapi.LookupResult) error {
+		return exporter.Export(result)
+	}
+}
+
+// StopOnError wraps the Consumer, so the first failed lookup stops the pipeline with its error.
+func StopOnError(consume Consumer) Consumer {
+	return func(ctx context.Context, result dnslookupapi.LookupResult) error {
+		if result.Err != nil {
+			return result.Err
+		}
+
+		return consume(ctx, result)
+	}
+}
@@ -0,0 +1,121 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/dnslookupapitest"
+)
+
+// domainNames returns n domain names.
+func domainNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = "d" + strconv.Itoa(i) + ".example.com"
+	}
+
+	return names
+}
+
+// TestRun tests that every domain name is looked up and consumed once.
+func TestRun(t *testing.T) {
+	fake := dnslookupapitest.NewFake()
+	fake.SetError("d3.example.com", errors.New("lookup failed"))
+
+	var consumed []string
+
+	failed := 0
+
+	p := Pipeline{Service: fake, Workers: 3}
+
+	consume := func(_ context.Context, r dnslookupapi.LookupResult) error {
+		consumed = append(consumed, r.DomainName)
+		if r.Err != nil {
+			failed++
+		}
+
+		return nil
+	}
+
+	if err := p.Run(context.Background(), FromSlice(domainNames(10)), consume); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	sort.Strings(consumed)
+
+	want := domainNames(10)
+	sort.Strings(want)
+
+	if strings.Join(consumed, ",") != strings.Join(want, ",") || failed != 1 {
+		t.Errorf("Run() consumed %v with %d failures", consumed, failed)
+	}
+}
+
+// TestRunStopsOnError tests that the consumer error cancels the other stages.
+func TestRunStopsOnError(t *testing.T) {
+	fake := dnslookupapitest.NewFake()
+	fake.SetError("d2.example.com", errors.New("lookup failed"))
+	fake.SetLatency(time.Millisecond)
+
+	var consumed int32
+
+	p := Pipeline{Service: fake, Workers: 2, Buffer: 1}
+
+	err := p.Run(context.Background(), FromSlice(domainNames(1000)), StopOnError(
+		func(context.Context, dnslookupapi.LookupResult) error {
+			atomic.AddInt32(&consumed, 1)
+			return nil
+		}))
+	if err == nil || err.Error() != "lookup failed" {
+		t.Errorf("Run() error = %v, want lookup failed", err)
+	}
+
+	if n := len(fake.Calls()); n >= 1000 {
+		t.Errorf("Run() made %d lookups after the error", n)
+	}
+}
+
+// TestRunCanceled tests that Run returns the context error.
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := Pipeline{Service: dnslookupapitest.NewFake()}
+
+	err := p.Run(ctx, FromSlice(domainNames(10)), func(context.Context, dnslookupapi.LookupResult) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+// TestFromReaderToExporter tests the stages built from a DomainReader and an Exporter.
+func TestFromReaderToExporter(t *testing.T) {
+	var out bytes.Buffer
+
+	exporter := dnslookupapi.NewCSVExporter(&out)
+	src := dnslookupapi.NewDomainReader(strings.NewReader(dnslookupapitest.FixtureDomain + "\n"))
+
+	if err := (Pipeline{Service: dnslookupapitest.NewFake()}).Run(
+		context.Background(), FromReader(src), ToExporter(exporter)); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if err := exporter.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	rows := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(rows) < 2 || !strings.HasPrefix(rows[1], dnslookupapitest.FixtureDomain+",") {
+		t.Errorf("Run() exported %q", out.String())
+	}
+}
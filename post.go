@@ -0,0 +1,49 @@
+package dnslookupapi
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// formMediaType is the content type of the requests made with ClientParams.PostForm.
+const formMediaType = "application/x-www-form-urlencoded"
+
+// toPostForm moves the query parameters of the request to the form-encoded POST body.
+func toPostForm(req *http.Request) {
+	form := req.URL.RawQuery
+
+	req.Method = http.MethodPost
+	req.URL.RawQuery = ""
+	req.Header.Set("Content-Type", formMediaType)
+
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(form)), nil
+	}
+	req.Body, _ = req.GetBody()
+	req.ContentLength = int64(len(form))
+}
+
+// RequestParams returns the API parameters of the request built by the client,
+// taken from the form-encoded POST body or the query string.
+// The body of the request is left intact.
+func RequestParams(req *http.Request) (url.Values, error) {
+	if req.Method != http.MethodPost || req.GetBody == nil {
+		return req.URL.Query(), nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	form, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return url.ParseQuery(string(form))
+}
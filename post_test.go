@@ -0,0 +1,84 @@
+package dnslookupapi
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestPostForm tests sending the parameters as a form-encoded POST body.
+func TestPostForm(t *testing.T) {
+	var failed int
+
+	handler := func(fail bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			if fail {
+				failed++
+				w.WriteHeader(http.StatusBadGateway)
+
+				return
+			}
+
+			if req.Method != http.MethodPost || req.URL.RawQuery != "" ||
+				req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+				t.Errorf("request = %s %s %s", req.Method, req.URL, req.Header.Get("Content-Type"))
+			}
+
+			body, _ := io.ReadAll(req.Body)
+
+			form, err := url.ParseQuery(string(body))
+			if err != nil || form.Get("apiKey") != "at_key" || form.Get("domainName") != "whoisxmlapi.com" ||
+				form.Get("type") != strings.Repeat("A,", 50)+"MX" {
+				t.Errorf("form = %v, %v", form, err)
+			}
+
+			_, _ = w.Write([]byte(`{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[]}}`))
+		}
+	}
+
+	primary := httptest.NewServer(handler(true))
+	defer primary.Close()
+
+	mirror := httptest.NewServer(handler(false))
+	defer mirror.Close()
+
+	primaryURL, _ := url.Parse(primary.URL)
+	mirrorURL, _ := url.Parse(mirror.URL)
+
+	client := NewClient("at_key", ClientParams{
+		DNSLookupBaseURL:    primaryURL,
+		DNSLookupMirrorURLs: []*url.URL{mirrorURL},
+		PostForm:            true,
+	})
+
+	types := OptionType(strings.Repeat("A,", 50) + "MX")
+
+	resp, _, err := client.Get(context.Background(), "whoisxmlapi.com", types)
+	if err != nil || resp.DomainName != "whoisxmlapi.com" {
+		t.Fatalf("Get() = %v, %v", resp, err)
+	}
+
+	if failed != 1 {
+		t.Errorf("primary calls = %d, want 1", failed)
+	}
+
+	req, err := client.BuildRequest("whoisxmlapi.com", types)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		params, err := RequestParams(req)
+		if err != nil || params.Get("domainName") != "whoisxmlapi.com" {
+			t.Errorf("RequestParams() = %v, %v", params, err)
+		}
+	}
+
+	if u := RedactURL(req.URL); strings.Contains(u, "at_key") {
+		t.Errorf("RedactURL() = %v", u)
+	}
+}
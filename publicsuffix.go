@@ -0,0 +1,35 @@
+package dnslookupapi
+
+import "golang.org/x/net/publicsuffix"
+
+// PublicSuffix returns the public suffix of the domain name according to the Public Suffix List
+// of golang.org/x/net/publicsuffix, e.g. "co.uk" for "app.eu.example.co.uk", and whether it's managed
+// by ICANN rather than privately, e.g. "github.io". The name is normalized, see NormalizeName.
+func PublicSuffix(domainName string) (suffix string, icann bool) {
+	name := NormalizeName(domainName)
+	if name == "" || name == "." {
+		return "", false
+	}
+
+	return publicsuffix.PublicSuffix(name)
+}
+
+// RegistrableDomain returns the registrable domain of the domain name, i.e. its public suffix with one more
+// label, e.g. "example.co.uk" for "app.eu.example.co.uk". It returns ArgError if the name is a public suffix.
+func RegistrableDomain(domainName string) (string, error) {
+	name := NormalizeName(domainName)
+	if name == "" || name == "." {
+		return "", domainNameError("is empty")
+	}
+
+	if suffix, _ := publicsuffix.PublicSuffix(name); name == suffix {
+		return "", domainNameError("is a public suffix: " + quote(name))
+	}
+
+	registrable, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return "", domainNameError(err.Error())
+	}
+
+	return registrable, nil
+}
@@ -0,0 +1,98 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestPublicSuffix tests the public suffix lookup.
+func TestPublicSuffix(t *testing.T) {
+	tests := []struct {
+		name   string
+		suffix string
+		icann  bool
+	}{
+		{"app.eu.example.co.uk", "co.uk", true},
+		{"Example.COM.", "com", true},
+		{"a.www.ck", "ck", true},
+		{"foo.bar.ck", "bar.ck", true},
+		{"x.user.github.io", "github.io", false},
+		{"example.unknowntld", "unknowntld", false},
+		{"bücher.de", "de", true},
+	}
+
+	for _, tt := range tests {
+		suffix, icann := PublicSuffix(tt.name)
+		if suffix != tt.suffix || icann != tt.icann {
+			t.Errorf("PublicSuffix(%q) = %q, %v, want %q, %v", tt.name, suffix, icann, tt.suffix, tt.icann)
+		}
+	}
+}
+
+// TestRegistrableDomain tests the registrable domain of exact, wildcard, exception and private rules.
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"app.eu.example.co.uk", "example.co.uk", false},
+		{"example.com", "example.com", false},
+		{"a.www.ck", "www.ck", false},
+		{"foo.bar.ck", "foo.bar.ck", false},
+		{"x.user.github.io", "user.github.io", false},
+		{"co.uk", "", true},
+		{"com", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := RegistrableDomain(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("RegistrableDomain(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+
+		var argErr *ArgError
+		if err != nil && !errors.As(err, &argErr) {
+			t.Errorf("RegistrableDomain(%q) error = %T, want *ArgError", tt.name, err)
+		}
+
+		if got != tt.want {
+			t.Errorf("RegistrableDomain(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestDNSLookupGetRegistrableDomain tests that Get queries the registrable domain if it's enabled.
+func TestDNSLookupGetRegistrableDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"` + req.URL.Query().Get("domainName") + `","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{
+		HTTPClient:        server.Client(),
+		DNSLookupBaseURL:  baseURL,
+		RegistrableDomain: true,
+	})
+
+	resp, _, err := client.Get(context.Background(), "app.eu.example.co.uk")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if resp.DomainName != "example.co.uk" || resp.ASCIIDomainName != "example.co.uk" ||
+		resp.RequestedDomainName != "app.eu.example.co.uk" {
+		t.Errorf("Get() domain names = %q, %q, %q", resp.DomainName, resp.ASCIIDomainName, resp.RequestedDomainName)
+	}
+
+	if _, err = client.GetRaw(context.Background(), "co.uk"); err == nil {
+		t.Error("GetRaw() expected error for public suffix")
+	}
+}
@@ -0,0 +1,242 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"strings"
+)
+
+// Count returns the number of all DNS records including those which failed to parse.
+func (r *DNSRecords) Count() int {
+	return len(r.All)
+}
+
+// Has reports whether there is at least one record of the DNS type.
+func (r *DNSRecords) Has(dnsType string) bool {
+	for _, record := range r.All {
+		if strings.EqualFold(record.CommonFields.DNSType, dnsType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filter returns a new DNSRecords holding only the records for which keep returns true.
+func (r *DNSRecords) Filter(keep func(record DNSRecord) bool) *DNSRecords {
+	filtered := r.derive()
+
+	for _, record := range r.All {
+		if keep(record) {
+			filtered.All = append(filtered.All, filtered.parseRecord(record.Raw))
+		}
+	}
+
+	return filtered
+}
+
+// FilterByType returns a new DNSRecords holding only the records of the DNS types.
+func (r *DNSRecords) FilterByType(dnsTypes ...string) *DNSRecords {
+	return r.Filter(func(record DNSRecord) bool {
+		for _, dnsType := range dnsTypes {
+			if strings.EqualFold(record.CommonFields.DNSType, dnsType) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// FilterByName returns a new DNSRecords holding only the records with the owner name.
+// Names are compared case-insensitively, the trailing dot is optional.
+func (r *DNSRecords) FilterByName(name string) *DNSRecords {
+	name = strings.TrimSuffix(name, ".")
+
+	return r.Filter(func(record DNSRecord) bool {
+		return strings.EqualFold(strings.TrimSuffix(record.CommonFields.Name, "."), name)
+	})
+}
+
+// Each calls fn for every successfully parsed record in the order returned by the API.
+// typed is the record of the corresponding type, e.g. ARecord or MXRecord, or the record in Legacy.
+// Records of unsupported DNS types are passed too, typed is UnknownRecord then.
+// Iteration stops when fn returns false.
+func (r *DNSRecords) Each(fn func(record DNSRecord, typed interface{}) bool) {
+	r.each(func(_ int, record DNSRecord, typed interface{}) bool {
+		return fn(record, typed)
+	})
+}
+
+// each calls fn for every successfully parsed record and every record of an unsupported DNS type
+// with its index in All.
+func (r *DNSRecords) each(fn func(i int, record DNSRecord, typed interface{}) bool) {
+	var (
+		next    = make(map[string]int)
+		unknown int
+	)
+
+	for i, record := range r.All {
+		var typed interface{}
+
+		// typed slices are filled in the order of All
+		switch {
+		case record.ParseError == nil:
+			dnsType := record.CommonFields.DNSType
+			typed = r.typedRecord(dnsType, next[dnsType])
+			next[dnsType]++
+		case errors.Is(record.ParseError, ErrUnsupportedDNSType):
+			if unknown < len(r.Unknown) {
+				typed = r.Unknown[unknown]
+			}
+			unknown++
+		}
+
+		if typed == nil {
+			continue
+		}
+
+		if !fn(i, record, typed) {
+			return
+		}
+	}
+}
+
+// typedRecord returns the i-th parsed record of the DNS type, nil if there is no such record.
+func (r *DNSRecords) typedRecord(dnsType string, i int) interface{} {
+	switch dnsType {
+	case "A":
+		if i < len(r.A) {
+			return r.A[i]
+		}
+	case "AAAA":
+		if i < len(r.AAAA) {
+			return r.AAAA[i]
+		}
+	case "NS":
+		if i < len(r.NS) {
+			return r.NS[i]
+		}
+	case "MX":
+		if i < len(r.MX) {
+			return r.MX[i]
+		}
+	case "MD":
+		if i < len(r.MD) {
+			return r.MD[i]
+		}
+	case "MF":
+		if i < len(r.MF) {
+			return r.MF[i]
+		}
+	case "MB":
+		if i < len(r.MB) {
+			return r.MB[i]
+		}
+	case "SOA":
+		if i < len(r.SOA) {
+			return r.SOA[i]
+		}
+	case "TXT":
+		if i < len(r.TXT) {
+			return r.TXT[i]
+		}
+	case "CAA":
+		if i < len(r.CAA) {
+			return r.CAA[i]
+		}
+	case "CNAME":
+		if i < len(r.CNAME) {
+			return r.CNAME[i]
+		}
+	case "DNAME":
+		if i < len(r.DNAME) {
+			return r.DNAME[i]
+		}
+	case "DNSKEY":
+		if i < len(r.DNSKEY) {
+			return r.DNSKEY[i]
+		}
+	case "NSEC":
+		if i < len(r.NSEC) {
+			return r.NSEC[i]
+		}
+	case "NSEC3PARAM":
+		if i < len(r.NSEC3PARAM) {
+			return r.NSEC3PARAM[i]
+		}
+	case "DS":
+		if i < len(r.DS) {
+			return r.DS[i]
+		}
+	case "PTR":
+		if i < len(r.PTR) {
+			return r.PTR[i]
+		}
+	case "SRV":
+		if i < len(r.SRV) {
+			return r.SRV[i]
+		}
+	case "LOC":
+		if i < len(r.LOC) {
+			return r.LOC[i]
+		}
+	case "NAPTR":
+		if i < len(r.NAPTR) {
+			return r.NAPTR[i]
+		}
+	case "HINFO":
+		if i < len(r.HINFO) {
+			return r.HINFO[i]
+		}
+	case "RP":
+		if i < len(r.RP) {
+			return r.RP[i]
+		}
+	case "DLV":
+		if i < len(r.DLV) {
+			return r.DLV[i]
+		}
+	case "SSHFP":
+		if i < len(r.SSHFP) {
+			return r.SSHFP[i]
+		}
+	case "DHCID":
+		if i < len(r.DHCID) {
+			return r.DHCID[i]
+		}
+	case "TLSA":
+		if i < len(r.TLSA) {
+			return r.TLSA[i]
+		}
+	case "NSAP":
+		if i < len(r.NSAP) {
+			return r.NSAP[i]
+		}
+	case "URI":
+		if i < len(r.URI) {
+			return r.URI[i]
+		}
+	case "CERT":
+		if i < len(r.CERT) {
+			return r.CERT[i]
+		}
+	case "SMIMEA":
+		if i < len(r.SMIMEA) {
+			return r.SMIMEA[i]
+		}
+	case "OPENPGPKEY":
+		if i < len(r.OPENPGPKEY) {
+			return r.OPENPGPKEY[i]
+		}
+	case "NULL":
+		if i < len(r.NULL) {
+			return r.NULL[i]
+		}
+	default:
+		if r.Legacy != nil {
+			return r.Legacy.typedRecord(dnsType, i)
+		}
+	}
+
+	return nil
+}
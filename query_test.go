@@ -0,0 +1,86 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testRecords returns DNS records for testing.
+func testRecords(t *testing.T) *DNSRecords {
+	const records = `[
+{"type":1,"dnsType":"A","name":"example.com.","address":"1.1.1.1"},
+{"type":15,"dnsType":"MX","name":"example.com.","target":"mx.example.com.","priority":10},
+{"type":1,"dnsType":"A","name":"www.example.com.","address":"2.2.2.2"},
+{"type":65535,"dnsType":"UNKNOWN","name":"example.com."},
+{"type":16,"dnsType":"TXT","name":"Example.com.","strings":["v=spf1 -all"]}
+]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	return &r
+}
+
+// TestQuery tests the query and filter functions.
+func TestQuery(t *testing.T) {
+	r := testRecords(t)
+
+	if r.Count() != 5 {
+		t.Errorf("Count() = %v, want 5", r.Count())
+	}
+
+	if !r.Has("mx") || r.Has("AAAA") {
+		t.Errorf("Has() = %v, %v", r.Has("mx"), r.Has("AAAA"))
+	}
+
+	byType := r.FilterByType("a", "TXT")
+	if byType.Count() != 3 || len(byType.A) != 2 || len(byType.TXT) != 1 || len(byType.MX) != 0 {
+		t.Errorf("FilterByType() = %+v", byType)
+	}
+
+	byName := r.FilterByName("example.com")
+	if byName.Count() != 4 || len(byName.A) != 1 || len(byName.MX) != 1 || len(byName.TXT) != 1 {
+		t.Errorf("FilterByName() = %+v", byName)
+	}
+
+	if !errors.Is(byName.All[2].ParseError, ErrUnsupportedDNSType) {
+		t.Errorf("FilterByName() ParseError = %v", byName.All[2].ParseError)
+	}
+}
+
+// TestEach tests the Each function.
+func TestEach(t *testing.T) {
+	r := testRecords(t)
+
+	var got []string
+	r.Each(func(record DNSRecord, typed interface{}) bool {
+		got = append(got, fmt.Sprintf("%s %T", record.CommonFields.Name, typed))
+		return true
+	})
+
+	want := []string{
+		"example.com. dnslookupapi.ARecord",
+		"example.com. dnslookupapi.MXRecord",
+		"www.example.com. dnslookupapi.ARecord",
+		"example.com. dnslookupapi.UnknownRecord",
+		"Example.com. dnslookupapi.TXTRecord",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Each() = %v, want %v", got, want)
+	}
+
+	var n int
+	r.Each(func(DNSRecord, interface{}) bool {
+		n++
+		return n < 2
+	})
+
+	if n != 2 {
+		t.Errorf("Each() calls = %v, want 2", n)
+	}
+}
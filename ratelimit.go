@@ -0,0 +1,79 @@
+package dnslookupapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate-limit and credit headers. The IETF draft names without the X- prefix are accepted as well.
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerCreditsRemaining   = "X-Credits-Remaining"
+	headerRetryAfter         = "Retry-After"
+)
+
+// resetEpochThreshold separates reset values given in seconds from now and as Unix time.
+const resetEpochThreshold = 1000000000
+
+// RateLimit is the rate-limit and credit metadata returned in the response headers.
+// Numeric fields are -1 and Reset is zero if the corresponding header is missing.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window
+	Limit int
+
+	// Remaining is the number of requests remaining in the current window
+	Remaining int
+
+	// Reset is the time the current window resets
+	Reset time.Time
+
+	// RetryAfter is the delay requested by the server before the next request
+	RetryAfter time.Duration
+
+	// CreditsRemaining is the remaining account balance in credits
+	CreditsRemaining int
+}
+
+// parseRateLimit parses the rate-limit headers relative to the time the response was received.
+func parseRateLimit(header http.Header, now time.Time) RateLimit {
+	rl := RateLimit{
+		Limit:            headerInt(header, headerRateLimitLimit),
+		Remaining:        headerInt(header, headerRateLimitRemaining),
+		CreditsRemaining: headerInt(header, headerCreditsRemaining),
+	}
+
+	if reset := headerInt(header, headerRateLimitReset); reset >= resetEpochThreshold {
+		rl.Reset = time.Unix(int64(reset), 0)
+	} else if reset >= 0 {
+		rl.Reset = now.Add(time.Duration(reset) * time.Second)
+	}
+
+	if value := header.Get(headerRetryAfter); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			rl.RetryAfter = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil && date.After(now) {
+			rl.RetryAfter = date.Sub(now)
+		}
+	}
+
+	return rl
+}
+
+// headerInt returns the non-negative integer value of the header or -1.
+func headerInt(header http.Header, name string) int {
+	value := header.Get(name)
+	if value == "" {
+		value = header.Get(strings.TrimPrefix(name, "X-"))
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return -1
+	}
+
+	return n
+}
@@ -0,0 +1,106 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestParseRateLimit tests parsing the rate-limit headers.
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2022, 7, 12, 11, 46, 25, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		want   RateLimit
+	}{
+		{
+			name:   "no headers",
+			header: http.Header{},
+			want:   RateLimit{Limit: -1, Remaining: -1, CreditsRemaining: -1},
+		},
+		{
+			name: "seconds",
+			header: http.Header{
+				"X-Ratelimit-Limit":     {"30"},
+				"X-Ratelimit-Remaining": {"29"},
+				"X-Ratelimit-Reset":     {"60"},
+				"X-Credits-Remaining":   {"1000"},
+				"Retry-After":           {"5"},
+			},
+			want: RateLimit{
+				Limit:            30,
+				Remaining:        29,
+				Reset:            now.Add(time.Minute),
+				RetryAfter:       5 * time.Second,
+				CreditsRemaining: 1000,
+			},
+		},
+		{
+			name: "draft names and dates",
+			header: http.Header{
+				"Ratelimit-Remaining": {"0"},
+				"Ratelimit-Reset":     {"1657626445"},
+				"Retry-After":         {now.Add(10 * time.Second).Format(http.TimeFormat)},
+			},
+			want: RateLimit{
+				Limit:            -1,
+				Remaining:        0,
+				Reset:            time.Unix(1657626445, 0),
+				RetryAfter:       10 * time.Second,
+				CreditsRemaining: -1,
+			},
+		},
+		{
+			name:   "invalid values",
+			header: http.Header{"X-Ratelimit-Remaining": {"many"}, "Retry-After": {"soon"}},
+			want:   RateLimit{Limit: -1, Remaining: -1, CreditsRemaining: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRateLimit(tt.header, now)
+			if got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining ||
+				!got.Reset.Equal(tt.want.Reset) || got.RetryAfter != tt.want.RetryAfter ||
+				got.CreditsRemaining != tt.want.CreditsRemaining {
+				t.Errorf("parseRateLimit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResponseRateLimit tests that responses carry the rate-limit metadata and the duration.
+func TestResponseRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "7")
+		time.Sleep(5 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL})
+
+	_, resp, err := client.Get(context.Background(), "whoisxmlapi.com")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if resp.RateLimit.Remaining != 7 || resp.Duration < 5*time.Millisecond {
+		t.Errorf("Get() rate limit = %+v, duration = %v", resp.RateLimit, resp.Duration)
+	}
+
+	resp, err = client.GetStream(context.Background(), "whoisxmlapi.com", func(DNSRecord) error { return nil })
+	if err != nil {
+		t.Fatalf("GetStream() error = %v", err)
+	}
+
+	if resp.RateLimit.Remaining != 7 || resp.Duration < 5*time.Millisecond {
+		t.Errorf("GetStream() rate limit = %+v, duration = %v", resp.RateLimit, resp.Duration)
+	}
+}
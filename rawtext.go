@@ -0,0 +1,315 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidRawText is returned when the raw text of the record cannot be parsed.
+var ErrInvalidRawText = errors.New("invalid raw text")
+
+// ParseRawText parses the raw text of the record in the presentation format (RFC 1035 section 5.1)
+// into the typed record, e.g. ARecord. It can rescue records whose JSON fields are missing
+// or malformed. The owner name, TTL and type are taken from the raw text when missing in CommonFields.
+// It returns ErrUnsupportedDNSType for types it cannot parse and ErrInvalidRawText for malformed text.
+func (r DNSRecord) ParseRawText() (interface{}, error) {
+	tokens, err := tokenizeRawText(r.CommonFields.RawText)
+	if err != nil {
+		return nil, err
+	}
+
+	common := r.CommonFields
+
+	dnsType, rdata, err := parseRawTextHeader(&common, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRData(common, dnsType, rdata)
+}
+
+// parseRawTextHeader parses the owner name, the optional TTL and class and the type.
+func parseRawTextHeader(common *commonFields, tokens []rawToken) (string, []rawToken, error) {
+	if len(tokens) < 2 {
+		return "", nil, ErrInvalidRawText
+	}
+
+	if common.Name == "" {
+		common.Name = tokens[0].text
+	}
+
+	i := 1
+	for ; i < len(tokens) && i <= 3; i++ {
+		text := strings.ToUpper(tokens[i].text)
+
+		if ttl, err := strconv.Atoi(text); err == nil {
+			if common.TTL == 0 {
+				common.TTL = ttl
+			}
+
+			continue
+		}
+
+		if text == "IN" || text == "CH" || text == "HS" || text == "CS" {
+			continue
+		}
+
+		break
+	}
+
+	if i == len(tokens) {
+		return "", nil, ErrInvalidRawText
+	}
+
+	dnsType := strings.ToUpper(tokens[i].text)
+	if common.DNSType == "" {
+		common.DNSType = dnsType
+	}
+
+	if common.Type == 0 {
+		common.Type, _ = RRTypeCode(dnsType)
+	}
+
+	return dnsType, tokens[i+1:], nil
+}
+
+// parseRData parses the record data of the type.
+func parseRData(common commonFields, dnsType string, rdata []rawToken) (interface{}, error) {
+	p := &rdataParser{tokens: rdata}
+
+	var record interface{}
+
+	switch dnsType {
+	case "A":
+		record = ARecord{commonFields: common, Address: p.text()}
+	case "AAAA":
+		record = AAAARecord{commonFields: common, Address: p.text()}
+	case "NS":
+		record = NSRecord{commonFields: common, Target: p.text()}
+	case "CNAME":
+		record = CNAMERecord{commonFields: common, Alias: common.Name, Target: p.text()}
+	case "DNAME":
+		record = DNAMERecord{commonFields: common, Alias: common.Name, Target: p.text()}
+	case "PTR":
+		record = PTRRecord{commonFields: common, Target: p.text()}
+	case "MX":
+		record = MXRecord{commonFields: common, Priority: p.int(), Target: p.text()}
+	case "SOA":
+		record = SOARecord{
+			commonFields: common,
+			Host:         p.text(),
+			Admin:        p.text(),
+			Serial:       p.int(),
+			Refresh:      p.int(),
+			Retry:        p.int(),
+			Expire:       p.int(),
+			Minimum:      p.int(),
+		}
+	case "TXT":
+		record = TXTRecord{commonFields: common, Strings: p.rest()}
+	case "CAA":
+		record = CAARecord{commonFields: common, Flags: p.int(), Tag: p.text(), Value: p.text()}
+	case "SRV":
+		record = SRVRecord{commonFields: common, Priority: p.int(), Weight: p.int(), Port: p.int(), Target: p.text()}
+	case "DS":
+		record = DSRecord{
+			commonFields: common,
+			Footprint:    p.int(),
+			Algorithm:    p.int(),
+			DigestID:     p.int(),
+			Digest:       p.rest(),
+		}
+	case "DLV":
+		record = DLVRecord{
+			commonFields: common,
+			Footprint:    p.int(),
+			Algorithm:    p.int(),
+			DigestID:     p.int(),
+			Digest:       p.rest(),
+		}
+	case "DNSKEY":
+		key := DNSKEYRecord{commonFields: common, Flags: p.int(), Protocol: p.int(), Algorithm: p.int(), Key: p.rest()}
+		if tag, err := key.KeyTag(); err == nil {
+			key.Footprint = int(tag)
+		}
+
+		record = key
+	case "SSHFP":
+		record = SSHFPRecord{commonFields: common, Algorithm: p.int(), DigestType: p.int(), FingerPrint: p.rest()}
+	case "TLSA":
+		record = TLSARecord{
+			commonFields:               common,
+			CertificateUsage:           p.int(),
+			Selector:                   p.int(),
+			MatchingType:               p.int(),
+			CertificateAssociationData: p.rest(),
+		}
+	case "SMIMEA":
+		record = SMIMEARecord{
+			commonFields:               common,
+			CertificateUsage:           p.int(),
+			Selector:                   p.int(),
+			MatchingType:               p.int(),
+			CertificateAssociationData: p.rest(),
+		}
+	case "URI":
+		record = URIRecord{commonFields: common, Priority: p.int(), Weight: p.int(), Target: p.text()}
+	case "CERT":
+		record = CERTRecord{
+			commonFields:    common,
+			CertificateType: p.int(),
+			KeyTag:          p.int(),
+			Algorithm:       p.int(),
+			Certificate:     p.rest(),
+		}
+	case "OPENPGPKEY":
+		record = OPENPGPKEYRecord{commonFields: common, PublicKey: p.rest()}
+	case "NAPTR":
+		record = NAPTRRecord{
+			commonFields: common,
+			Order:        p.int(),
+			Preference:   p.int(),
+			Flags:        p.text(),
+			Service:      p.text(),
+			Regexp:       p.text(),
+			Replacement:  p.text(),
+		}
+	case "HINFO":
+		record = HINFORecord{commonFields: common, CPU: p.text(), OS: p.text()}
+	case "RP":
+		record = RPRecord{commonFields: common, Mailbox: p.text(), TextDomain: p.text()}
+	case "NSEC3PARAM":
+		record = NSEC3PARAMRecord{
+			commonFields:  common,
+			HashAlgorithm: p.int(),
+			Flags:         p.int(),
+			Iterations:    p.int(),
+			Salt:          p.rest(),
+		}
+	default:
+		return nil, ErrUnsupportedDNSType
+	}
+
+	if err := p.finish(); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// rawToken is the token of the presentation format.
+type rawToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenizeRawText splits the presentation format into tokens. Quoted strings are unquoted,
+// parentheses are removed and comments are skipped.
+func tokenizeRawText(rawText string) ([]rawToken, error) {
+	var (
+		tokens []rawToken
+		b      strings.Builder
+	)
+
+	for i := 0; i < len(rawText); i++ {
+		c := rawText[i]
+
+		switch {
+		case c == ';':
+			for i < len(rawText) && rawText[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')':
+		case c == '"':
+			b.Reset()
+
+			for i++; i < len(rawText) && rawText[i] != '"'; i++ {
+				if rawText[i] == '\\' && i+1 < len(rawText) {
+					i++
+				}
+
+				b.WriteByte(rawText[i])
+			}
+
+			if i == len(rawText) {
+				return nil, ErrInvalidRawText
+			}
+
+			tokens = append(tokens, rawToken{text: b.String(), quoted: true})
+		default:
+			start := i
+			for i+1 < len(rawText) && !strings.ContainsRune(" \t\r\n();\"", rune(rawText[i+1])) {
+				i++
+			}
+
+			tokens = append(tokens, rawToken{text: rawText[start : i+1]})
+		}
+	}
+
+	return tokens, nil
+}
+
+// rdataParser consumes the record data tokens recording the first error.
+type rdataParser struct {
+	tokens []rawToken
+	err    error
+}
+
+// text returns the next token.
+func (p *rdataParser) text() string {
+	if len(p.tokens) == 0 {
+		p.fail()
+		return ""
+	}
+
+	t := p.tokens[0].text
+	p.tokens = p.tokens[1:]
+
+	return t
+}
+
+// int returns the next token as an integer.
+func (p *rdataParser) int() int {
+	t := p.text()
+
+	n, err := strconv.Atoi(t)
+	if err != nil {
+		p.fail()
+	}
+
+	return n
+}
+
+// rest returns all remaining tokens.
+func (p *rdataParser) rest() []string {
+	if len(p.tokens) == 0 {
+		p.fail()
+		return nil
+	}
+
+	rest := make([]string, 0, len(p.tokens))
+	for _, t := range p.tokens {
+		rest = append(rest, t.text)
+	}
+
+	p.tokens = nil
+
+	return rest
+}
+
+// fail records the error.
+func (p *rdataParser) fail() {
+	if p.err == nil {
+		p.err = ErrInvalidRawText
+	}
+}
+
+// finish returns the first error or ErrInvalidRawText if some tokens are left unparsed.
+func (p *rdataParser) finish() error {
+	if p.err == nil && len(p.tokens) != 0 {
+		return ErrInvalidRawText
+	}
+
+	return p.err
+}
@@ -0,0 +1,153 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestParseRawText tests parsing the raw text of records.
+func TestParseRawText(t *testing.T) {
+	common := func(name string, ttl int, dnsType string, code int, rawText string) commonFields {
+		return commonFields{Name: name, TTL: ttl, DNSType: dnsType, Type: code, RawText: rawText}
+	}
+
+	tests := []struct {
+		name    string
+		rawText string
+		want    interface{}
+		wantErr error
+	}{
+		{
+			name:    "A",
+			rawText: "whoisxmlapi.com.\t300\tIN\tA\t104.26.13.210",
+			want: ARecord{
+				commonFields: common("whoisxmlapi.com.", 300, "A", 1, "whoisxmlapi.com.\t300\tIN\tA\t104.26.13.210"),
+				Address:      "104.26.13.210",
+			},
+		},
+		{
+			name:    "MX with class before TTL",
+			rawText: "whoisxmlapi.com. IN 300 MX 10 aspmx.l.google.com.",
+			want: MXRecord{
+				commonFields: common("whoisxmlapi.com.", 300, "MX", 15, "whoisxmlapi.com. IN 300 MX 10 aspmx.l.google.com."),
+				Priority:     10,
+				Target:       "aspmx.l.google.com.",
+			},
+		},
+		{
+			name:    "TXT with quoted strings",
+			rawText: `example.com. 300 IN TXT "v=spf1 include:_spf.google.com" " ~all" "say \"hi\""`,
+			want: TXTRecord{
+				commonFields: common("example.com.", 300, "TXT", 16,
+					`example.com. 300 IN TXT "v=spf1 include:_spf.google.com" " ~all" "say \"hi\""`),
+				Strings: []string{"v=spf1 include:_spf.google.com", " ~all", `say "hi"`},
+			},
+		},
+		{
+			name: "SOA with parentheses and comments",
+			rawText: "example.com. 3600 IN SOA ns1.example.com. admin.example.com. ( 2022071201 ; serial\n" +
+				"86400 7200 3600000 3600 )",
+			want: SOARecord{
+				commonFields: common("example.com.", 3600, "SOA", 6,
+					"example.com. 3600 IN SOA ns1.example.com. admin.example.com. ( 2022071201 ; serial\n"+
+						"86400 7200 3600000 3600 )"),
+				Host:    "ns1.example.com.",
+				Admin:   "admin.example.com.",
+				Serial:  2022071201,
+				Refresh: 86400,
+				Retry:   7200,
+				Expire:  3600000,
+				Minimum: 3600,
+			},
+		},
+		{
+			name:    "CAA",
+			rawText: `example.com. 300 IN CAA 0 issue "letsencrypt.org"`,
+			want: CAARecord{
+				commonFields: common("example.com.", 300, "CAA", 257, `example.com. 300 IN CAA 0 issue "letsencrypt.org"`),
+				Tag:          "issue",
+				Value:        "letsencrypt.org",
+			},
+		},
+		{
+			name:    "SRV",
+			rawText: "_sip._tcp.example.com. 300 IN SRV 10 60 5060 sip.example.com.",
+			want: SRVRecord{
+				commonFields: common("_sip._tcp.example.com.", 300, "SRV", 33,
+					"_sip._tcp.example.com. 300 IN SRV 10 60 5060 sip.example.com."),
+				Priority: 10,
+				Weight:   60,
+				Port:     5060,
+				Target:   "sip.example.com.",
+			},
+		},
+		{
+			name:    "missing field",
+			rawText: "example.com. 300 IN MX aspmx.l.google.com.",
+			wantErr: ErrInvalidRawText,
+		},
+		{
+			name:    "extra field",
+			rawText: "example.com. 300 IN A 1.1.1.1 2.2.2.2",
+			wantErr: ErrInvalidRawText,
+		},
+		{
+			name:    "unterminated quote",
+			rawText: `example.com. 300 IN TXT "v=spf1`,
+			wantErr: ErrInvalidRawText,
+		},
+		{
+			name:    "unsupported type",
+			rawText: "example.com. 300 IN HTTPS 1 . alpn=h2",
+			wantErr: ErrUnsupportedDNSType,
+		},
+		{
+			name:    "empty",
+			rawText: "",
+			wantErr: ErrInvalidRawText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := DNSRecord{CommonFields: commonFields{RawText: tt.rawText}}
+
+			got, err := record.ParseRawText()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRawText() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRawText() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseRawTextKeepsCommonFields tests that JSON common fields take precedence over the raw text.
+func TestParseRawTextKeepsCommonFields(t *testing.T) {
+	record := DNSRecord{CommonFields: commonFields{
+		Name:    "whoisxmlapi.com.",
+		TTL:     60,
+		DNSType: "DNSKEY",
+		Type:    48,
+		RawText: "dskey.example.com. 86400 IN DNSKEY 256 3 5 ( AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/" +
+			" 2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvx egXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9Xzc" +
+			" nOf+EPbtG9DMBmADjFDc2w/rljwvFw== ) ; key id = 60485",
+	}}
+
+	got, err := record.ParseRawText()
+	if err != nil {
+		t.Fatalf("ParseRawText() error = %v", err)
+	}
+
+	key, ok := got.(DNSKEYRecord)
+	if !ok {
+		t.Fatalf("ParseRawText() = %T, want DNSKEYRecord", got)
+	}
+
+	if key.Name != "whoisxmlapi.com." || key.TTL != 60 || key.Flags != 256 || key.Footprint != 60485 || len(key.Key) != 4 {
+		t.Errorf("ParseRawText() = %+v", key)
+	}
+}
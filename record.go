@@ -0,0 +1,79 @@
+package dnslookupapi
+
+// Record is the common interface implemented by all typed DNS records.
+type Record interface {
+	// GetName returns the owner name of the record.
+	GetName() string
+
+	// GetTTL returns the time to live of the record.
+	GetTTL() int
+
+	// GetDNSType returns the DNS record type, e.g. "A".
+	GetDNSType() string
+
+	// GetRawText returns the raw text of the record.
+	GetRawText() string
+
+	// GetTypeCode returns the DNS record type code, e.g. 1 for A.
+	GetTypeCode() int
+}
+
+var _ = []Record{
+	ARecord{}, AAAARecord{}, NSRecord{}, MXRecord{}, MDRecord{}, MFRecord{}, MBRecord{},
+	SOARecord{}, TXTRecord{}, CAARecord{}, CNAMERecord{}, DNAMERecord{}, DNSKEYRecord{},
+	NSEC3PARAMRecord{}, NSECRecord{}, DSRecord{}, PTRRecord{}, SRVRecord{}, LOCRecord{},
+	NAPTRRecord{}, HINFORecord{}, RPRecord{}, DLVRecord{}, SSHFPRecord{}, DHCIDRecord{},
+	TLSARecord{}, NSAPRecord{}, NULLRecord{}, URIRecord{}, CERTRecord{}, SMIMEARecord{}, OPENPGPKEYRecord{},
+	UnknownRecord{},
+}
+
+// common returns the common fields of the typed record.
+func (c commonFields) common() commonFields {
+	return c
+}
+
+// setDNSType sets the resolved DNS type of the record.
+func (c *commonFields) setDNSType(dnsType string) {
+	c.DNSType = dnsType
+}
+
+// GetName returns the owner name of the record.
+func (c commonFields) GetName() string {
+	return c.Name
+}
+
+// GetTTL returns the time to live of the record.
+func (c commonFields) GetTTL() int {
+	return c.TTL
+}
+
+// GetDNSType returns the DNS record type.
+func (c commonFields) GetDNSType() string {
+	return c.DNSType
+}
+
+// GetRawText returns the raw text of the record.
+func (c commonFields) GetRawText() string {
+	return c.RawText
+}
+
+// GetTypeCode returns the DNS record type code.
+func (c commonFields) GetTypeCode() int {
+	return c.Type
+}
+
+// Records returns all successfully parsed records as Record values in the order returned by the API.
+// Records of unsupported DNS types are returned as UnknownRecord values.
+func (r *DNSRecords) Records() []Record {
+	records := make([]Record, 0, len(r.All))
+
+	r.Each(func(_ DNSRecord, typed interface{}) bool {
+		if record, ok := typed.(Record); ok {
+			records = append(records, record)
+		}
+
+		return true
+	})
+
+	return records
+}
@@ -0,0 +1,28 @@
+package dnslookupapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRecords tests the Records function.
+func TestRecords(t *testing.T) {
+	r := testRecords(t)
+
+	var got []string
+	for _, record := range r.Records() {
+		got = append(got, record.GetDNSType()+" "+record.GetName())
+	}
+
+	want := []string{
+		"A example.com.", "MX example.com.", "A www.example.com.", "UNKNOWN example.com.", "TXT Example.com.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Records() = %v, want %v", got, want)
+	}
+
+	mx := r.Records()[1]
+	if mx.GetTypeCode() != 15 || mx.(MXRecord).Target != "mx.example.com." {
+		t.Errorf("Records()[1] = %+v", mx)
+	}
+}
@@ -0,0 +1,253 @@
+// Package redact scrubs sensitive values from DNS Lookup API records and raw response bodies
+// before they are logged or persisted: SOA admin mailboxes, RP mailboxes, TXT verification tokens
+// and addresses of internal networks. The input is never modified, a sanitized deep copy is returned.
+package redact
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strings"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// Replacements of the redacted values. They keep the syntax of the values, so the records still parse.
+const (
+	// Mailbox replaces SOA admin and RP mailboxes.
+	Mailbox = "redacted.invalid."
+
+	// Token replaces the value of a verification token, the prefix is kept.
+	Token = "REDACTED"
+
+	// IPv4 replaces the IPv4 addresses of the redacted networks.
+	IPv4 = "0.0.0.0"
+
+	// IPv6 replaces the IPv6 addresses of the redacted networks.
+	IPv6 = "::"
+)
+
+// DefaultTokenPrefixes are the prefixes of the domain verification tokens published in TXT records.
+var DefaultTokenPrefixes = []string{
+	"google-site-verification=", "MS=", "facebook-domain-verification=", "apple-domain-verification=",
+	"atlassian-domain-verification=", "docusign=", "adobe-idp-site-verification=", "globalsign-domain-verification=",
+	"stripe-verification=", "hubspot-developer-verification=", "zoom-domain-verification=",
+}
+
+// DefaultNetworks are the private, loopback, link-local and shared address ranges.
+var DefaultNetworks = []string{
+	"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+	"fc00::/7", "fe80::/10", "::1/128",
+}
+
+// Policy selects the values to redact.
+type Policy struct {
+	// SOAAdmin redacts the admin mailbox of SOA records.
+	SOAAdmin bool
+
+	// RPMailbox redacts the mailbox of RP records.
+	RPMailbox bool
+
+	// TokenPrefixes are the prefixes of the TXT strings whose value is redacted.
+	TokenPrefixes []string
+
+	// Networks are the address ranges whose A and AAAA records are redacted.
+	Networks []*net.IPNet
+}
+
+// DefaultPolicy returns the policy redacting all supported values with the default token prefixes and networks.
+func DefaultPolicy() Policy {
+	policy := Policy{
+		SOAAdmin:      true,
+		RPMailbox:     true,
+		TokenPrefixes: append([]string(nil), DefaultTokenPrefixes...),
+	}
+
+	for _, cidr := range DefaultNetworks {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		policy.Networks = append(policy.Networks, network)
+	}
+
+	return policy
+}
+
+// Records returns the sanitized deep copy of the records. Records which failed to parse are copied as is.
+func Records(records *dnslookupapi.DNSRecords, policy Policy) (*dnslookupapi.DNSRecords, error) {
+	raw := make([]json.RawMessage, 0, len(records.All))
+
+	for _, record := range records.All {
+		if len(record.Raw) == 0 {
+			b, err := json.Marshal(record.CommonFields)
+			if err != nil {
+				return nil, err
+			}
+
+			record.Raw = b
+		}
+
+		raw = append(raw, policy.record(record.Raw))
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dnslookupapi.DNSLookupResponse{}
+	if err = json.Unmarshal(data, &resp.DNSRecords); err != nil {
+		return nil, err
+	}
+
+	if records.Legacy != nil {
+		if err = dnslookupapi.ParseLegacy()(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	return &resp.DNSRecords, nil
+}
+
+// Body returns the sanitized copy of the raw JSON response body, as returned by GetRaw.
+// The fields other than the records are kept, the body is re-encoded with the object keys sorted.
+func Body(body []byte, policy Policy) ([]byte, error) {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(response["DNSData"], &data); err != nil || data == nil {
+		return nil, errors.New("redact: no DNSData in the response body")
+	}
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(data["dnsRecords"], &records); err != nil {
+		return nil, err
+	}
+
+	for i, record := range records {
+		records[i] = policy.record(record)
+	}
+
+	var err error
+	if data["dnsRecords"], err = json.Marshal(records); err != nil {
+		return nil, err
+	}
+
+	if response["DNSData"], err = json.Marshal(data); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(response)
+}
+
+// record returns the sanitized raw record. The record is returned as is if nothing is redacted.
+func (p Policy) record(raw json.RawMessage) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw
+	}
+
+	var dnsType string
+	_ = json.Unmarshal(fields["dnsType"], &dnsType)
+
+	replaced := make(map[string]string)
+
+	switch strings.ToUpper(dnsType) {
+	case "SOA":
+		if p.SOAAdmin {
+			replaceField(fields, "admin", replaced, func(string) string { return Mailbox })
+		}
+	case "RP":
+		if p.RPMailbox {
+			replaceField(fields, "mailbox", replaced, func(string) string { return Mailbox })
+		}
+	case "A", "AAAA":
+		replaceField(fields, "address", replaced, p.address)
+	case "TXT":
+		p.txt(fields, replaced)
+	}
+
+	if len(replaced) == 0 {
+		return raw
+	}
+
+	var rawText string
+	if err := json.Unmarshal(fields["rawText"], &rawText); err == nil && rawText != "" {
+		for original, replacement := range replaced {
+			rawText = strings.ReplaceAll(rawText, original, replacement)
+		}
+
+		fields["rawText"], _ = json.Marshal(rawText)
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+
+	return b
+}
+
+// address returns the replacement of the address if it belongs to one of the networks.
+func (p Policy) address(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return address
+	}
+
+	for _, network := range p.Networks {
+		if network.Contains(ip) {
+			if ip.To4() != nil {
+				return IPv4
+			}
+
+			return IPv6
+		}
+	}
+
+	return address
+}
+
+// txt redacts the verification tokens of the TXT strings.
+func (p Policy) txt(fields map[string]json.RawMessage, replaced map[string]string) {
+	var values []string
+	if err := json.Unmarshal(fields["strings"], &values); err != nil {
+		return
+	}
+
+	changed := false
+
+	for i, value := range values {
+		for _, prefix := range p.TokenPrefixes {
+			if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+				values[i] = value[:len(prefix)] + Token
+				replaced[value] = values[i]
+				changed = true
+
+				break
+			}
+		}
+	}
+
+	if changed {
+		fields["strings"], _ = json.Marshal(values)
+	}
+}
+
+// replaceField replaces the string field with the result of fn and records the replacement.
+func replaceField(fields map[string]json.RawMessage, name string, replaced map[string]string, fn func(string) string) {
+	var value string
+	if err := json.Unmarshal(fields[name], &value); err != nil || value == "" {
+		return
+	}
+
+	if replacement := fn(value); replacement != value {
+		replaced[value] = replacement
+		fields[name], _ = json.Marshal(replacement)
+	}
+}
@@ -0,0 +1,76 @@
+package redact
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+const testRecords = `[
+{"type":6,"dnsType":"SOA","name":"example.com.","ttl":300,` +
+	`"rawText":"example.com. 300 IN SOA ns1.example.com. jane.example.com. 1 2 3 4 5",` +
+	`"admin":"jane.example.com.","host":"ns1.example.com.","serial":1},
+{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,` +
+	`"rawText":"example.com. 300 IN TXT \"google-site-verification=abc123\"",` +
+	`"strings":["google-site-verification=abc123"]},
+{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"strings":["v=spf1 -all"]},
+{"type":1,"dnsType":"A","name":"intranet.example.com.","ttl":300,` +
+	`"rawText":"intranet.example.com. 300 IN A 10.1.2.3","address":"10.1.2.3"},
+{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"}
+]`
+
+// TestRecords tests that the sensitive values are redacted in a copy of the records.
+func TestRecords(t *testing.T) {
+	var records dnslookupapi.DNSRecords
+	if err := json.Unmarshal([]byte(testRecords), &records); err != nil {
+		t.Fatal(err)
+	}
+
+	redacted, err := Records(&records, DefaultPolicy())
+	if err != nil {
+		t.Fatalf("Records() error = %v", err)
+	}
+
+	if soa := redacted.SOA[0]; soa.Admin != Mailbox || strings.Contains(soa.RawText, "jane") {
+		t.Errorf("SOA = %+v", soa)
+	}
+
+	txt := redacted.TXT[0]
+	if txt.Strings[0] != "google-site-verification="+Token || strings.Contains(txt.RawText, "abc123") {
+		t.Errorf("TXT = %+v", txt)
+	}
+
+	if redacted.TXT[1].Strings[0] != "v=spf1 -all" {
+		t.Errorf("TXT = %+v", redacted.TXT[1])
+	}
+
+	if a := redacted.A; a[0].Address != IPv4 || a[0].RawText != "intranet.example.com. 300 IN A 0.0.0.0" ||
+		a[1].Address != "192.0.2.1" {
+		t.Errorf("A = %+v", a)
+	}
+
+	if records.SOA[0].Admin != "jane.example.com." || records.A[0].Address != "10.1.2.3" {
+		t.Errorf("Records() modified the input: %+v, %+v", records.SOA[0], records.A[0])
+	}
+}
+
+// TestBody tests that the raw response body is redacted keeping the other fields.
+func TestBody(t *testing.T) {
+	body := []byte(`{"DNSData":{"domainName":"example.com","dnsTypes":"_all","dnsRecords":` + testRecords + `}}`)
+
+	redacted, err := Body(body, Policy{SOAAdmin: true})
+	if err != nil {
+		t.Fatalf("Body() error = %v", err)
+	}
+
+	if s := string(redacted); strings.Contains(s, "jane") || !strings.Contains(s, `"domainName":"example.com"`) ||
+		!strings.Contains(s, "10.1.2.3") || !strings.Contains(s, "abc123") {
+		t.Errorf("Body() = %s", redacted)
+	}
+
+	if _, err = Body([]byte(`{"ErrorMessage":{}}`), DefaultPolicy()); err == nil {
+		t.Error("Body() expected error for the body without DNSData")
+	}
+}
@@ -0,0 +1,97 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// defaultMaxRedirects is the default number of redirects followed by the client.
+const defaultMaxRedirects = 10
+
+var (
+	// ErrTooManyRedirects is returned when the API redirects more than allowed by ClientParams.MaxRedirects.
+	ErrTooManyRedirects = errors.New("too many redirects")
+
+	// ErrCrossHostRedirect is returned when the API redirects to another host
+	// and ClientParams.AllowCrossHostRedirects is not set.
+	ErrCrossHostRedirect = errors.New("redirect to another host")
+)
+
+// redirectTraceKey is the context key of the redirect trace.
+type redirectTraceKey struct{}
+
+// redirectTrace records redirects followed during a single API request.
+type redirectTrace struct {
+	mu    sync.Mutex
+	chain []string
+}
+
+// add records the redirect target.
+func (t *redirectTrace) add(u *url.URL) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.chain = append(t.chain, RedactURL(u))
+}
+
+// urls returns recorded redirect targets.
+func (t *redirectTrace) urls() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]string(nil), t.chain...)
+}
+
+// withRedirectTrace returns the context recording redirects to the trace.
+func withRedirectTrace(ctx context.Context, trace *redirectTrace) context.Context {
+	return context.WithValue(ctx, redirectTraceKey{}, trace)
+}
+
+// redirectPolicy is the redirect policy of the client.
+type redirectPolicy struct {
+	maxRedirects   int
+	allowCrossHost bool
+	next           func(req *http.Request, via []*http.Request) error
+}
+
+// checkRedirect implements http.Client CheckRedirect.
+func (p redirectPolicy) checkRedirect(req *http.Request, via []*http.Request) error {
+	if p.maxRedirects < 0 {
+		return http.ErrUseLastResponse
+	}
+
+	if trace, ok := req.Context().Value(redirectTraceKey{}).(*redirectTrace); ok {
+		trace.add(req.URL)
+	}
+
+	if len(via) > p.maxRedirects {
+		return ErrTooManyRedirects
+	}
+
+	if !p.allowCrossHost && len(via) > 0 && req.URL.Host != via[0].URL.Host {
+		return ErrCrossHostRedirect
+	}
+
+	if p.next != nil {
+		return p.next(req, via)
+	}
+
+	return nil
+}
+
+// newRedirectPolicy creates the redirect policy from the client parameters.
+func newRedirectPolicy(params ClientParams, next func(req *http.Request, via []*http.Request) error) redirectPolicy {
+	maxRedirects := params.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	return redirectPolicy{
+		maxRedirects:   maxRedirects,
+		allowCrossHost: params.AllowCrossHostRedirects,
+		next:           next,
+	}
+}
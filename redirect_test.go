@@ -0,0 +1,123 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestRedirects tests the redirect policy.
+func TestRedirects(t *testing.T) {
+	const resp = `{"DNSData":{"dnsRecords":[]}}`
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte(resp))
+		case "/one":
+			http.Redirect(w, req, "/ok?"+req.URL.RawQuery, http.StatusFound)
+		case "/two":
+			http.Redirect(w, req, "/one?"+req.URL.RawQuery, http.StatusMovedPermanently)
+		case "/other":
+			http.Redirect(w, req, other.URL+"/ok", http.StatusFound)
+		default:
+			panic(req.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		params    ClientParams
+		wantChain []string
+		wantErr   error
+		wantCode  int
+	}{
+		{
+			name:      "followed",
+			path:      "/two",
+			wantChain: []string{"/one", "/ok"},
+			wantCode:  http.StatusOK,
+		},
+		{
+			name:      "too many",
+			path:      "/two",
+			params:    ClientParams{MaxRedirects: 1},
+			wantChain: []string{"/one", "/ok"},
+			wantErr:   ErrTooManyRedirects,
+		},
+		{
+			name:     "not followed",
+			path:     "/two",
+			params:   ClientParams{MaxRedirects: -1},
+			wantCode: http.StatusMovedPermanently,
+		},
+		{
+			name:      "cross host",
+			path:      "/other",
+			wantChain: []string{"/ok"},
+			wantErr:   ErrCrossHostRedirect,
+		},
+		{
+			name:      "cross host allowed",
+			path:      "/other",
+			params:    ClientParams{AllowCrossHostRedirects: true},
+			wantChain: []string{"/ok"},
+			wantCode:  http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiURL, err := url.Parse(server.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.params.HTTPClient = server.Client()
+			tt.params.DNSLookupBaseURL = apiURL
+
+			resp, err := NewClient(apiKey, tt.params).GetRaw(context.Background(), "whoisxmlapi.com")
+			var respErr *ErrorResponse
+			if errors.As(err, &respErr) {
+				err = nil
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetRaw() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var chain []string
+			for _, u := range resp.Redirects {
+				if strings.Contains(u, apiKey) {
+					t.Errorf("redirect %s contains API key", u)
+				}
+
+				parsed, err := url.Parse(u)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				chain = append(chain, parsed.Path)
+			}
+
+			if !reflect.DeepEqual(chain, tt.wantChain) {
+				t.Errorf("Redirects = %v, want %v", chain, tt.wantChain)
+			}
+
+			if tt.wantErr == nil && resp.StatusCode != tt.wantCode {
+				t.Errorf("StatusCode = %v, want %v", resp.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+// Package report builds email deliverability posture reports from DNS Lookup API responses.
+package report
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+	"github.com/whois-api-llc/dns-lookup-go/spf"
+)
+
+// Severity is the severity of a finding.
+type Severity string
+
+// Severities of the findings.
+const (
+	SeverityInfo   Severity = "info"
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// Finding is a single machine-readable result of the posture check or of the lint package rules.
+type Finding struct {
+	// ID is the stable identifier of the check, e.g. "spf-missing".
+	ID string `json:"id"`
+
+	// Severity is the severity of the finding.
+	Severity Severity `json:"severity"`
+
+	// Message is the human-readable description of the finding.
+	Message string `json:"message"`
+
+	// Name is the owner name of the record the finding is about, if any.
+	Name string `json:"name,omitempty"`
+}
+
+// MXHost is a mail server of the domain.
+type MXHost struct {
+	// Target is the domain name of a mail server.
+	Target string `json:"target"`
+
+	// Priority is the priority field.
+	Priority int `json:"priority"`
+}
+
+// EmailPostureReport is the email deliverability posture of a domain.
+type EmailPostureReport struct {
+	// Domain is the checked domain name.
+	Domain string `json:"domain"`
+
+	// MX are the mail servers sorted by priority.
+	MX []MXHost `json:"mx"`
+
+	// SPF is the SPF record as a string.
+	SPF string `json:"spf,omitempty"`
+
+	// SPFValid reports whether the SPF record exists and has no syntax errors.
+	SPFValid bool `json:"spfValid"`
+
+	// SPFLookups is the number of DNS-querying SPF terms.
+	SPFLookups int `json:"spfLookups"`
+
+	// DMARCPolicy is the DMARC policy for the domain: none, quarantine, reject or empty if there is no record.
+	DMARCPolicy string `json:"dmarcPolicy,omitempty"`
+
+	// DMARCPercent is the percentage of messages the DMARC policy applies to.
+	DMARCPercent int `json:"dmarcPercent,omitempty"`
+
+	// BIMI reports whether the default BIMI record is published.
+	BIMI bool `json:"bimi"`
+
+	// MTASTS reports whether the MTA-STS record is published.
+	MTASTS bool `json:"mtaSts"`
+
+	// Findings are the results of the checks sorted by severity.
+	Findings []Finding `json:"findings"`
+}
+
+// MaxSeverity returns the highest severity of the findings or an empty string if there are none.
+func (r *EmailPostureReport) MaxSeverity() Severity {
+	if len(r.Findings) == 0 {
+		return ""
+	}
+
+	return r.Findings[0].Severity
+}
+
+// Records are the DNS records the report is built from.
+type Records struct {
+	// Domain holds MX and TXT records of the domain.
+	Domain *dnslookupapi.DNSRecords
+
+	// DMARC holds TXT records of _dmarc.<domain>.
+	DMARC *dnslookupapi.DNSRecords
+
+	// BIMI holds TXT records of default._bimi.<domain>.
+	BIMI *dnslookupapi.DNSRecords
+
+	// MTASTS holds TXT records of _mta-sts.<domain>.
+	MTASTS *dnslookupapi.DNSRecords
+}
+
+// Generate queries the records of the domain and builds the report.
+func Generate(ctx context.Context, service dnslookupapi.DNSLookupService, domainName string) (*EmailPostureReport, error) {
+	var (
+		records Records
+		err     error
+	)
+
+	lookups := []struct {
+		name  string
+		types string
+		dst   **dnslookupapi.DNSRecords
+	}{
+		{name: domainName, types: "MX,TXT", dst: &records.Domain},
+		{name: "_dmarc." + domainName, types: "TXT", dst: &records.DMARC},
+		{name: "default._bimi." + domainName, types: "TXT", dst: &records.BIMI},
+		{name: "_mta-sts." + domainName, types: "TXT", dst: &records.MTASTS},
+	}
+
+	for _, lookup := range lookups {
+		*lookup.dst, err = getRecords(ctx, service, lookup.name, lookup.types)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return Build(domainName, records), nil
+}
+
+// getRecords returns DNS records of the specified types.
+func getRecords(ctx context.Context, service dnslookupapi.DNSLookupService, domainName, types string) (
+	*dnslookupapi.DNSRecords, error) {
+	resp, _, err := service.Get(ctx, domainName, dnslookupapi.OptionType(types))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.ParsedRecords(), nil
+}
+
+// Build builds the report from already fetched records. Nil records are treated as empty.
+func Build(domainName string, records Records) *EmailPostureReport {
+	r := &EmailPostureReport{Domain: domainName}
+
+	r.checkMX(orEmpty(records.Domain).MX)
+	r.checkSPF(orEmpty(records.Domain).TXT)
+	r.checkDMARC(orEmpty(records.DMARC).TXT)
+
+	r.BIMI = hasPrefix(orEmpty(records.BIMI).TXT, "v=BIMI1")
+	if !r.BIMI {
+		r.add("bimi-missing", SeverityInfo, "BIMI record is not published")
+	}
+
+	r.MTASTS = hasPrefix(orEmpty(records.MTASTS).TXT, "v=STSv1")
+	if !r.MTASTS {
+		r.add("mta-sts-missing", SeverityLow, "MTA-STS record is not published")
+	}
+
+	SortFindings(r.Findings)
+
+	return r
+}
+
+// SortFindings sorts the findings by severity, the most severe first.
+// Findings of the same severity are kept in order.
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank[findings[i].Severity] > severityRank[findings[j].Severity]
+	})
+}
+
+// severityRank is used to sort the findings.
+var severityRank = map[Severity]int{
+	SeverityInfo:   0,
+	SeverityLow:    1,
+	SeverityMedium: 2,
+	SeverityHigh:   3,
+}
+
+// add adds the finding to the report.
+func (r *EmailPostureReport) add(id string, severity Severity, message string) {
+	r.Findings = append(r.Findings, Finding{ID: id, Severity: severity, Message: message})
+}
+
+// checkMX checks the mail servers of the domain.
+func (r *EmailPostureReport) checkMX(records []dnslookupapi.MXRecord) {
+	for _, record := range records {
+		r.MX = append(r.MX, MXHost{Target: record.Target, Priority: record.Priority})
+	}
+
+	sort.SliceStable(r.MX, func(i, j int) bool {
+		return r.MX[i].Priority < r.MX[j].Priority
+	})
+
+	switch {
+	case len(r.MX) == 0:
+		r.add("mx-missing", SeverityHigh, "no MX records, mail is delivered to the A record if any")
+	case len(r.MX) == 1 && (r.MX[0].Target == "." || r.MX[0].Target == ""):
+		r.add("mx-null", SeverityInfo, "null MX record, the domain does not accept mail")
+	case len(r.MX) == 1:
+		r.add("mx-single", SeverityLow, "only one MX record, there is no backup mail server")
+	}
+}
+
+// checkSPF checks the SPF policy of the domain.
+func (r *EmailPostureReport) checkSPF(records []dnslookupapi.TXTRecord) {
+	record, err := spf.FromTXT(records)
+
+	switch {
+	case errors.Is(err, spf.ErrNoRecord):
+		r.add("spf-missing", SeverityHigh, "SPF record is not published")
+		return
+	case errors.Is(err, spf.ErrMultipleRecords):
+		r.add("spf-multiple", SeverityHigh, "multiple SPF records are published, SPF evaluation fails")
+		return
+	case err != nil:
+		r.add("spf-invalid", SeverityHigh, err.Error())
+		return
+	}
+
+	r.SPF = record.Raw
+	r.SPFValid = record.Valid()
+	r.SPFLookups = record.DNSLookups()
+
+	for _, e := range record.Errors {
+		r.add("spf-syntax", SeverityHigh, e.Error())
+	}
+
+	if record.ExceedsLookupLimit() {
+		r.add("spf-lookup-limit", SeverityMedium, "SPF record exceeds the limit of 10 DNS lookups")
+	}
+
+	_, redirect := record.Modifier("redirect")
+
+	var all *spf.Mechanism
+	for i := range record.Mechanisms {
+		if record.Mechanisms[i].Name == "all" {
+			all = &record.Mechanisms[i]
+		}
+	}
+
+	switch {
+	case all == nil && !redirect:
+		r.add("spf-no-all", SeverityMedium, "SPF record has no all mechanism, the default result is neutral")
+	case all != nil && all.Qualifier == spf.Pass:
+		r.add("spf-pass-all", SeverityHigh, "SPF record allows any sender with +all")
+	case all != nil && all.Qualifier == spf.Neutral:
+		r.add("spf-neutral-all", SeverityMedium, "SPF record ends with ?all")
+	}
+}
+
+// checkDMARC checks the DMARC policy of the domain.
+func (r *EmailPostureReport) checkDMARC(records []dnslookupapi.TXTRecord) {
+	var found []string
+
+	for _, record := range records {
+		if txt := record.Value(); version(txt) == "v=dmarc1" {
+			found = append(found, txt)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		r.add("dmarc-missing", SeverityHigh, "DMARC record is not published")
+		return
+	case 1:
+	default:
+		r.add("dmarc-multiple", SeverityHigh, "multiple DMARC records are published, DMARC is not applied")
+		return
+	}
+
+	policy, err := dnslookupapi.ParseDMARC(found[0])
+	if err != nil {
+		r.add("dmarc-invalid", SeverityHigh, err.Error())
+		return
+	}
+
+	r.DMARCPolicy = policy.Policy
+	r.DMARCPercent = policy.Percent
+
+	switch policy.Policy {
+	case "reject":
+	case "quarantine":
+		r.add("dmarc-quarantine", SeverityInfo, "DMARC policy is quarantine")
+	case "none":
+		r.add("dmarc-none", SeverityMedium, "DMARC policy is none, failing messages are delivered")
+	default:
+		r.add("dmarc-invalid-policy", SeverityHigh, `DMARC policy "`+policy.Policy+`" is not valid`)
+	}
+
+	if policy.Percent < 100 {
+		r.add("dmarc-partial", SeverityLow, "DMARC policy applies only to a part of messages")
+	}
+
+	if len(policy.AggregateReportURIs) == 0 {
+		r.add("dmarc-no-reports", SeverityLow, "DMARC aggregate reports are not requested")
+	}
+}
+
+// orEmpty returns empty records instead of nil.
+func orEmpty(records *dnslookupapi.DNSRecords) *dnslookupapi.DNSRecords {
+	if records == nil {
+		return &dnslookupapi.DNSRecords{}
+	}
+
+	return records
+}
+
+// hasPrefix reports whether any of the TXT records starts with the version tag.
+func hasPrefix(records []dnslookupapi.TXTRecord, prefix string) bool {
+	for _, record := range records {
+		if version(record.Value()) == strings.ToLower(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// version returns the lower-case first tag of the tag=value list without spaces.
+func version(txt string) string {
+	if i := strings.IndexByte(txt, ';'); i >= 0 {
+		txt = txt[:i]
+	}
+
+	return strings.ToLower(strings.ReplaceAll(txt, " ", ""))
+}
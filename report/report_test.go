@@ -0,0 +1,132 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// fakeService returns prepared DNS records per domain name.
+type fakeService map[string]string
+
+// Get returns the prepared records of the domain name.
+func (f fakeService) Get(_ context.Context, domainName string, opts ...dnslookupapi.Option) (
+	*dnslookupapi.DNSLookupResponse, *dnslookupapi.Response, error) {
+	q := url.Values{}
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	if q.Get("type") == "" {
+		panic("type is not set")
+	}
+
+	var resp dnslookupapi.DNSLookupResponse
+
+	records, ok := f[domainName]
+	if !ok {
+		records = "[]"
+	}
+
+	if err := json.Unmarshal([]byte(`{"dnsRecords":`+records+`}`), &resp); err != nil {
+		return nil, nil, err
+	}
+
+	return &resp, nil, nil
+}
+
+// GetRaw is not used by the report.
+func (f fakeService) GetRaw(context.Context, string, ...dnslookupapi.Option) (*dnslookupapi.Response, error) {
+	panic("not implemented")
+}
+
+// findingIDs returns identifiers of the findings.
+func findingIDs(findings []Finding) []string {
+	var ids []string
+	for _, f := range findings {
+		ids = append(ids, f.ID)
+	}
+
+	return ids
+}
+
+// TestGenerate tests the Generate function.
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name         string
+		service      fakeService
+		wantMX       []MXHost
+		wantFindings []string
+		wantMax      Severity
+	}{
+		{
+			name: "good posture",
+			service: fakeService{
+				"example.com": `[
+{"dnsType":"MX","target":"mx2.example.com.","priority":20},
+{"dnsType":"MX","target":"mx1.example.com.","priority":10},
+{"dnsType":"TXT","strings":["v=spf1 mx -all"]}]`,
+				"_dmarc.example.com":        `[{"dnsType":"TXT","strings":["v=DMARC1; p=reject; rua=mailto:d@example.com"]}]`,
+				"default._bimi.example.com": `[{"dnsType":"TXT","strings":["v=BIMI1; l=https://example.com/logo.svg"]}]`,
+				"_mta-sts.example.com":      `[{"dnsType":"TXT","strings":["v=STSv1; id=20220101"]}]`,
+			},
+			wantMX: []MXHost{
+				{Target: "mx1.example.com.", Priority: 10},
+				{Target: "mx2.example.com.", Priority: 20},
+			},
+			wantFindings: nil,
+			wantMax:      "",
+		},
+		{
+			name: "weak posture",
+			service: fakeService{
+				"example.com": `[
+{"dnsType":"MX","target":"mx.example.com.","priority":10},
+{"dnsType":"TXT","strings":["v=spf1 include:a.example.com ip4:300.0.0.1 +all"]}]`,
+				"_dmarc.example.com": `[{"dnsType":"TXT","strings":["v=DMARC1; p=none; pct=50"]}]`,
+			},
+			wantMX: []MXHost{{Target: "mx.example.com.", Priority: 10}},
+			wantFindings: []string{
+				"spf-syntax", "spf-pass-all",
+				"dmarc-none",
+				"mx-single", "dmarc-partial", "dmarc-no-reports", "mta-sts-missing",
+				"bimi-missing",
+			},
+			wantMax: SeverityHigh,
+		},
+		{
+			name:    "nothing published",
+			service: fakeService{},
+			wantFindings: []string{
+				"mx-missing", "spf-missing", "dmarc-missing",
+				"mta-sts-missing",
+				"bimi-missing",
+			},
+			wantMax: SeverityHigh,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generate(context.Background(), tt.service, "example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got.MX, tt.wantMX) {
+				t.Errorf("MX = %v, want %v", got.MX, tt.wantMX)
+			}
+
+			if ids := findingIDs(got.Findings); !reflect.DeepEqual(ids, tt.wantFindings) {
+				t.Errorf("Findings = %v, want %v", ids, tt.wantFindings)
+			}
+
+			if got.MaxSeverity() != tt.wantMax {
+				t.Errorf("MaxSeverity() = %v, want %v", got.MaxSeverity(), tt.wantMax)
+			}
+		})
+	}
+}
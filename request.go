@@ -0,0 +1,162 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RecordType is the DNS record type name, e.g. "MX".
+type RecordType string
+
+// Frequently requested DNS record types.
+const (
+	RecordTypeAll    RecordType = "_all"
+	RecordTypeA      RecordType = "A"
+	RecordTypeAAAA   RecordType = "AAAA"
+	RecordTypeCNAME  RecordType = "CNAME"
+	RecordTypeMX     RecordType = "MX"
+	RecordTypeNS     RecordType = "NS"
+	RecordTypeSOA    RecordType = "SOA"
+	RecordTypeTXT    RecordType = "TXT"
+	RecordTypeCAA    RecordType = "CAA"
+	RecordTypeSRV    RecordType = "SRV"
+	RecordTypePTR    RecordType = "PTR"
+	RecordTypeDNSKEY RecordType = "DNSKEY"
+	RecordTypeDS     RecordType = "DS"
+)
+
+// reservedParams are the query parameters set by the client and Request fields.
+var reservedParams = []string{"apiKey", "domainName", "type", "outputFormat", "callback"}
+
+// Request is the lookup query built as a struct, an alternative to Get with variadic options.
+type Request struct {
+	// Domain is the domain name to look up
+	Domain string
+
+	// Types are the DNS record types to return
+	// If it's empty then all types are returned
+	Types []RecordType
+
+	// OutputFormat is the response format, JSON or XML
+	// If it's empty then JSON is used
+	OutputFormat string
+
+	// Callback is the JSONP callback function name, see OptionCallback
+	Callback string
+
+	// Params are additional query parameters, e.g. the ones not yet supported by the library
+	// They must not override the parameters set by the other fields
+	Params map[string]string
+}
+
+// Validate checks the request without sending it. It returns ArgError naming the first invalid field.
+func (r Request) Validate() error {
+	if err := validateDomainName(r.Domain); err != nil {
+		return err
+	}
+
+	for _, t := range r.Types {
+		name := strings.ToUpper(strings.TrimSpace(string(t)))
+		if name == "_ALL" || name == "ANY" {
+			continue
+		}
+
+		if _, ok := RRTypeCode(name); !ok {
+			return &ArgError{Name: "types", Message: "has unknown DNS type " + quote(string(t))}
+		}
+	}
+
+	switch strings.ToUpper(r.OutputFormat) {
+	case "", "JSON", "XML":
+	default:
+		return &ArgError{Name: "outputFormat", Message: "must be JSON or XML, got " + quote(r.OutputFormat)}
+	}
+
+	if r.Callback != "" {
+		if strings.EqualFold(r.OutputFormat, "XML") {
+			return &ArgError{Name: "callback", Message: "is supported only with JSON output format"}
+		}
+
+		if !isIdentifier(r.Callback) {
+			return &ArgError{Name: "callback", Message: "must be a JavaScript identifier, got " + quote(r.Callback)}
+		}
+	}
+
+	for name := range r.Params {
+		if containsString(reservedParams, name) {
+			return &ArgError{Name: "params", Message: "must not override " + quote(name)}
+		}
+	}
+
+	return nil
+}
+
+// Options returns the options equivalent to the request, excluding the domain name.
+func (r Request) Options() []Option {
+	var opts []Option
+
+	if len(r.Types) != 0 {
+		types := make([]string, 0, len(r.Types))
+		for _, t := range r.Types {
+			types = append(types, strings.TrimSpace(string(t)))
+		}
+
+		opts = append(opts, OptionType(strings.Join(types, ",")))
+	}
+
+	if r.OutputFormat != "" {
+		opts = append(opts, OptionOutputFormat(r.OutputFormat))
+	}
+
+	if r.Callback != "" {
+		opts = append(opts, OptionCallback(r.Callback))
+	}
+
+	if len(r.Params) != 0 {
+		names := make([]string, 0, len(r.Params))
+		for name := range r.Params {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			opts = append(opts, optionParam(name, r.Params[name]))
+		}
+	}
+
+	return opts
+}
+
+// GetWithRequest validates the request and looks it up like Get.
+func (c *Client) GetWithRequest(ctx context.Context, req Request) (*DNSLookupResponse, *Response, error) {
+	if err := req.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return c.Get(ctx, req.Domain, req.Options()...)
+}
+
+// optionParam sets the query parameter.
+func optionParam(name, value string) Option {
+	return func(v url.Values) {
+		v.Set(name, value)
+	}
+}
+
+// isIdentifier reports whether s is a JavaScript identifier of ASCII letters, digits, '_', '$' and '.'.
+func isIdentifier(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$' || r == '.':
+		case r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return s != ""
+}
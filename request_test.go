@@ -0,0 +1,77 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestRequestValidate tests the validation of the requests.
+func TestRequestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      Request
+		wantName string
+	}{
+		{name: "valid", req: Request{Domain: "example.com", Types: []RecordType{RecordTypeA, "type65280", "any"}}},
+		{name: "domain", req: Request{Domain: "https://example.com"}, wantName: "domainName"},
+		{name: "types", req: Request{Domain: "example.com", Types: []RecordType{"MAIL"}}, wantName: "types"},
+		{name: "output format", req: Request{Domain: "example.com", OutputFormat: "YAML"}, wantName: "outputFormat"},
+		{name: "callback", req: Request{Domain: "example.com", Callback: "f()"}, wantName: "callback"},
+		{
+			name:     "callback with XML",
+			req:      Request{Domain: "example.com", OutputFormat: "xml", Callback: "f"},
+			wantName: "callback",
+		},
+		{name: "params", req: Request{Domain: "example.com", Params: map[string]string{"type": "A"}}, wantName: "params"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantName == "" {
+				checkErr(t, err, "")
+				return
+			}
+
+			var argErr *ArgError
+			if !errors.As(err, &argErr) || argErr.Name != tt.wantName {
+				t.Errorf("Validate() error = %v, want ArgError of %s", err, tt.wantName)
+			}
+		})
+	}
+}
+
+// TestGetWithRequest tests that the request fields are sent as the query parameters.
+func TestGetWithRequest(t *testing.T) {
+	var query url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query = req.URL.Query()
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL})
+
+	_, _, err := client.GetWithRequest(context.Background(), Request{
+		Domain: "example.com",
+		Types:  []RecordType{RecordTypeMX, "txt"},
+		Params: map[string]string{"da": "2"},
+	})
+	if err != nil {
+		t.Fatalf("GetWithRequest() error = %v", err)
+	}
+
+	if query.Get("domainName") != "example.com" || query.Get("type") != "MX,TXT" || query.Get("da") != "2" {
+		t.Errorf("GetWithRequest() query = %v", query)
+	}
+
+	if _, _, err = client.GetWithRequest(context.Background(), Request{}); err == nil {
+		t.Error("GetWithRequest() expected error for empty domain")
+	}
+}
@@ -0,0 +1,80 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy repeats the requests failed with transient errors: transport errors, 5xx and 429 responses.
+// Requests canceled or timed out by the context or the lookup timeout are not repeated.
+// The delay before a retry doubles with every retry; a longer Retry-After delay requested by the server is
+// respected. It is safe for concurrent use and can be shared by several clients.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a request
+	MaxRetries int
+
+	// Backoff is the delay before the first retry
+	// If it's zero then 500 milliseconds is used
+	Backoff time.Duration
+
+	// MaxBackoff caps the delay before a retry
+	// If it's zero then 30 seconds is used
+	MaxBackoff time.Duration
+}
+
+// shouldRetry reports whether the request which failed retries times already must be repeated.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, retries int, resp *Response, err error) bool {
+	if p == nil || retries >= p.MaxRetries || ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpResp *http.Response
+	if resp != nil {
+		httpResp = resp.Response
+	}
+
+	return isFailure(httpResp, err)
+}
+
+// delay returns the delay before the retry following the given number of retries.
+func (p *RetryPolicy) delay(retries int, resp *Response) time.Duration {
+	backoff, maxBackoff := p.Backoff, p.MaxBackoff
+	if backoff <= 0 {
+		backoff = 500 * time.Millisecond
+	}
+
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	d := backoff
+	for i := 0; i < retries && d < maxBackoff; i++ {
+		d *= 2
+	}
+
+	if resp != nil && resp.RateLimit.RetryAfter > d {
+		d = resp.RateLimit.RetryAfter
+	}
+
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+
+	return d
+}
+
+// wait sleeps for the delay or until the context is done.
+func (p *RetryPolicy) wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRetryPolicy tests that transient failures are retried up to MaxRetries.
+func TestRetryPolicy(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[]}}`))
+		}
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+	recorder := &eventRecorder{}
+
+	client := New(apiKey,
+		ClientOptionHTTPClient(server.Client()),
+		ClientOptionBaseURL(baseURL),
+		ClientOptionLogger(recorder),
+		ClientOptionRetry(&RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}),
+	)
+
+	if _, _, err := client.Get(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Get() made %d requests, want 3", calls)
+	}
+
+	var retries int
+
+	for _, event := range recorder.events {
+		if event.Kind == LogRetry {
+			retries++
+		}
+	}
+
+	if retries != 2 {
+		t.Errorf("Get() logged %d retries, want 2", retries)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+
+	client = New(apiKey,
+		ClientOptionHTTPClient(server.Client()),
+		ClientOptionBaseURL(baseURL),
+		ClientOptionRetry(&RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond}),
+	)
+
+	if _, _, err := client.Get(context.Background(), "example.com"); err == nil {
+		t.Error("Get() error = nil, want throttled error")
+	}
+
+	if calls != 2 {
+		t.Errorf("Get() made %d requests, want 2", calls)
+	}
+}
+
+// TestRetryPolicyDelay tests the backoff of the retries.
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	for retries, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if got := policy.delay(retries, nil); got != want {
+			t.Errorf("delay(%d) = %v, want %v", retries, got, want)
+		}
+	}
+
+	resp := &Response{RateLimit: RateLimit{RetryAfter: 3 * time.Second}}
+	if got := policy.delay(0, resp); got != 3*time.Second {
+		t.Errorf("delay() with Retry-After = %v", got)
+	}
+}
@@ -0,0 +1,89 @@
+package dnslookupapi
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// rrTypeNames maps the IANA DNS resource record type codes to their names.
+var rrTypeNames = map[int]string{
+	1: "A", 2: "NS", 3: "MD", 4: "MF", 5: "CNAME", 6: "SOA", 7: "MB", 8: "MG", 9: "MR", 10: "NULL",
+	11: "WKS", 12: "PTR", 13: "HINFO", 14: "MINFO", 15: "MX", 16: "TXT", 17: "RP", 18: "AFSDB",
+	19: "X25", 20: "ISDN", 21: "RT", 22: "NSAP", 23: "NSAP-PTR", 24: "SIG", 25: "KEY", 26: "PX",
+	27: "GPOS", 28: "AAAA", 29: "LOC", 30: "NXT", 31: "EID", 32: "NIMLOC", 33: "SRV", 34: "ATMA",
+	35: "NAPTR", 36: "KX", 37: "CERT", 38: "A6", 39: "DNAME", 40: "SINK", 41: "OPT", 42: "APL",
+	43: "DS", 44: "SSHFP", 45: "IPSECKEY", 46: "RRSIG", 47: "NSEC", 48: "DNSKEY", 49: "DHCID",
+	50: "NSEC3", 51: "NSEC3PARAM", 52: "TLSA", 53: "SMIMEA", 55: "HIP", 56: "NINFO", 57: "RKEY",
+	58: "TALINK", 59: "CDS", 60: "CDNSKEY", 61: "OPENPGPKEY", 62: "CSYNC", 63: "ZONEMD", 64: "SVCB",
+	65: "HTTPS", 99: "SPF", 100: "UINFO", 101: "UID", 102: "GID", 103: "UNSPEC", 104: "NID",
+	105: "L32", 106: "L64", 107: "LP", 108: "EUI48", 109: "EUI64", 249: "TKEY", 250: "TSIG",
+	251: "IXFR", 252: "AXFR", 253: "MAILB", 254: "MAILA", 255: "ANY", 256: "URI", 257: "CAA",
+	258: "AVC", 259: "DOA", 260: "AMTRELAY", 32768: "TA", 32769: "DLV",
+}
+
+// rrTypeCodes maps the DNS resource record type names to their codes.
+var rrTypeCodes = func() map[string]int {
+	codes := make(map[string]int, len(rrTypeNames))
+	for code, name := range rrTypeNames {
+		codes[name] = code
+	}
+
+	return codes
+}()
+
+// RRTypeName returns the name of the DNS resource record type code, e.g. "MX" for 15.
+// Codes without a registered name are returned in the RFC 3597 form, e.g. "TYPE65280".
+func RRTypeName(code int) string {
+	if name, ok := rrTypeNames[code]; ok {
+		return name
+	}
+
+	return "TYPE" + strconv.Itoa(code)
+}
+
+// RRTypeCode returns the code of the DNS resource record type name, e.g. 15 for "MX".
+// The name is case-insensitive; the RFC 3597 form, e.g. "TYPE65280", is accepted too.
+func RRTypeCode(name string) (int, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	if code, ok := rrTypeCodes[name]; ok {
+		return code, true
+	}
+
+	if strings.HasPrefix(name, "TYPE") {
+		code, err := strconv.ParseUint(name[len("TYPE"):], 10, 16)
+		if err == nil {
+			return int(code), true
+		}
+	}
+
+	return 0, false
+}
+
+// OptionTypeCodes sets types of DNS records that should be returned by their IANA codes,
+// e.g. OptionTypeCodes(1, 15) is the same as OptionType("A,MX").
+func OptionTypeCodes(codes ...int) Option {
+	names := make([]string, 0, len(codes))
+	for _, code := range codes {
+		names = append(names, RRTypeName(code))
+	}
+
+	return func(v url.Values) {
+		v.Set("type", strings.Join(names, ","))
+	}
+}
+
+// resolveDNSType returns the canonical name of the DNS type of the record.
+// If the name is missing or not registered, the type is resolved from the code.
+func resolveDNSType(dnsType string, code int) string {
+	if c, ok := RRTypeCode(dnsType); ok {
+		return RRTypeName(c)
+	}
+
+	if _, ok := rrTypeNames[code]; ok {
+		return rrTypeNames[code]
+	}
+
+	return dnsType
+}
@@ -0,0 +1,82 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+// TestRRTypeRegistry tests the mapping between type codes and names.
+func TestRRTypeRegistry(t *testing.T) {
+	names := map[int]string{1: "A", 15: "MX", 65: "HTTPS", 257: "CAA", 32769: "DLV", 65280: "TYPE65280"}
+	for code, name := range names {
+		if got := RRTypeName(code); got != name {
+			t.Errorf("RRTypeName(%d) = %v, want %v", code, got, name)
+		}
+
+		if got, ok := RRTypeCode(name); !ok || got != code {
+			t.Errorf("RRTypeCode(%v) = %d, %v, want %d", name, got, ok, code)
+		}
+	}
+
+	if code, ok := RRTypeCode(" mx "); !ok || code != 15 {
+		t.Errorf("RRTypeCode(mx) = %d, %v", code, ok)
+	}
+
+	for _, name := range []string{"", "BOGUS", "TYPE", "TYPE70000"} {
+		if code, ok := RRTypeCode(name); ok {
+			t.Errorf("RRTypeCode(%q) = %d, want not found", name, code)
+		}
+	}
+
+	for code, name := range rrTypeNames {
+		if rrTypeCodes[name] != code {
+			t.Errorf("duplicate type name %v", name)
+		}
+	}
+}
+
+// TestOptionTypeCodes tests the numeric type option.
+func TestOptionTypeCodes(t *testing.T) {
+	v := url.Values{}
+	OptionTypeCodes(1, 28, 15, 65280)(v)
+
+	if got := v.Get("type"); got != "A,AAAA,MX,TYPE65280" {
+		t.Errorf("type = %v", got)
+	}
+}
+
+// TestResolveDNSType tests resolving of missing and nonstandard type names of records.
+func TestResolveDNSType(t *testing.T) {
+	const records = `[
+		{"type":15,"name":"example.com.","target":"mx.example.com.","priority":10},
+		{"type":1,"dnsType":"a","name":"example.com.","address":"192.0.2.1"},
+		{"type":65,"dnsType":"","name":"example.com."},
+		{"type":0,"dnsType":"BOGUS","name":"example.com."}
+	]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"MX", "A", "HTTPS", "BOGUS"}
+	for i, record := range r.All {
+		if record.CommonFields.DNSType != want[i] {
+			t.Errorf("record %d DNSType = %v, want %v", i, record.CommonFields.DNSType, want[i])
+		}
+	}
+
+	if len(r.MX) != 1 || r.MX[0].DNSType != "MX" || len(r.A) != 1 || r.A[0].DNSType != "A" {
+		t.Errorf("typed records = %+v %+v", r.MX, r.A)
+	}
+
+	var lazy LazyDNSRecords
+	if err := json.Unmarshal([]byte(records), &lazy); err != nil {
+		t.Fatal(err)
+	}
+
+	if mx := lazy.Type("MX"); len(mx) != 1 || mx[0].GetDNSType() != "MX" {
+		t.Errorf("lazy Type(MX) = %+v", mx)
+	}
+}
@@ -0,0 +1,90 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// SchemaError is returned in the schema validation mode when the response has fields
+// or record types not known to the library.
+type SchemaError struct {
+	// Errors are the messages describing differences from the known schema.
+	Errors []string
+
+	// Violations are the structured differences from the API schema.
+	// They are set only by ValidateResponse and ClientParams.ValidateJSONSchema.
+	Violations []SchemaViolation
+}
+
+// Error returns error message as a string.
+func (e *SchemaError) Error() string {
+	msg := "response does not match the schema"
+	for i, err := range e.Errors {
+		if i == 0 {
+			msg += ": " + err
+		} else {
+			msg += "; " + err
+		}
+	}
+
+	return msg
+}
+
+// schemaResponse mirrors apiResponse with DNS records kept raw for per-record validation.
+type schemaResponse struct {
+	DNSData *struct {
+		DomainName string            `json:"domainName"`
+		Types      []int             `json:"types"`
+		DNSTypes   string            `json:"dnsTypes"`
+		Audit      Audit             `json:"audit"`
+		DNSRecords []json.RawMessage `json:"dnsRecords"`
+	} `json:"DNSData"`
+	ErrorMessage *ErrorMessage `json:"ErrorMessage"`
+}
+
+// decodeStrict decodes JSON disallowing unknown fields.
+func decodeStrict(raw []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
+
+// validateSchema checks that the raw API response has no fields or record types unknown to the library.
+func validateSchema(raw []byte) error {
+	var response schemaResponse
+	if err := decodeStrict(raw, &response); err != nil {
+		return &SchemaError{Errors: []string{err.Error()}}
+	}
+
+	if response.DNSData == nil {
+		return nil
+	}
+
+	var errs []string
+
+	for i, record := range response.DNSData.DNSRecords {
+		var common commonFields
+		if err := json.Unmarshal(record, &common); err != nil {
+			errs = append(errs, "record "+strconv.Itoa(i)+": "+err.Error())
+			continue
+		}
+
+		actual := actualDNSType(common.DNSType)
+		if actual == nil {
+			errs = append(errs, "record "+strconv.Itoa(i)+": "+ErrUnsupportedDNSType.Error()+" "+common.DNSType)
+			continue
+		}
+
+		if err := decodeStrict(record, actual); err != nil {
+			errs = append(errs, "record "+strconv.Itoa(i)+" ("+common.DNSType+"): "+err.Error())
+		}
+	}
+
+	if len(errs) != 0 {
+		return &SchemaError{Errors: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestUnknownRecord tests that records of unsupported types are preserved.
+func TestUnknownRecord(t *testing.T) {
+	const records = `[{"type":65534,"dnsType":"FUTURE","name":"example.com.","ttl":300,"data":[1,2],"big":12345678901234567890}]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(r.Unknown) != 1 || !errors.Is(r.All[0].ParseError, ErrUnsupportedDNSType) {
+		t.Fatalf("Unknown = %+v, All = %+v", r.Unknown, r.All)
+	}
+
+	unknown := r.Unknown[0]
+	if unknown.GetDNSType() != "FUTURE" || unknown.GetTypeCode() != 65534 {
+		t.Errorf("Unknown[0] = %+v", unknown)
+	}
+
+	want := map[string]interface{}{
+		"type":    json.Number("65534"),
+		"dnsType": "FUTURE",
+		"name":    "example.com.",
+		"ttl":     json.Number("300"),
+		"data":    []interface{}{json.Number("1"), json.Number("2")},
+		"big":     json.Number("12345678901234567890"),
+	}
+	if !reflect.DeepEqual(unknown.Fields, want) {
+		t.Errorf("Fields = %v, want %v", unknown.Fields, want)
+	}
+}
+
+// TestValidateSchema tests the validateSchema function.
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			raw:  `{"DNSData":{"domainName":"example.com","types":[1],"dnsTypes":"A","dnsRecords":[{"type":1,"dnsType":"A","address":"1.1.1.1"}]}}`,
+		},
+		{
+			name:    "unknown top-level field",
+			raw:     `{"DNSData":{"domainName":"example.com","newField":1}}`,
+			wantErr: `response does not match the schema: json: unknown field "newField"`,
+		},
+		{
+			name: "unknown record field and type",
+			raw:  `{"DNSData":{"dnsRecords":[{"dnsType":"NS","target":"ns.","additionalName":"ns."},{"dnsType":"FUTURE"}]}}`,
+			wantErr: `response does not match the schema: record 0 (NS): json: unknown field "additionalName"; ` +
+				`record 1: unknown DNS type FUTURE`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkErr(t, validateSchema([]byte(tt.raw)), tt.wantErr)
+		})
+	}
+}
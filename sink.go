@@ -0,0 +1,322 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the header carrying the webhook payload signature.
+	SignatureHeader = "X-DNSLookup-Signature"
+
+	defaultWebhookRetries = 3
+	defaultWebhookBackoff = time.Second
+)
+
+// ErrInvalidSignature is returned by VerifyWebhookSignature when the signature doesn't match the payload.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// EventSink receives the events of the Monitor, e.g. to push them to an incident tool.
+type EventSink interface {
+	// Send delivers the event.
+	Send(ctx context.Context, event ChangeEvent) error
+}
+
+// ChannelSink is the EventSink sending events to the channel.
+type ChannelSink chan<- ChangeEvent
+
+// Send sends the event to the channel, blocking until it's received or the context is done.
+func (s ChannelSink) Send(ctx context.Context, event ChangeEvent) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s <- event:
+		return nil
+	}
+}
+
+// WebhookSink is the EventSink posting events as JSON to the HTTP endpoint.
+// Requests failing with a transport error, 429 or 5xx status code are retried with exponential backoff.
+type WebhookSink struct {
+	// URL is the webhook endpoint.
+	URL string
+
+	// Secret is the key the payload is signed with. If it's empty then the payload is not signed
+	// Otherwise SignatureHeader is set to "t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<payload>">"
+	Secret []byte
+
+	// HTTPClient is the client used to post events
+	// If it's nil then http.DefaultClient is used
+	HTTPClient *http.Client
+
+	// Format encodes the event as the request body
+	// If it's nil then WebhookPayload encoded as JSON is posted
+	Format func(ChangeEvent) ([]byte, error)
+
+	// MaxRetries is the maximum number of retries
+	// If it's zero then failed requests are retried 3 times, if it's negative then they are not retried
+	MaxRetries int
+
+	// Backoff is the delay before the first retry, doubled for every next retry
+	// If it's zero then it's one second
+	Backoff time.Duration
+
+	now func() time.Time
+}
+
+var _ EventSink = &WebhookSink{}
+
+// NewWebhookSink creates WebhookSink posting to the URL and signing the payload with the secret.
+func NewWebhookSink(url string, secret []byte) *WebhookSink {
+	return &WebhookSink{URL: url, Secret: secret}
+}
+
+// WebhookPayload is the JSON payload posted by WebhookSink by default.
+type WebhookPayload struct {
+	// DomainName is the monitored domain name.
+	DomainName string `json:"domainName"`
+
+	// Time is the time of the lookup.
+	Time time.Time `json:"time"`
+
+	// Changes are the changes since the previous lookup.
+	Changes []WebhookChange `json:"changes"`
+
+	// Error is the lookup error message, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// WebhookChange is a single change in WebhookPayload.
+type WebhookChange struct {
+	// Kind is the kind of the change.
+	Kind ChangeKind `json:"kind"`
+
+	// DNSType is the DNS record type.
+	DNSType string `json:"dnsType"`
+
+	// Name is the owner name of the record.
+	Name string `json:"name"`
+
+	// Old is the previous raw record. It's omitted for added records.
+	Old json.RawMessage `json:"old,omitempty"`
+
+	// New is the current raw record. It's omitted for removed records.
+	New json.RawMessage `json:"new,omitempty"`
+}
+
+// NewWebhookPayload creates the payload of the event.
+func NewWebhookPayload(event ChangeEvent) WebhookPayload {
+	payload := WebhookPayload{
+		DomainName: event.DomainName,
+		Time:       event.Time,
+		Changes:    make([]WebhookChange, 0, len(event.Changes)),
+	}
+
+	if event.Err != nil {
+		payload.Error = event.Err.Error()
+	}
+
+	for _, c := range event.Changes {
+		change := WebhookChange{Kind: c.Kind}
+
+		if c.Old != nil {
+			change.DNSType, change.Name, change.Old = c.Old.CommonFields.DNSType, c.Old.CommonFields.Name, c.Old.Raw
+		}
+
+		if c.New != nil {
+			change.DNSType, change.Name, change.New = c.New.CommonFields.DNSType, c.New.CommonFields.Name, c.New.Raw
+		}
+
+		payload.Changes = append(payload.Changes, change)
+	}
+
+	return payload
+}
+
+// SlackFormat formats the event as a Slack incoming webhook message.
+func SlackFormat(event ChangeEvent) ([]byte, error) {
+	return json.Marshal(struct {
+		Text string `json:"text"`
+	}{Text: summarizeEvent(event)})
+}
+
+// summarizeEvent returns the human-readable description of the event.
+func summarizeEvent(event ChangeEvent) string {
+	var b strings.Builder
+
+	if event.Err != nil {
+		b.WriteString(event.DomainName + ": lookup failed: " + event.Err.Error())
+		return b.String()
+	}
+
+	b.WriteString(event.DomainName + ": " + strconv.Itoa(len(event.Changes)) + " DNS record change(s)")
+
+	for _, c := range event.Changes {
+		switch c.Kind {
+		case ChangeAdded:
+			b.WriteString("\n+ " + describeRecord(c.New))
+		case ChangeRemoved:
+			b.WriteString("\n- " + describeRecord(c.Old))
+		case ChangeModified:
+			b.WriteString("\n~ " + describeRecord(c.Old) + " -> " + describeRecord(c.New))
+		}
+	}
+
+	return b.String()
+}
+
+// describeRecord returns the raw text of the record or its type and owner name.
+func describeRecord(record *DNSRecord) string {
+	if text := strings.Join(strings.Fields(record.CommonFields.RawText), " "); text != "" {
+		return text
+	}
+
+	return record.CommonFields.Name + " " + record.CommonFields.DNSType
+}
+
+// Send posts the event to the webhook.
+func (s *WebhookSink) Send(ctx context.Context, event ChangeEvent) error {
+	body, err := s.format(event)
+	if err != nil {
+		return fmt.Errorf("cannot encode event: %w", err)
+	}
+
+	retries := s.MaxRetries
+	if retries == 0 {
+		retries = defaultWebhookRetries
+	}
+
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = defaultWebhookBackoff
+	}
+
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, body)
+		if err == nil || !retry || attempt >= retries {
+			return err
+		}
+
+		timer := time.NewTimer(backoff << attempt)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// format encodes the event.
+func (s *WebhookSink) format(event ChangeEvent) ([]byte, error) {
+	if s.Format != nil {
+		return s.Format(event)
+	}
+
+	return json.Marshal(NewWebhookPayload(event))
+}
+
+// post posts the body once. It reports whether the failed request should be retried.
+func (s *WebhookSink) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", mediaType)
+	req.Header.Set("User-Agent", userAgent)
+
+	if len(s.Secret) != 0 {
+		now := time.Now
+		if s.now != nil {
+			now = s.now
+		}
+
+		req.Header.Set(SignatureHeader, SignWebhookPayload(s.Secret, now(), body))
+	}
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("cannot post event: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if c := resp.StatusCode; c < 200 || c > 299 {
+		return c == http.StatusTooManyRequests || c >= 500,
+			errors.New("webhook failed with status code: " + strconv.Itoa(c))
+	}
+
+	return false, nil
+}
+
+// SignWebhookPayload returns the SignatureHeader value of the payload sent at the time.
+func SignWebhookPayload(secret []byte, t time.Time, payload []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+
+	return "t=" + ts + ",v1=" + hex.EncodeToString(webhookMAC(secret, ts, payload))
+}
+
+// VerifyWebhookSignature checks the SignatureHeader value of the received payload.
+// Signatures older than tolerance are rejected to prevent replays; zero tolerance disables the check.
+func VerifyWebhookSignature(secret []byte, signature string, payload []byte, tolerance time.Duration) error {
+	var ts, v1 string
+
+	for _, part := range strings.Split(signature, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		switch kv[0] {
+		case "t":
+			ts = kv[1]
+		case "v1":
+			v1 = kv[1]
+		}
+	}
+
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || v1 == "" {
+		return ErrInvalidSignature
+	}
+
+	mac, err := hex.DecodeString(v1)
+	if err != nil || !hmac.Equal(mac, webhookMAC(secret, ts, payload)) {
+		return ErrInvalidSignature
+	}
+
+	if age := time.Since(time.Unix(sec, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return fmt.Errorf("%w: timestamp is outside the tolerance", ErrInvalidSignature)
+	}
+
+	return nil
+}
+
+// webhookMAC returns HMAC-SHA256 of "<timestamp>.<payload>".
+func webhookMAC(secret []byte, ts string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts + "."))
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
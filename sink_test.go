@@ -0,0 +1,181 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWebhookSink tests posting, retrying and signing of events.
+func TestWebhookSink(t *testing.T) {
+	secret := []byte("s3cret")
+
+	var (
+		calls    int32
+		payloads = make(chan WebhookPayload, 1)
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if err := VerifyWebhookSignature(secret, r.Header.Get(SignatureHeader), body, time.Minute); err != nil {
+			t.Errorf("VerifyWebhookSignature() error = %v", err)
+		}
+
+		var payload WebhookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Error(err)
+		}
+
+		payloads <- payload
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, secret)
+	sink.Backoff = time.Millisecond
+
+	event := ChangeEvent{
+		DomainName: "example.com",
+		Time:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Changes: []RecordChange{{
+			Kind: ChangeAdded,
+			New: &DNSRecord{
+				CommonFields: commonFields{DNSType: "A", Name: "example.com."},
+				Raw:          json.RawMessage(`{"dnsType":"A","address":"1.1.1.1"}`),
+			},
+		}},
+	}
+
+	if err := sink.Send(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+
+	payload := <-payloads
+	if payload.DomainName != "example.com" || len(payload.Changes) != 1 ||
+		payload.Changes[0].DNSType != "A" || payload.Changes[0].Kind != ChangeAdded || payload.Changes[0].Old != nil {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failing.Close()
+
+	sink = &WebhookSink{URL: failing.URL, Backoff: time.Hour}
+	if err := sink.Send(context.Background(), event); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Send() error = %v", err)
+	}
+}
+
+// TestVerifyWebhookSignature tests signature verification.
+func TestVerifyWebhookSignature(t *testing.T) {
+	secret := []byte("s3cret")
+	payload := []byte(`{"domainName":"example.com"}`)
+	now := time.Now()
+
+	signature := SignWebhookPayload(secret, now, payload)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		signature string
+		payload   []byte
+		tolerance time.Duration
+		ok        bool
+	}{
+		{"valid", secret, signature, payload, time.Minute, true},
+		{"wrong secret", []byte("other"), signature, payload, time.Minute, false},
+		{"tampered", secret, signature, []byte(`{}`), time.Minute, false},
+		{"malformed", secret, "v1=abc", payload, time.Minute, false},
+		{"expired", secret, SignWebhookPayload(secret, now.Add(-time.Hour), payload), payload, time.Minute, false},
+		{"no tolerance", secret, SignWebhookPayload(secret, now.Add(-time.Hour), payload), payload, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature(tt.secret, tt.signature, tt.payload, tt.tolerance)
+			if (err == nil) != tt.ok || (err != nil && !errors.Is(err, ErrInvalidSignature)) {
+				t.Errorf("VerifyWebhookSignature() error = %v", err)
+			}
+		})
+	}
+}
+
+// TestSlackFormat tests the Slack message format.
+func TestSlackFormat(t *testing.T) {
+	old := &DNSRecord{CommonFields: commonFields{RawText: "example.com.\t300\tIN\tA\t1.1.1.1"}}
+	new := &DNSRecord{CommonFields: commonFields{RawText: "example.com.\t300\tIN\tA\t2.2.2.2"}}
+
+	b, err := SlackFormat(ChangeEvent{
+		DomainName: "example.com",
+		Changes:    []RecordChange{{Kind: ChangeRemoved, Old: old}, {Kind: ChangeAdded, New: new}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var message struct {
+		Text string `json:"text"`
+	}
+
+	if err = json.Unmarshal(b, &message); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "example.com: 2 DNS record change(s)\n- example.com. 300 IN A 1.1.1.1\n+ example.com. 300 IN A 2.2.2.2"
+	if message.Text != want {
+		t.Errorf("text = %q, want %q", message.Text, want)
+	}
+}
+
+// TestMonitorSinks tests delivering of events to sinks.
+func TestMonitorSinks(t *testing.T) {
+	service := &sequenceService{records: []string{""}}
+	events := make(chan ChangeEvent, 1)
+
+	var sinkErrors int32
+
+	monitor := NewMonitor(service, []string{"example.com"}, MonitorParams{
+		Interval: time.Hour,
+		Sinks:    []EventSink{&WebhookSink{URL: "http://127.0.0.1:0", MaxRetries: -1}, ChannelSink(events)},
+		OnSinkError: func(EventSink, ChangeEvent, error) {
+			atomic.AddInt32(&sinkErrors, 1)
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = monitor.Run(ctx)
+	}()
+
+	if event := <-events; event.Err == nil {
+		t.Errorf("event = %+v", event)
+	}
+
+	cancel()
+
+	if _, ok := <-monitor.Events(); ok {
+		t.Error("event delivered to the Events channel")
+	}
+
+	if atomic.LoadInt32(&sinkErrors) != 1 {
+		t.Errorf("sink errors = %d, want 1", sinkErrors)
+	}
+}
@@ -0,0 +1,111 @@
+package dnslookupapi
+
+import (
+	"strconv"
+	"time"
+)
+
+// SOAWarningCode identifies the SOA sanity check which failed.
+type SOAWarningCode string
+
+// SOA sanity checks based on RIPE-203 and RFC 1912 recommendations.
+const (
+	SOARefreshTooLow        SOAWarningCode = "refresh-too-low"
+	SOARefreshTooHigh       SOAWarningCode = "refresh-too-high"
+	SOARetryTooLow          SOAWarningCode = "retry-too-low"
+	SOARetryNotBelowRefresh SOAWarningCode = "retry-not-below-refresh"
+	SOAExpireTooLow         SOAWarningCode = "expire-too-low"
+	SOAExpireTooHigh        SOAWarningCode = "expire-too-high"
+	SOAExpireBelowRefresh   SOAWarningCode = "expire-below-refresh"
+	SOAMinimumTooLow        SOAWarningCode = "minimum-too-low"
+	SOAMinimumTooHigh       SOAWarningCode = "minimum-too-high"
+	SOASerialNotDate        SOAWarningCode = "serial-not-date"
+)
+
+// Recommended SOA timer ranges in seconds.
+const (
+	soaMinRefresh = 1200
+	soaMaxRefresh = 86400
+	soaMinRetry   = 120
+	soaMinExpire  = 604800
+	soaMaxExpire  = 3600000
+	soaMinMinimum = 300
+	soaMaxMinimum = 86400
+)
+
+// SOAWarning is the failed SOA sanity check.
+type SOAWarning struct {
+	// Code identifies the check
+	Code SOAWarningCode
+
+	// Field is the name of the SOARecord field the warning is about
+	Field string
+
+	// Message is the human-readable description of the problem
+	Message string
+}
+
+// Check inspects the SOA timers and the serial number against RIPE-203 style recommendations:
+// refresh between 20 minutes and 1 day, retry of at least 2 minutes and below refresh,
+// expire between 1 week and 1000 hours and well above refresh plus retry,
+// negative caching TTL between 5 minutes and 1 day and the YYYYMMDDnn serial format.
+// It returns nil if all checks pass.
+func (r SOARecord) Check() []SOAWarning {
+	var warnings []SOAWarning
+
+	add := func(code SOAWarningCode, field, message string) {
+		warnings = append(warnings, SOAWarning{Code: code, Field: field, Message: message})
+	}
+
+	switch {
+	case r.Refresh < soaMinRefresh:
+		add(SOARefreshTooLow, "Refresh", "refresh "+r.RefreshDuration().String()+" is below 20m")
+	case r.Refresh > soaMaxRefresh:
+		add(SOARefreshTooHigh, "Refresh", "refresh "+r.RefreshDuration().String()+" is above 24h")
+	}
+
+	if r.Retry < soaMinRetry {
+		add(SOARetryTooLow, "Retry", "retry "+r.RetryDuration().String()+" is below 2m")
+	}
+
+	if r.Retry >= r.Refresh {
+		add(SOARetryNotBelowRefresh, "Retry",
+			"retry "+r.RetryDuration().String()+" is not below refresh "+r.RefreshDuration().String())
+	}
+
+	switch {
+	case r.Expire < r.Refresh+r.Retry:
+		add(SOAExpireBelowRefresh, "Expire",
+			"expire "+r.ExpireDuration().String()+" is below refresh plus retry, secondaries may drop the zone")
+	case r.Expire < soaMinExpire:
+		add(SOAExpireTooLow, "Expire", "expire "+r.ExpireDuration().String()+" is below 1 week")
+	case r.Expire > soaMaxExpire:
+		add(SOAExpireTooHigh, "Expire", "expire "+r.ExpireDuration().String()+" is above 1000h")
+	}
+
+	switch {
+	case r.Minimum < soaMinMinimum:
+		add(SOAMinimumTooLow, "Minimum", "negative caching TTL "+r.MinimumTTL().String()+" is below 5m")
+	case r.Minimum > soaMaxMinimum:
+		add(SOAMinimumTooHigh, "Minimum", "negative caching TTL "+r.MinimumTTL().String()+" is above 24h")
+	}
+
+	if !isDateSerial(r.SerialNumber()) {
+		add(SOASerialNotDate, "Serial",
+			"serial "+strconv.FormatUint(uint64(r.SerialNumber()), 10)+" is not in YYYYMMDDnn format")
+	}
+
+	return warnings
+}
+
+// isDateSerial reports whether the serial number is in the YYYYMMDDnn format with a valid date.
+func isDateSerial(serial uint32) bool {
+	s := strconv.FormatUint(uint64(serial), 10)
+	if len(s) != 10 {
+		return false
+	}
+
+	date, err := time.Parse("20060102", s[:8])
+
+	return err == nil && date.Year() >= 1990
+}
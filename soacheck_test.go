@@ -0,0 +1,54 @@
+package dnslookupapi
+
+import "testing"
+
+// TestSOACheck tests the SOA sanity checks.
+func TestSOACheck(t *testing.T) {
+	tests := []struct {
+		name string
+		soa  SOARecord
+		want []SOAWarningCode
+	}{
+		{
+			name: "recommended",
+			soa:  SOARecord{Refresh: 86400, Retry: 7200, Expire: 3600000, Minimum: 3600, Serial: 2022071201},
+		},
+		{
+			name: "cloudflare",
+			soa:  SOARecord{Refresh: 10000, Retry: 2400, Expire: 604800, Minimum: 3600, Serial: 2280826063},
+			want: []SOAWarningCode{SOASerialNotDate},
+		},
+		{
+			name: "low timers",
+			soa:  SOARecord{Refresh: 600, Retry: 60, Expire: 86400, Minimum: 60, Serial: 2022023001},
+			want: []SOAWarningCode{
+				SOARefreshTooLow, SOARetryTooLow, SOAExpireTooLow, SOAMinimumTooLow, SOASerialNotDate,
+			},
+		},
+		{
+			name: "high timers",
+			soa:  SOARecord{Refresh: 172800, Retry: 172800, Expire: 4000000, Minimum: 172800, Serial: 2022071201},
+			want: []SOAWarningCode{SOARefreshTooHigh, SOARetryNotBelowRefresh, SOAExpireTooHigh, SOAMinimumTooHigh},
+		},
+		{
+			name: "expire below refresh",
+			soa:  SOARecord{Refresh: 43200, Retry: 3600, Expire: 3600, Minimum: 3600, Serial: 2022071201},
+			want: []SOAWarningCode{SOAExpireBelowRefresh},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.soa.Check()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i].Code != tt.want[i] || got[i].Message == "" || got[i].Field == "" {
+					t.Errorf("Check()[%d] = %+v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
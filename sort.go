@@ -0,0 +1,130 @@
+package dnslookupapi
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// MXRecordsByPriority implements sort.Interface to sort MX records by priority, lowest first.
+type MXRecordsByPriority []MXRecord
+
+func (s MXRecordsByPriority) Len() int           { return len(s) }
+func (s MXRecordsByPriority) Less(i, j int) bool { return s[i].Priority < s[j].Priority }
+func (s MXRecordsByPriority) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// SRVRecordsByPriority implements sort.Interface to sort SRV records by priority, lowest first,
+// and by weight, highest first, within the same priority.
+type SRVRecordsByPriority []SRVRecord
+
+func (s SRVRecordsByPriority) Len() int { return len(s) }
+func (s SRVRecordsByPriority) Less(i, j int) bool {
+	if s[i].Priority != s[j].Priority {
+		return s[i].Priority < s[j].Priority
+	}
+
+	return s[i].Weight > s[j].Weight
+}
+func (s SRVRecordsByPriority) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// OrderSRV returns SRV records in the order clients should try them as defined in RFC 2782:
+// by priority, and by weighted random selection within the same priority.
+// If rnd is nil, the default source of math/rand is used.
+// Records with the "." target, meaning that the service is not available, are omitted.
+func OrderSRV(records []SRVRecord, rnd *rand.Rand) []SRVRecord {
+	intn := rand.Intn
+	if rnd != nil {
+		intn = rnd.Intn
+	}
+
+	sorted := make([]SRVRecord, 0, len(records))
+	for _, record := range records {
+		if record.Target != "." {
+			sorted = append(sorted, record)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	result := make([]SRVRecord, 0, len(sorted))
+
+	for start := 0; start < len(sorted); {
+		end := start
+		for end < len(sorted) && sorted[end].Priority == sorted[start].Priority {
+			end++
+		}
+
+		// zero-weight records are placed first so they have a small chance of being selected
+		group := append([]SRVRecord(nil), sorted[start:end]...)
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Weight == 0 && group[j].Weight != 0
+		})
+
+		for len(group) > 0 {
+			var total int
+			for _, record := range group {
+				total += record.Weight
+			}
+
+			selected := 0
+			if total > 0 {
+				n := intn(total + 1)
+
+				var sum int
+				for i, record := range group {
+					sum += record.Weight
+					if sum >= n {
+						selected = i
+						break
+					}
+				}
+			}
+
+			result = append(result, group[selected])
+			group = append(group[:selected], group[selected+1:]...)
+		}
+
+		start = end
+	}
+
+	return result
+}
+
+// SelectSRVTarget selects the SRV record to contact first as defined in RFC 2782.
+// It returns false if there are no available targets.
+func SelectSRVTarget(records []SRVRecord, rnd *rand.Rand) (SRVRecord, bool) {
+	ordered := OrderSRV(records, rnd)
+	if len(ordered) == 0 {
+		return SRVRecord{}, false
+	}
+
+	return ordered[0], true
+}
+
+// Sort sorts all records in a stable canonical order: by DNS type, owner name and raw text.
+// Typed slices are rebuilt to follow the new order.
+func (r *DNSRecords) Sort() {
+	all := append([]DNSRecord(nil), r.All...)
+
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i].CommonFields, all[j].CommonFields
+		if a.DNSType != b.DNSType {
+			return a.DNSType < b.DNSType
+		}
+
+		if an, bn := strings.ToLower(a.Name), strings.ToLower(b.Name); an != bn {
+			return an < bn
+		}
+
+		return a.RawText < b.RawText
+	})
+
+	sorted := r.derive()
+	for _, record := range all {
+		sorted.All = append(sorted.All, sorted.parseRecord(record.Raw))
+	}
+
+	*r = *sorted
+}
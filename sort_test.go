@@ -0,0 +1,113 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestMXRecordsByPriority tests sorting of MX records.
+func TestMXRecordsByPriority(t *testing.T) {
+	records := []MXRecord{{Target: "c", Priority: 30}, {Target: "a", Priority: 10}, {Target: "b", Priority: 20}}
+	sort.Sort(MXRecordsByPriority(records))
+
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Target)
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sort.Sort() = %v, want %v", got, want)
+	}
+}
+
+// TestSRVRecordsByPriority tests sorting of SRV records.
+func TestSRVRecordsByPriority(t *testing.T) {
+	records := []SRVRecord{
+		{Target: "c", Priority: 20, Weight: 0},
+		{Target: "b", Priority: 10, Weight: 5},
+		{Target: "a", Priority: 10, Weight: 50},
+	}
+	sort.Sort(SRVRecordsByPriority(records))
+
+	var got []string
+	for _, r := range records {
+		got = append(got, r.Target)
+	}
+
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sort.Sort() = %v, want %v", got, want)
+	}
+}
+
+// TestOrderSRV tests the OrderSRV and SelectSRVTarget functions.
+func TestOrderSRV(t *testing.T) {
+	records := []SRVRecord{
+		{Target: "backup", Priority: 20, Weight: 0},
+		{Target: "light", Priority: 10, Weight: 1},
+		{Target: "heavy", Priority: 10, Weight: 99},
+		{Target: "zero", Priority: 10, Weight: 0},
+	}
+
+	counts := make(map[string]int)
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		ordered := OrderSRV(records, rnd)
+		if len(ordered) != 4 || ordered[3].Target != "backup" {
+			t.Fatalf("OrderSRV() = %+v", ordered)
+		}
+
+		counts[ordered[0].Target]++
+	}
+
+	if counts["heavy"] < 900 || counts["light"] == 0 && counts["zero"] == 0 {
+		t.Errorf("first target distribution = %v", counts)
+	}
+
+	if _, ok := SelectSRVTarget([]SRVRecord{{Target: "."}}, nil); ok {
+		t.Error("SelectSRVTarget() with unavailable service returned a target")
+	}
+
+	if got, ok := SelectSRVTarget(records[:1], nil); !ok || got.Target != "backup" {
+		t.Errorf("SelectSRVTarget() = %+v, %v", got, ok)
+	}
+}
+
+// TestSort tests the Sort function.
+func TestSort(t *testing.T) {
+	const records = `[
+{"dnsType":"TXT","name":"example.com.","rawText":"example.com. 300 IN TXT \"b\"","strings":["b"]},
+{"dnsType":"A","name":"www.example.com.","rawText":"www.example.com. 300 IN A 1.1.1.1","address":"1.1.1.1"},
+{"dnsType":"A","name":"example.com.","rawText":"example.com. 300 IN A 2.2.2.2","address":"2.2.2.2"},
+{"dnsType":"A","name":"example.com.","rawText":"example.com. 300 IN A 1.1.1.1","address":"1.1.1.1"}
+]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	r.Sort()
+
+	var got []string
+	for _, record := range r.Records() {
+		got = append(got, record.GetRawText())
+	}
+
+	want := []string{
+		"example.com. 300 IN A 1.1.1.1",
+		"example.com. 300 IN A 2.2.2.2",
+		"www.example.com. 300 IN A 1.1.1.1",
+		`example.com. 300 IN TXT "b"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sort() = %v, want %v", got, want)
+	}
+
+	if r.A[0].Address != "1.1.1.1" || r.A[1].Address != "2.2.2.2" {
+		t.Errorf("Sort() A = %+v", r.A)
+	}
+}
@@ -0,0 +1,351 @@
+// Package spf extracts, parses and checks SPF policies (RFC 7208) from TXT records
+// returned by DNS Lookup API.
+package spf
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// MaxDNSLookups is the maximum number of DNS-querying mechanisms and modifiers allowed by RFC 7208.
+const MaxDNSLookups = 10
+
+var (
+	// ErrNoRecord is returned when there is no SPF record among TXT records.
+	ErrNoRecord = errors.New("no SPF record")
+
+	// ErrMultipleRecords is returned when there is more than one SPF record among TXT records.
+	ErrMultipleRecords = errors.New("multiple SPF records")
+
+	// ErrNotSPF is returned when the string is not an SPF record.
+	ErrNotSPF = errors.New("not an SPF record")
+)
+
+// Qualifier is the result returned when the mechanism matches.
+type Qualifier byte
+
+// Qualifiers of the mechanisms.
+const (
+	Pass     Qualifier = '+'
+	Fail     Qualifier = '-'
+	SoftFail Qualifier = '~'
+	Neutral  Qualifier = '?'
+)
+
+// String returns the qualifier result name.
+func (q Qualifier) String() string {
+	switch q {
+	case Pass:
+		return "pass"
+	case Fail:
+		return "fail"
+	case SoftFail:
+		return "softfail"
+	case Neutral:
+		return "neutral"
+	}
+
+	return "unknown"
+}
+
+// Mechanism is an SPF mechanism, e.g. "-ip4:192.0.2.0/24".
+type Mechanism struct {
+	// Qualifier is the mechanism qualifier. Default: Pass.
+	Qualifier Qualifier
+
+	// Name is the lower-case mechanism name: all, include, a, mx, ptr, ip4, ip6 or exists.
+	Name string
+
+	// Value is the domain-spec or the IP address of the mechanism without the CIDR length.
+	Value string
+
+	// IPv4CIDR is the IPv4 prefix length or -1 if it's not specified.
+	IPv4CIDR int
+
+	// IPv6CIDR is the IPv6 prefix length or -1 if it's not specified.
+	IPv6CIDR int
+}
+
+// Modifier is an SPF modifier, e.g. "redirect=_spf.example.com".
+type Modifier struct {
+	// Name is the lower-case modifier name.
+	Name string
+
+	// Value is the modifier value.
+	Value string
+}
+
+// SyntaxError is the error in a term of the SPF record.
+type SyntaxError struct {
+	Term    string
+	Message string
+}
+
+// Error returns error message as a string.
+func (e *SyntaxError) Error() string {
+	return `invalid SPF term "` + e.Term + `": ` + e.Message
+}
+
+// Record is the parsed SPF record.
+type Record struct {
+	// Raw is the SPF record as a string.
+	Raw string
+
+	// Mechanisms are the mechanisms in the order of evaluation.
+	Mechanisms []Mechanism
+
+	// Modifiers are the modifiers of the record.
+	Modifiers []Modifier
+
+	// Errors are the syntax errors found in the record.
+	Errors []*SyntaxError
+}
+
+// Valid reports whether the record has no syntax errors.
+func (r *Record) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Modifier returns the value of the modifier and whether it is present.
+func (r *Record) Modifier(name string) (string, bool) {
+	for _, m := range r.Modifiers {
+		if m.Name == strings.ToLower(name) {
+			return m.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// DNSLookups returns the number of mechanisms and modifiers that cause DNS lookups.
+// Nested include and redirect targets are not resolved.
+func (r *Record) DNSLookups() int {
+	var n int
+
+	for _, m := range r.Mechanisms {
+		switch m.Name {
+		case "include", "a", "mx", "ptr", "exists":
+			n++
+		}
+	}
+
+	if _, ok := r.Modifier("redirect"); ok {
+		n++
+	}
+
+	return n
+}
+
+// ExceedsLookupLimit reports whether the record exceeds the limit of DNS lookups.
+func (r *Record) ExceedsLookupLimit() bool {
+	return r.DNSLookups() > MaxDNSLookups
+}
+
+// Extract returns SPF policies from the TXT records.
+// Character strings of each TXT record are concatenated as defined in RFC 7208 section 3.3.
+func Extract(records []dnslookupapi.TXTRecord) []string {
+	var result []string
+
+	for _, record := range records {
+		txt := record.Value()
+		if isSPF(txt) {
+			result = append(result, txt)
+		}
+	}
+
+	return result
+}
+
+// FromTXT extracts and parses the only SPF policy from the TXT records.
+func FromTXT(records []dnslookupapi.TXTRecord) (*Record, error) {
+	policies := Extract(records)
+
+	switch len(policies) {
+	case 0:
+		return nil, ErrNoRecord
+	case 1:
+		return Parse(policies[0])
+	}
+
+	return nil, ErrMultipleRecords
+}
+
+// isSPF reports whether the string starts with the SPF version.
+func isSPF(txt string) bool {
+	const version = "v=spf1"
+
+	return len(txt) >= len(version) && strings.EqualFold(txt[:len(version)], version) &&
+		(len(txt) == len(version) || txt[len(version)] == ' ')
+}
+
+// Parse parses the SPF record. Syntax errors are collected in Record.Errors.
+func Parse(txt string) (*Record, error) {
+	if !isSPF(txt) {
+		return nil, ErrNotSPF
+	}
+
+	record := &Record{Raw: txt}
+
+	for _, term := range strings.Fields(txt)[1:] {
+		if err := record.parseTerm(term); err != nil {
+			record.Errors = append(record.Errors, err)
+		}
+	}
+
+	return record, nil
+}
+
+// parseTerm parses the mechanism or modifier and adds it to the record.
+func (r *Record) parseTerm(term string) *SyntaxError {
+	if i := strings.IndexAny(term, "=:/"); i > 0 && term[i] == '=' {
+		return r.parseModifier(term, term[:i], term[i+1:])
+	}
+
+	m := Mechanism{Qualifier: Pass, IPv4CIDR: -1, IPv6CIDR: -1}
+
+	body := term
+	switch Qualifier(body[0]) {
+	case Pass, Fail, SoftFail, Neutral:
+		m.Qualifier = Qualifier(body[0])
+		body = body[1:]
+	}
+
+	name := body
+	if i := strings.IndexAny(body, ":/"); i >= 0 {
+		name = body[:i]
+		body = body[i:]
+	} else {
+		body = ""
+	}
+
+	m.Name = strings.ToLower(name)
+
+	var value string
+	if strings.HasPrefix(body, ":") {
+		value = body[1:]
+		body = ""
+
+		if m.Name == "a" || m.Name == "mx" || m.Name == "ip4" || m.Name == "ip6" {
+			value, body = splitCIDR(value)
+		}
+	}
+
+	m.Value = value
+
+	var err *SyntaxError
+
+	switch m.Name {
+	case "all":
+		if value != "" || body != "" {
+			err = &SyntaxError{Term: term, Message: "all takes no arguments"}
+		}
+	case "include", "exists":
+		if value == "" || body != "" {
+			err = &SyntaxError{Term: term, Message: m.Name + " requires a domain"}
+		}
+	case "ptr":
+		if body != "" {
+			err = &SyntaxError{Term: term, Message: "ptr takes no CIDR length"}
+		}
+	case "a", "mx":
+		m.IPv4CIDR, m.IPv6CIDR, err = parseDualCIDR(term, body)
+	case "ip4":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+			err = &SyntaxError{Term: term, Message: "invalid IPv4 address"}
+		} else {
+			m.IPv4CIDR, err = parseCIDR(term, body, 32)
+		}
+	case "ip6":
+		if ip := net.ParseIP(value); ip == nil || !strings.Contains(value, ":") {
+			err = &SyntaxError{Term: term, Message: "invalid IPv6 address"}
+		} else {
+			m.IPv6CIDR, err = parseCIDR(term, body, 128)
+		}
+	default:
+		err = &SyntaxError{Term: term, Message: "unknown mechanism"}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	r.Mechanisms = append(r.Mechanisms, m)
+
+	return nil
+}
+
+// parseModifier parses the modifier and adds it to the record.
+func (r *Record) parseModifier(term, name, value string) *SyntaxError {
+	name = strings.ToLower(name)
+
+	for i, c := range name {
+		if i == 0 && !(c >= 'a' && c <= 'z') {
+			return &SyntaxError{Term: term, Message: "invalid modifier name"}
+		}
+
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return &SyntaxError{Term: term, Message: "invalid modifier name"}
+		}
+	}
+
+	if name == "redirect" || name == "exp" {
+		if value == "" {
+			return &SyntaxError{Term: term, Message: name + " requires a domain"}
+		}
+
+		if _, ok := r.Modifier(name); ok {
+			return &SyntaxError{Term: term, Message: "duplicate " + name + " modifier"}
+		}
+	}
+
+	r.Modifiers = append(r.Modifiers, Modifier{Name: name, Value: value})
+
+	return nil
+}
+
+// splitCIDR splits the mechanism argument into the value and the CIDR length part.
+func splitCIDR(arg string) (string, string) {
+	if i := strings.IndexByte(arg, '/'); i >= 0 {
+		return arg[:i], arg[i:]
+	}
+
+	return arg, ""
+}
+
+// parseCIDR parses the "/N" CIDR length.
+func parseCIDR(term, s string, max int) (int, *SyntaxError) {
+	if s == "" {
+		return -1, nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(s, "/"))
+	if err != nil || !strings.HasPrefix(s, "/") || n < 0 || n > max {
+		return -1, &SyntaxError{Term: term, Message: "invalid CIDR length"}
+	}
+
+	return n, nil
+}
+
+// parseDualCIDR parses the "/N//M" dual CIDR length of a and mx mechanisms.
+func parseDualCIDR(term, s string) (int, int, *SyntaxError) {
+	ip4, ip6 := s, ""
+	if i := strings.Index(s, "//"); i >= 0 {
+		ip4, ip6 = s[:i], s[i+1:]
+	}
+
+	v4, err := parseCIDR(term, ip4, 32)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	v6, err := parseCIDR(term, ip6, 128)
+	if err != nil {
+		return -1, -1, err
+	}
+
+	return v4, v6, nil
+}
@@ -0,0 +1,174 @@
+package spf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// TestParse tests the Parse function.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		txt         string
+		mechanisms  []Mechanism
+		modifiers   []Modifier
+		errors      []string
+		lookups     int
+		wantErr     error
+		wantExceeds bool
+	}{
+		{
+			name: "mechanisms",
+			txt:  "v=spf1 ip4:192.0.2.0/24 ip6:2001:db8::/32 a mx:mail.example.com/24//64 include:_spf.google.com ~all",
+			mechanisms: []Mechanism{
+				{Qualifier: Pass, Name: "ip4", Value: "192.0.2.0", IPv4CIDR: 24, IPv6CIDR: -1},
+				{Qualifier: Pass, Name: "ip6", Value: "2001:db8::", IPv4CIDR: -1, IPv6CIDR: 32},
+				{Qualifier: Pass, Name: "a", IPv4CIDR: -1, IPv6CIDR: -1},
+				{Qualifier: Pass, Name: "mx", Value: "mail.example.com", IPv4CIDR: 24, IPv6CIDR: 64},
+				{Qualifier: Pass, Name: "include", Value: "_spf.google.com", IPv4CIDR: -1, IPv6CIDR: -1},
+				{Qualifier: SoftFail, Name: "all", IPv4CIDR: -1, IPv6CIDR: -1},
+			},
+			lookups: 3,
+		},
+		{
+			name: "modifiers",
+			txt:  "V=SPF1 -A//64 redirect=_spf.example.com exp=explain.example.com",
+			mechanisms: []Mechanism{
+				{Qualifier: Fail, Name: "a", IPv4CIDR: -1, IPv6CIDR: 64},
+			},
+			modifiers: []Modifier{
+				{Name: "redirect", Value: "_spf.example.com"},
+				{Name: "exp", Value: "explain.example.com"},
+			},
+			lookups: 2,
+		},
+		{
+			name: "syntax errors",
+			txt:  "v=spf1 ip4:2001:db8:: ip6:192.0.2.1 ip4:192.0.2.1/33 include all:x foo -redirect=x redirect=a redirect=b ?all",
+			mechanisms: []Mechanism{
+				{Qualifier: Neutral, Name: "all", IPv4CIDR: -1, IPv6CIDR: -1},
+			},
+			modifiers: []Modifier{
+				{Name: "redirect", Value: "a"},
+			},
+			errors: []string{
+				`invalid SPF term "ip4:2001:db8::": invalid IPv4 address`,
+				`invalid SPF term "ip6:192.0.2.1": invalid IPv6 address`,
+				`invalid SPF term "ip4:192.0.2.1/33": invalid CIDR length`,
+				`invalid SPF term "include": include requires a domain`,
+				`invalid SPF term "all:x": all takes no arguments`,
+				`invalid SPF term "foo": unknown mechanism`,
+				`invalid SPF term "-redirect=x": invalid modifier name`,
+				`invalid SPF term "redirect=b": duplicate redirect modifier`,
+			},
+			lookups: 1,
+		},
+		{
+			name: "lookup limit",
+			txt: "v=spf1 a mx ptr exists:%{i}.example.com include:a.example.com include:b.example.com " +
+				"include:c.example.com include:d.example.com include:e.example.com include:f.example.com -all",
+			lookups:     10,
+			wantExceeds: false,
+		},
+		{
+			name:        "lookup limit exceeded",
+			txt:         "v=spf1" + strings.Repeat(" a", 10) + " redirect=example.com",
+			modifiers:   []Modifier{{Name: "redirect", Value: "example.com"}},
+			lookups:     11,
+			wantExceeds: true,
+		},
+		{
+			name:    "not spf",
+			txt:     "v=spf10 -all",
+			wantErr: ErrNotSPF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.txt)
+			if err != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if tt.mechanisms != nil && !reflect.DeepEqual(got.Mechanisms, tt.mechanisms) {
+				t.Errorf("Mechanisms = %+v, want %+v", got.Mechanisms, tt.mechanisms)
+			}
+
+			if !reflect.DeepEqual(got.Modifiers, tt.modifiers) {
+				t.Errorf("Modifiers = %+v, want %+v", got.Modifiers, tt.modifiers)
+			}
+
+			var errs []string
+			for _, e := range got.Errors {
+				errs = append(errs, e.Error())
+			}
+
+			if !reflect.DeepEqual(errs, tt.errors) {
+				t.Errorf("Errors = %q, want %q", errs, tt.errors)
+			}
+
+			if got.Valid() != (len(tt.errors) == 0) {
+				t.Errorf("Valid() = %v", got.Valid())
+			}
+
+			if n := got.DNSLookups(); n != tt.lookups {
+				t.Errorf("DNSLookups() = %v, want %v", n, tt.lookups)
+			}
+
+			if exceeds := got.ExceedsLookupLimit(); exceeds != tt.wantExceeds {
+				t.Errorf("ExceedsLookupLimit() = %v, want %v", exceeds, tt.wantExceeds)
+			}
+		})
+	}
+}
+
+// TestFromTXT tests the FromTXT function.
+func TestFromTXT(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []dnslookupapi.TXTRecord
+		want    string
+		wantErr error
+	}{
+		{
+			name: "split strings",
+			records: []dnslookupapi.TXTRecord{
+				{Strings: []string{"google-site-verification=abc"}},
+				{Strings: []string{"v=spf1 include:_spf.google.com", " -all"}},
+			},
+			want: "v=spf1 include:_spf.google.com -all",
+		},
+		{
+			name:    "no record",
+			records: []dnslookupapi.TXTRecord{{Strings: []string{"v=DMARC1; p=none"}}},
+			wantErr: ErrNoRecord,
+		},
+		{
+			name: "multiple records",
+			records: []dnslookupapi.TXTRecord{
+				{Strings: []string{"v=spf1 -all"}},
+				{Strings: []string{"v=spf1 +all"}},
+			},
+			wantErr: ErrMultipleRecords,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromTXT(tt.records)
+			if err != tt.wantErr {
+				t.Fatalf("FromTXT() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && got.Raw != tt.want {
+				t.Errorf("FromTXT() = %v, want %v", got.Raw, tt.want)
+			}
+		})
+	}
+}
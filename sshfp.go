@@ -0,0 +1,108 @@
+package dnslookupapi
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// SSHFP fingerprint types (RFC 4255, RFC 6594).
+const (
+	SSHFPDigestSHA1   = 1
+	SSHFPDigestSHA256 = 2
+)
+
+// SSHFP public key algorithms (RFC 4255, RFC 6594, RFC 7479, RFC 8709).
+const (
+	SSHFPAlgorithmRSA     = 1
+	SSHFPAlgorithmDSA     = 2
+	SSHFPAlgorithmECDSA   = 3
+	SSHFPAlgorithmEd25519 = 4
+	SSHFPAlgorithmEd448   = 6
+)
+
+// ErrUnsupportedDigestType is returned when the SSHFP record uses an unknown fingerprint type.
+var ErrUnsupportedDigestType = errors.New("unsupported fingerprint type")
+
+// Fingerprint returns the fingerprint of the SSHFP record as a byte slice.
+// If the FingerPrint field is not a hex string, the fingerprint is taken from the raw text of the record.
+func (r SSHFPRecord) Fingerprint() ([]byte, error) {
+	fp, err := hex.DecodeString(strings.Join(r.FingerPrint, ""))
+	if err == nil && len(fp) != 0 {
+		return fp, nil
+	}
+
+	fields := strings.Fields(r.RawText)
+	for i, field := range fields {
+		if strings.EqualFold(field, "SSHFP") && len(fields) > i+3 {
+			return hex.DecodeString(strings.Join(fields[i+3:], ""))
+		}
+	}
+
+	if err == nil {
+		err = errors.New("empty fingerprint")
+	}
+
+	return nil, err
+}
+
+// Matches reports whether the SSHFP record matches the public key.
+// pubKey is the public key in the SSH wire format, as returned by ssh.PublicKey.Marshal().
+func (r SSHFPRecord) Matches(pubKey []byte) (bool, error) {
+	fp, err := r.Fingerprint()
+	if err != nil {
+		return false, err
+	}
+
+	var digest []byte
+
+	switch r.DigestType {
+	case SSHFPDigestSHA1:
+		sum := sha1.Sum(pubKey)
+		digest = sum[:]
+	case SSHFPDigestSHA256:
+		sum := sha256.Sum256(pubKey)
+		digest = sum[:]
+	default:
+		return false, ErrUnsupportedDigestType
+	}
+
+	if alg, ok := sshfpAlgorithm(pubKey); ok && alg != r.Algorithm {
+		return false, nil
+	}
+
+	return bytes.Equal(fp, digest), nil
+}
+
+// sshfpAlgorithm returns the SSHFP algorithm number of the public key in the SSH wire format.
+func sshfpAlgorithm(pubKey []byte) (int, bool) {
+	if len(pubKey) < 4 {
+		return 0, false
+	}
+
+	n := binary.BigEndian.Uint32(pubKey)
+	if uint64(n) > uint64(len(pubKey)-4) {
+		return 0, false
+	}
+
+	keyType := string(pubKey[4 : 4+n])
+
+	switch {
+	case keyType == "ssh-rsa":
+		return SSHFPAlgorithmRSA, true
+	case keyType == "ssh-dss":
+		return SSHFPAlgorithmDSA, true
+	case strings.HasPrefix(keyType, "ecdsa-sha2-"):
+		return SSHFPAlgorithmECDSA, true
+	case keyType == "ssh-ed25519":
+		return SSHFPAlgorithmEd25519, true
+	case keyType == "ssh-ed448":
+		return SSHFPAlgorithmEd448, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package dnslookupapi
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// wireKey returns the public key in the SSH wire format.
+func wireKey(keyType string, key []byte) []byte {
+	b := make([]byte, 4, 4+len(keyType)+4+len(key))
+	binary.BigEndian.PutUint32(b, uint32(len(keyType)))
+	b = append(b, keyType...)
+	b = append(b, 0, 0, 0, byte(len(key)))
+
+	return append(b, key...)
+}
+
+// TestSSHFPMatches tests the Matches function.
+func TestSSHFPMatches(t *testing.T) {
+	pubKey := wireKey("ssh-ed25519", []byte(strings.Repeat("k", 32)))
+	sum1 := sha1.Sum(pubKey)
+	sum256 := sha256.Sum256(pubKey)
+	fp1 := strings.ToUpper(hex.EncodeToString(sum1[:]))
+	fp256 := hex.EncodeToString(sum256[:])
+
+	tests := []struct {
+		name    string
+		record  SSHFPRecord
+		want    bool
+		wantErr string
+	}{
+		{
+			name:   "sha1",
+			record: SSHFPRecord{Algorithm: 4, DigestType: 1, FingerPrint: []string{fp1}},
+			want:   true,
+		},
+		{
+			name:   "sha256",
+			record: SSHFPRecord{Algorithm: 4, DigestType: 2, FingerPrint: []string{fp256[:32], fp256[32:]}},
+			want:   true,
+		},
+		{
+			name: "sha256 from raw text",
+			record: SSHFPRecord{
+				commonFields: commonFields{RawText: "example.com.\t300\tIN\tSSHFP\t4 2 " + fp256},
+				Algorithm:    4,
+				DigestType:   2,
+			},
+			want: true,
+		},
+		{
+			name:   "algorithm mismatch",
+			record: SSHFPRecord{Algorithm: 1, DigestType: 2, FingerPrint: []string{fp256}},
+			want:   false,
+		},
+		{
+			name:   "digest mismatch",
+			record: SSHFPRecord{Algorithm: 4, DigestType: 2, FingerPrint: []string{fp1}},
+			want:   false,
+		},
+		{
+			name:    "unsupported digest type",
+			record:  SSHFPRecord{Algorithm: 4, DigestType: 3, FingerPrint: []string{fp256}},
+			want:    false,
+			wantErr: "unsupported fingerprint type",
+		},
+		{
+			name:    "empty fingerprint",
+			record:  SSHFPRecord{Algorithm: 4, DigestType: 2},
+			want:    false,
+			wantErr: "empty fingerprint",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.record.Matches(pubKey)
+			checkErr(t, err, tt.wantErr)
+
+			if got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// GetStream requests DNS records of the domain and decodes them incrementally from the response body,
+// calling fn for every record in the order returned by the API. Memory usage does not depend
+// on the number of records. If fn returns an error, decoding stops and the error is returned.
+// Unlike Get, the request is made with the first API key to the primary endpoint only
+// and the returned Response has no Body. The lookup timeout covers decoding, including the calls of fn.
+// OptionCallback is ignored.
+func (c *Client) GetStream(
+	ctx context.Context,
+	domainName string,
+	fn func(DNSRecord) error,
+	opts ...Option,
+) (resp *Response, err error) {
+	optsJSON := make([]Option, 0, len(opts)+1)
+	optsJSON = append(optsJSON, opts...)
+	optsJSON = append(optsJSON, OptionOutputFormat("JSON"), withoutCallback)
+
+	req, err := c.BuildRequest(domainName, optsJSON...)
+	if err != nil {
+		return nil, err
+	}
+
+	if base := baseURLFromContext(ctx); base != nil {
+		req = withBaseURL(req.Context(), req, base)
+	}
+
+	budget := c.startLookupBudget(ctx)
+	defer func() {
+		err = budget.done(err)
+	}()
+
+	ctx = budget.ctx
+	budget.attempt()
+
+	trace := &redirectTrace{}
+	start := time.Now()
+	keyID := maskKey(c.keys.first())
+
+	event := LogEvent{Kind: LogRequest, URL: RedactURL(req.URL), KeyID: keyID, Attempt: 1}
+	c.log(ctx, event)
+
+	httpResp, err := c.send(req.WithContext(withRedirectTrace(ctx, trace)))
+	if err != nil {
+		err = fmt.Errorf("cannot execute request: %w", err)
+
+		event.Kind, event.Duration, event.Err = LogResponse, time.Since(start), err
+		c.log(ctx, event)
+
+		return nil, err
+	}
+
+	defer httpResp.Body.Close()
+
+	resp = &Response{
+		Response:  httpResp,
+		Redirects: trace.urls(),
+		KeyID:     keyID,
+		RateLimit: parseRateLimit(httpResp.Header, time.Now()),
+	}
+
+	defer func() {
+		resp.Duration = time.Since(start)
+
+		event.Kind, event.StatusCode, event.Duration, event.Err = LogResponse, httpResp.StatusCode, resp.Duration, err
+		c.log(ctx, event)
+	}()
+
+	body, err := decodeBody(httpResp)
+	if err != nil {
+		return resp, fmt.Errorf("cannot decompress response: %w", err)
+	}
+
+	if c.maxResponseBytes > 0 {
+		body = &limitedReader{r: body, n: c.maxResponseBytes}
+	}
+
+	if c := httpResp.StatusCode; c < 200 || c > 299 {
+		errBody, _ := io.ReadAll(body)
+		return resp, checkResponse(httpResp, errBody)
+	}
+
+	if err = decodeStream(body, fn); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// withoutCallback removes the JSONP callback from the query.
+func withoutCallback(v url.Values) {
+	v.Del("callback")
+}
+
+// decodeStream decodes the API response calling fn for every DNS record.
+func decodeStream(r io.Reader, fn func(DNSRecord) error) error {
+	decoder := json.NewDecoder(r)
+
+	return decodeObject(decoder, func(key string) error {
+		switch key {
+		case "DNSData":
+			return decodeObject(decoder, func(key string) error {
+				if key != "dnsRecords" {
+					return skipValue(decoder)
+				}
+
+				return decodeRecords(decoder, fn)
+			})
+		case "ErrorMessage":
+			var msg ErrorMessage
+			if err := decoder.Decode(&msg); err != nil {
+				return fmt.Errorf("cannot parse response: %w", err)
+			}
+
+			if msg.Code != "" || msg.Message != "" {
+				return &msg
+			}
+
+			return nil
+		}
+
+		return skipValue(decoder)
+	})
+}
+
+// decodeObject reads the JSON object calling fn for every key. fn must consume the value.
+func decodeObject(decoder *json.Decoder, fn func(key string) error) error {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("cannot parse response: %w", err)
+		}
+
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("cannot parse response: unexpected token %v", tok)
+		}
+
+		if err = fn(key); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, '}')
+}
+
+// decodeRecords reads the JSON array of DNS records calling fn for every record.
+func decodeRecords(decoder *json.Decoder, fn func(DNSRecord) error) error {
+	if err := expectDelim(decoder, '['); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return fmt.Errorf("cannot parse response: %w", err)
+		}
+
+		if err := fn((&DNSRecords{}).parseRecord(raw)); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token and checks that it is the delimiter.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	tok, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("cannot parse response: %w", err)
+	}
+
+	if tok != delim {
+		return fmt.Errorf("cannot parse response: expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// skipValue reads and discards the next JSON value.
+func skipValue(decoder *json.Decoder) error {
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return fmt.Errorf("cannot parse response: %w", err)
+	}
+
+	return nil
+}
+
+// limitedReader is the reader returning ErrResponseTooLarge after n bytes.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, ErrResponseTooLarge
+	}
+
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	if l.n < 0 {
+		return n, ErrResponseTooLarge
+	}
+
+	return n, err
+}
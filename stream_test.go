@@ -0,0 +1,88 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestGetStream tests the GetStream function.
+func TestGetStream(t *testing.T) {
+	const resp = `{"DNSData":{"domainName":"whoisxmlapi.com","types":[1,15],"audit":{"createdDate":""},"dnsRecords":[
+{"type":1,"dnsType":"A","name":"whoisxmlapi.com.","address":"104.26.13.210"},
+{"type":15,"dnsType":"MX","name":"whoisxmlapi.com.","target":"mx.whoisxmlapi.com.","priority":10},
+{"type":65535,"dnsType":"UNKNOWN"}
+]},"extra":{"ignored":[1,2,3]}}`
+
+	const respUnparsable = `<?xml version="1.0" encoding="utf-8"?><>`
+
+	const errResp = `{"ErrorMessage":{"errorCode":"TEST_CODE","msg":"test error message"}}`
+
+	server := dummyServer(resp, respUnparsable, errResp)
+	defer server.Close()
+
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name    string
+		path    string
+		stopAt  int
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "successful request",
+			path: pathDNSLookupResponseOK,
+			want: []string{"A", "MX", "UNKNOWN"},
+		},
+		{
+			name:    "callback error",
+			path:    pathDNSLookupResponseOK,
+			stopAt:  2,
+			want:    []string{"A", "MX"},
+			wantErr: "stop",
+		},
+		{
+			name:    "partial response",
+			path:    pathDNSLookupResponsePartial1,
+			want:    []string{"A", "MX", "UNKNOWN"},
+			wantErr: "cannot parse response: unexpected EOF",
+		},
+		{
+			name:    "non 200 status code",
+			path:    pathDNSLookupResponseError,
+			wantErr: "API failed with status code: 499 (test error message)",
+		},
+		{
+			name:    "unparsable response",
+			path:    pathDNSLookupResponseUnparsable,
+			wantErr: "cannot parse response: invalid character '<' looking for beginning of value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newAPI(server, tt.path)
+
+			var got []string
+			_, err := api.GetStream(context.Background(), "whoisxmlapi.com", func(record DNSRecord) error {
+				got = append(got, record.CommonFields.DNSType)
+				if len(got) == tt.stopAt {
+					return errStop
+				}
+
+				return nil
+			})
+			checkErr(t, err, tt.wantErr)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetStream() records = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("GetStream() records = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
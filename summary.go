@@ -0,0 +1,157 @@
+package dnslookupapi
+
+import (
+	"sort"
+	"strings"
+)
+
+// Summary is the overview of the DNS records of a domain, e.g. for dashboards.
+type Summary struct {
+	// DomainName is the requested domain name.
+	DomainName string `json:"domainName"`
+
+	// Total is the number of records including those which failed to parse.
+	Total int `json:"total"`
+
+	// Counts are the numbers of records per DNS type.
+	Counts map[string]int `json:"counts"`
+
+	// ParseErrors is the number of records which failed to parse.
+	ParseErrors int `json:"parseErrors"`
+
+	// MinTTL and MaxTTL are the lowest and highest TTLs of the records in seconds. They are zero if there are no records.
+	MinTTL int `json:"minTTL"`
+	MaxTTL int `json:"maxTTL"`
+
+	// NameServers are the normalized targets of the NS records in ascending order.
+	NameServers []string `json:"nameServers"`
+
+	// MailServers are the normalized targets of the MX records in the order of priority.
+	MailServers []string `json:"mailServers"`
+
+	// DNSProviders are the providers of the name servers, e.g. "Cloudflare".
+	DNSProviders []string `json:"dnsProviders"`
+
+	// MailProviders are the providers of the mail servers, e.g. "Google".
+	MailProviders []string `json:"mailProviders"`
+
+	// DNSSEC reports whether the domain has DNSKEY or DS records.
+	DNSSEC bool `json:"dnssec"`
+}
+
+// providerRule matches the host names of a provider by their suffixes or by a label prefix.
+type providerRule struct {
+	name          string
+	suffixes      []string
+	labelPrefixes []string
+}
+
+// match reports whether the normalized host name belongs to the provider.
+func (p providerRule) match(host string) bool {
+	for _, suffix := range p.suffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return true
+		}
+	}
+
+	for _, label := range strings.Split(host, ".") {
+		for _, prefix := range p.labelPrefixes {
+			if strings.HasPrefix(label, prefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+var (
+	// dnsProviderRules are the well-known DNS hosting providers.
+	dnsProviderRules = []providerRule{
+		{name: "Cloudflare", suffixes: []string{"ns.cloudflare.com"}},
+		{name: "Google", suffixes: []string{"googledomains.com", "google.com"}},
+		{name: "AWS", labelPrefixes: []string{"awsdns-"}},
+		{name: "Microsoft", suffixes: []string{"azure-dns.com", "azure-dns.net", "azure-dns.org", "azure-dns.info"}},
+	}
+
+	// mailProviderRules are the well-known email providers.
+	mailProviderRules = []providerRule{
+		{name: "Google", suffixes: []string{"google.com", "googlemail.com"}},
+		{name: "Microsoft", suffixes: []string{"mail.protection.outlook.com"}},
+		{name: "AWS", suffixes: []string{"amazonses.com", "amazonaws.com"}},
+		{name: "Cloudflare", suffixes: []string{"mx.cloudflare.net"}},
+	}
+)
+
+// providersOf returns the distinct providers of the hosts in the order of the rules.
+func providersOf(hosts []string, rules []providerRule) []string {
+	var providers []string
+
+	for _, rule := range rules {
+		for _, host := range hosts {
+			if rule.match(host) {
+				providers = append(providers, rule.name)
+				break
+			}
+		}
+	}
+
+	return providers
+}
+
+// Summary returns the summary of the response.
+func (r *DNSLookupResponse) Summary() Summary {
+	s := r.ParsedRecords().Summary()
+	s.DomainName = r.DomainName
+
+	return s
+}
+
+// Summary returns the summary of the records. DomainName is not set.
+func (r *DNSRecords) Summary() Summary {
+	s := Summary{Total: len(r.All), Counts: make(map[string]int)}
+
+	first := true
+
+	for _, record := range r.All {
+		if record.ParseError != nil {
+			s.ParseErrors++
+			continue
+		}
+
+		s.Counts[record.CommonFields.DNSType]++
+
+		if ttl := record.CommonFields.TTL; first || ttl < s.MinTTL {
+			s.MinTTL = ttl
+		}
+
+		if ttl := record.CommonFields.TTL; first || ttl > s.MaxTTL {
+			s.MaxTTL = ttl
+		}
+
+		first = false
+	}
+
+	for _, ns := range r.NS {
+		if host := NormalizeName(ns.Target); host != "" && !containsString(s.NameServers, host) {
+			s.NameServers = append(s.NameServers, host)
+		}
+	}
+
+	sort.Strings(s.NameServers)
+
+	mx := append([]MXRecord(nil), r.MX...)
+	sort.Stable(MXRecordsByPriority(mx))
+
+	for _, record := range mx {
+		if host := NormalizeName(record.Target); host != "" && !containsString(s.MailServers, host) {
+			s.MailServers = append(s.MailServers, host)
+		}
+	}
+
+	s.DNSProviders = providersOf(s.NameServers, dnsProviderRules)
+	s.MailProviders = providersOf(s.MailServers, mailProviderRules)
+	s.DNSSEC = len(r.DNSKEY) != 0 || len(r.DS) != 0
+
+	return s
+}
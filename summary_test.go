@@ -0,0 +1,49 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestSummary tests the summary of the response.
+func TestSummary(t *testing.T) {
+	const records = `[
+		{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"},
+		{"type":2,"dnsType":"NS","name":"example.com.","ttl":86400,"target":"Kurt.NS.Cloudflare.com."},
+		{"type":2,"dnsType":"NS","name":"example.com.","ttl":86400,"target":"ns-1.awsdns-01.org."},
+		{"type":2,"dnsType":"NS","name":"example.com.","ttl":86400,"target":"elle.ns.cloudflare.com."},
+		{"type":15,"dnsType":"MX","name":"example.com.","ttl":60,"target":"alt1.aspmx.l.google.com.","priority":5},
+		{"type":15,"dnsType":"MX","name":"example.com.","ttl":60,"target":"aspmx.l.google.com.","priority":1},
+		{"type":15,"dnsType":"MX","name":"example.com.","ttl":60,"target":"backup.example.net.","priority":20},
+		{"type":48,"dnsType":"DNSKEY","name":"example.com.","ttl":3600,"flags":257,"protocol":3,"algorithm":13},
+		{"type":15,"dnsType":"MX","name":"example.com.","priority":"broken"}
+	]`
+
+	resp := DNSLookupResponse{DomainName: "example.com"}
+	if err := json.Unmarshal([]byte(records), &resp.DNSRecords); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Summary{
+		DomainName:    "example.com",
+		Total:         9,
+		Counts:        map[string]int{"A": 1, "NS": 3, "MX": 3, "DNSKEY": 1},
+		ParseErrors:   1,
+		MinTTL:        60,
+		MaxTTL:        86400,
+		NameServers:   []string{"elle.ns.cloudflare.com", "kurt.ns.cloudflare.com", "ns-1.awsdns-01.org"},
+		MailServers:   []string{"aspmx.l.google.com", "alt1.aspmx.l.google.com", "backup.example.net"},
+		DNSProviders:  []string{"Cloudflare", "AWS"},
+		MailProviders: []string{"Google"},
+		DNSSEC:        true,
+	}
+
+	if got := resp.Summary(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+
+	if got := (&DNSRecords{}).Summary(); got.Total != 0 || got.MinTTL != 0 || got.DNSSEC || len(got.Counts) != 0 {
+		t.Errorf("Summary() of no records = %+v", got)
+	}
+}
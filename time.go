@@ -0,0 +1,142 @@
+package dnslookupapi
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// TimeLayout is the layout of the times in DNS Lookup API responses. Time is always encoded with it.
+	TimeLayout = "2006-01-02 15:04:05 MST"
+
+	// EpochSeconds is the pseudo-layout accepting Unix time in seconds as a JSON number or string.
+	EpochSeconds = "epoch-seconds"
+)
+
+var (
+	// timeLayouts holds the []string of the layouts accepted by Time.
+	timeLayouts atomic.Value
+
+	// timeLayoutsMu serializes the changes of timeLayouts.
+	timeLayoutsMu sync.Mutex
+)
+
+func init() {
+	timeLayouts.Store([]string{TimeLayout})
+}
+
+// RegisterTimeLayout adds the layouts accepted by Time after the already registered ones,
+// e.g. time.RFC3339 for ISO-8601 times with offsets or EpochSeconds.
+// Only TimeLayout is accepted by default. It's intended to be called during initialization.
+func RegisterTimeLayout(layouts ...string) {
+	timeLayoutsMu.Lock()
+	defer timeLayoutsMu.Unlock()
+
+	current := TimeLayouts()
+	next := make([]string, 0, len(current)+len(layouts))
+	next = append(next, current...)
+
+	for _, layout := range layouts {
+		if !containsString(next, layout) {
+			next = append(next, layout)
+		}
+	}
+
+	timeLayouts.Store(next)
+}
+
+// SetTimeLayouts replaces the layouts accepted by Time. They are tried in order.
+// If none is given then only TimeLayout is accepted.
+func SetTimeLayouts(layouts ...string) {
+	timeLayoutsMu.Lock()
+	defer timeLayoutsMu.Unlock()
+
+	if len(layouts) == 0 {
+		layouts = []string{TimeLayout}
+	}
+
+	timeLayouts.Store(append([]string(nil), layouts...))
+}
+
+// TimeLayouts returns the layouts accepted by Time. The returned slice must not be modified.
+func TimeLayouts() []string {
+	return timeLayouts.Load().([]string)
+}
+
+// containsString reports whether the string is in the list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptsEpochSeconds reports whether EpochSeconds is registered.
+func acceptsEpochSeconds() bool {
+	return containsString(TimeLayouts(), EpochSeconds)
+}
+
+// AsTime returns the time as time.Time.
+func (t Time) AsTime() time.Time {
+	return time.Time(t)
+}
+
+// IsZero reports whether the time is not set, e.g. it was an empty string in the response.
+func (t Time) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// String returns the time formatted with TimeLayout or an empty string if it's not set.
+func (t Time) String() string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return time.Time(t).Format(TimeLayout)
+}
+
+// parse parses the string with the registered layouts. It returns the error of the first layout
+// if none of them matches.
+func (t *Time) parse(str string) error {
+	var firstErr error
+
+	for _, layout := range TimeLayouts() {
+		var err error
+
+		if layout == EpochSeconds {
+			err = t.parseEpochSeconds(str)
+		} else {
+			var v time.Time
+			if v, err = time.Parse(layout, str); err == nil {
+				*t = Time(v)
+			}
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// parseEpochSeconds parses Unix time in seconds.
+func (t *Time) parseEpochSeconds(str string) error {
+	sec, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return &time.ParseError{Layout: EpochSeconds, Value: str, Message: ": invalid Unix time"}
+	}
+
+	*t = Time(time.Unix(sec, 0).UTC())
+
+	return nil
+}
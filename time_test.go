@@ -0,0 +1,71 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestTimeLayouts tests parsing of times with the registered layouts.
+func TestTimeLayouts(t *testing.T) {
+	defer SetTimeLayouts()
+
+	want := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		layouts []string
+		input   string
+		ok      bool
+	}{
+		{"default", nil, `"2024-03-01 12:00:00 UTC"`, true},
+		{"default rejects ISO", nil, `"2024-03-01T14:00:00+02:00"`, false},
+		{"default rejects epoch", nil, `1709294400`, false},
+		{"ISO with offset", []string{TimeLayout, time.RFC3339}, `"2024-03-01T14:00:00+02:00"`, true},
+		{"API layout after ISO", []string{time.RFC3339, TimeLayout}, `"2024-03-01 12:00:00 UTC"`, true},
+		{"epoch number", []string{TimeLayout, EpochSeconds}, `1709294400`, true},
+		{"epoch string", []string{TimeLayout, EpochSeconds}, `"1709294400"`, true},
+		{"epoch garbage", []string{TimeLayout, EpochSeconds}, `"soon"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTimeLayouts(tt.layouts...)
+
+			var v Time
+
+			err := json.Unmarshal([]byte(tt.input), &v)
+			if (err == nil) != tt.ok {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.input, err)
+			}
+
+			if tt.ok && !v.AsTime().Equal(want) {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.input, v.AsTime(), want)
+			}
+		})
+	}
+
+	SetTimeLayouts()
+	RegisterTimeLayout(time.RFC3339, TimeLayout, time.RFC3339)
+
+	if layouts := TimeLayouts(); len(layouts) != 2 || layouts[0] != TimeLayout || layouts[1] != time.RFC3339 {
+		t.Errorf("TimeLayouts() = %v", layouts)
+	}
+}
+
+// TestTimeHelpers tests the conversion helpers of Time.
+func TestTimeHelpers(t *testing.T) {
+	var empty Time
+	if !empty.IsZero() || empty.String() != "" {
+		t.Errorf("empty Time = %q, IsZero() = %v", empty.String(), empty.IsZero())
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &empty); err != nil || !empty.IsZero() {
+		t.Errorf("Unmarshal(null) = %v, %v", empty, err)
+	}
+
+	v := Time(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	if v.IsZero() || v.String() != "2024-03-01 12:00:00 UTC" || !v.AsTime().Equal(time.Time(v)) {
+		t.Errorf("Time = %q, IsZero() = %v", v.String(), v.IsZero())
+	}
+}
@@ -0,0 +1,91 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// TimeoutExceeded is returned when the lookup doesn't finish within its time budget,
+// including the retries with other API keys and mirrors.
+type TimeoutExceeded struct {
+	// Timeout is the time budget of the lookup
+	Timeout time.Duration
+
+	// Attempts is the number of requests started within the budget
+	Attempts int
+
+	// Err is the error of the last attempt
+	Err error
+}
+
+// Error returns error message as a string.
+func (e *TimeoutExceeded) Error() string {
+	msg := "lookup timeout of " + e.Timeout.String() + " exceeded after " + strconv.Itoa(e.Attempts) + " attempt(s)"
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *TimeoutExceeded) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the target is context.DeadlineExceeded, so the error can be handled as any other timeout.
+func (e *TimeoutExceeded) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// lookupTimeoutKey is the context key of the lookup timeout override.
+type lookupTimeoutKey struct{}
+
+// WithLookupTimeout returns the context overriding ClientParams.LookupTimeout for the calls made with it.
+// Zero timeout disables the budget.
+func WithLookupTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, lookupTimeoutKey{}, timeout)
+}
+
+// lookupBudget is the time budget of a single lookup.
+type lookupBudget struct {
+	parent   context.Context
+	ctx      context.Context
+	cancel   context.CancelFunc
+	timeout  time.Duration
+	attempts int
+}
+
+// startLookupBudget returns the budget of the lookup with its own deadline, separate from the caller's context.
+func (c *Client) startLookupBudget(ctx context.Context) *lookupBudget {
+	timeout := c.lookupTimeout
+	if override, ok := ctx.Value(lookupTimeoutKey{}).(time.Duration); ok {
+		timeout = override
+	}
+
+	b := &lookupBudget{parent: ctx, ctx: ctx, cancel: func() {}, timeout: timeout}
+	if timeout > 0 {
+		b.ctx, b.cancel = context.WithTimeout(ctx, timeout)
+	}
+
+	return b
+}
+
+// attempt counts the started request.
+func (b *lookupBudget) attempt() {
+	b.attempts++
+}
+
+// done releases the budget and returns TimeoutExceeded if the error is caused by the budget deadline
+// rather than by the caller's context.
+func (b *lookupBudget) done(err error) error {
+	defer b.cancel()
+
+	if err == nil || b.timeout <= 0 || b.parent.Err() != nil || !errors.Is(b.ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return &TimeoutExceeded{Timeout: b.timeout, Attempts: b.attempts, Err: err}
+}
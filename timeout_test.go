@@ -0,0 +1,74 @@
+package dnslookupapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestLookupTimeout tests the time budget of lookups.
+func TestLookupTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("apiKey") == "at_noCredits" {
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"code":402,"messages":"Insufficient balance"}`))
+
+			return
+		}
+
+		select {
+		case <-req.Context().Done():
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"whoisxmlapi.com","dnsRecords":[]}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_noCredits", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		APIKeys:          []string{"at_slow"},
+		LookupTimeout:    20 * time.Millisecond,
+	})
+
+	_, _, err := client.Get(context.Background(), "whoisxmlapi.com")
+
+	var timeoutErr *TimeoutExceeded
+	if !errors.As(err, &timeoutErr) || timeoutErr.Attempts != 2 || timeoutErr.Timeout != 20*time.Millisecond {
+		t.Fatalf("Get() error = %v, want TimeoutExceeded after 2 attempts", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("errors.Is(%v, context.DeadlineExceeded) = false", err)
+	}
+
+	slow := NewClient("at_slow", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		LookupTimeout:    20 * time.Millisecond,
+	})
+
+	_, err = slow.GetStream(context.Background(), "whoisxmlapi.com", func(DNSRecord) error { return nil })
+	if !errors.As(err, &timeoutErr) || timeoutErr.Attempts != 1 {
+		t.Errorf("GetStream() error = %v, want TimeoutExceeded", err)
+	}
+
+	if _, _, err = client.Get(WithLookupTimeout(context.Background(), 0), "whoisxmlapi.com"); err != nil {
+		t.Errorf("Get() without budget error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, _, err = client.Get(WithLookupTimeout(ctx, time.Second), "whoisxmlapi.com")
+	if err == nil || errors.As(err, &timeoutErr) {
+		t.Errorf("Get() with caller's deadline error = %v, want not TimeoutExceeded", err)
+	}
+}
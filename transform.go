@@ -0,0 +1,198 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// nameFields are the JSON fields of the records holding domain names.
+var nameFields = []string{
+	"name", "target", "additionalName", "mailAgent", "mailbox", "admin",
+	"host", "alias", "next", "replacement", "textDomain", "exchanger", "hostname", "intermediateHost",
+}
+
+// decodeHooksKey is the context key of the per-call decode hooks.
+type decodeHooksKey struct{}
+
+// WithDecodeHooks returns the context making Get apply the hooks after ClientParams.DecodeHooks.
+// Hooks are added to the hooks of the parent context.
+func WithDecodeHooks(ctx context.Context, hooks ...DecodeHook) context.Context {
+	parent := decodeHooksFromContext(ctx)
+
+	all := make([]DecodeHook, 0, len(parent)+len(hooks))
+	all = append(all, parent...)
+	all = append(all, hooks...)
+
+	return context.WithValue(ctx, decodeHooksKey{}, all)
+}
+
+// decodeHooksFromContext returns the hooks set by WithDecodeHooks.
+func decodeHooksFromContext(ctx context.Context) []DecodeHook {
+	hooks, _ := ctx.Value(decodeHooksKey{}).([]DecodeHook)
+	return hooks
+}
+
+// ChainHooks returns the DecodeHook applying the hooks in order. It stops at the first error.
+// It's useful to build a single policy shared by several clients.
+func ChainHooks(hooks ...DecodeHook) DecodeHook {
+	hooks = append([]DecodeHook(nil), hooks...)
+
+	return func(resp *DNSLookupResponse) error {
+		for _, hook := range hooks {
+			if err := hook(resp); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// DropTypes returns the DecodeHook removing the records of the DNS types from the response.
+func DropTypes(dnsTypes ...string) DecodeHook {
+	return func(resp *DNSLookupResponse) error {
+		resp.DNSRecords = *resp.DNSRecords.Filter(func(record DNSRecord) bool {
+			for _, dnsType := range dnsTypes {
+				if strings.EqualFold(record.CommonFields.DNSType, dnsType) {
+					return false
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	}
+}
+
+// MapNames returns the DecodeHook replacing the domain names in the records, including their owner names,
+// targets and raw text, with the result of fn. Records which failed to parse are left as is.
+func MapNames(fn func(name string) string) DecodeHook {
+	return func(resp *DNSLookupResponse) error {
+		mapped := resp.DNSRecords.derive()
+
+		for _, record := range resp.DNSRecords.All {
+			if record.ParseError != nil || len(record.Raw) == 0 {
+				mapped.All = append(mapped.All, record)
+				continue
+			}
+
+			mapped.All = append(mapped.All, mapped.parseRecord(mapRecordNames(record.Raw, fn)))
+		}
+
+		resp.DNSRecords = *mapped
+
+		return nil
+	}
+}
+
+// LowercaseNames returns the DecodeHook lower-casing the domain names in the records.
+func LowercaseNames() DecodeHook {
+	return MapNames(strings.ToLower)
+}
+
+// RedactNames returns the DecodeHook replacing the domain names for which match returns true
+// with the replacement, e.g. to hide internal hostnames before the response is stored or shown.
+func RedactNames(match func(name string) bool, replacement string) DecodeHook {
+	return MapNames(func(name string) string {
+		if match(name) {
+			return replacement
+		}
+
+		return name
+	})
+}
+
+// MatchNameSuffix returns the function reporting whether the domain name is equal to or below
+// any of the domains, e.g. "corp.example.com". It can be used with RedactNames.
+func MatchNameSuffix(domains ...string) func(name string) bool {
+	return func(name string) bool {
+		for _, domain := range domains {
+			if IsSubdomain(name, domain) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// mapRecordNames returns the raw record with the domain names replaced. The raw record is returned as is
+// if no name is changed.
+func mapRecordNames(raw json.RawMessage, fn func(string) string) json.RawMessage {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return raw
+	}
+
+	replaced := make(map[string]string)
+
+	for _, field := range nameFields {
+		var name string
+		if err := json.Unmarshal(fields[field], &name); err != nil || name == "" {
+			continue
+		}
+
+		if mapped := fn(name); mapped != name {
+			replaced[name] = mapped
+			fields[field], _ = json.Marshal(mapped)
+		}
+	}
+
+	if len(replaced) == 0 {
+		return raw
+	}
+
+	var rawText string
+	if err := json.Unmarshal(fields["rawText"], &rawText); err == nil && rawText != "" {
+		fields["rawText"], _ = json.Marshal(replaceTokens(rawText, replaced))
+	}
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+
+	return b
+}
+
+// replaceTokens replaces the whitespace-separated tokens of the text keeping the separators.
+func replaceTokens(text string, replacements map[string]string) string {
+	var b strings.Builder
+
+	start := -1
+
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		token := text[start:end]
+		if replacement, ok := replacements[token]; ok {
+			token = replacement
+		}
+
+		b.WriteString(token)
+
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			flush(i)
+			b.WriteRune(r)
+
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	flush(len(text))
+
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package dnslookupapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// transformTestRecords are the records transformed in the tests.
+const transformTestRecords = `[
+	{"type":1,"dnsType":"A","name":"WWW.Example.com.","ttl":300,"rawText":"WWW.Example.com.\t300\tIN\tA\t192.0.2.1",
+		"address":"192.0.2.1"},
+	{"type":15,"dnsType":"MX","name":"example.com.","ttl":300,"rawText":"example.com.\t300\tIN\tMX\t10 mx.corp.example.com.",
+		"target":"mx.corp.example.com.","priority":10},
+	{"type":16,"dnsType":"TXT","name":"example.com.","ttl":300,"rawText":"example.com.\t300\tIN\tTXT\t\"v=spf1 -all\"",
+		"strings":["v=spf1 -all"]}
+]`
+
+// transformTestResponse returns the response with transformTestRecords.
+func transformTestResponse(t *testing.T) *DNSLookupResponse {
+	resp := &DNSLookupResponse{DomainName: "example.com"}
+	if err := json.Unmarshal([]byte(transformTestRecords), &resp.DNSRecords); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp
+}
+
+// TestTransformers tests the built-in decode hooks.
+func TestTransformers(t *testing.T) {
+	resp := transformTestResponse(t)
+
+	hook := ChainHooks(
+		DropTypes("txt"),
+		LowercaseNames(),
+		RedactNames(MatchNameSuffix("corp.example.com"), "redacted."),
+	)
+
+	if err := hook(resp); err != nil {
+		t.Fatal(err)
+	}
+
+	records := resp.DNSRecords
+	if records.Count() != 2 || len(records.TXT) != 0 || len(records.A) != 1 || len(records.MX) != 1 {
+		t.Fatalf("records = %+v", records.All)
+	}
+
+	if a := records.A[0]; a.Name != "www.example.com." || a.RawText != "www.example.com.\t300\tIN\tA\t192.0.2.1" {
+		t.Errorf("A = %+v", a)
+	}
+
+	if mx := records.MX[0]; mx.Target != "redacted." || mx.RawText != "example.com.\t300\tIN\tMX\t10 redacted." ||
+		mx.Priority != 10 {
+		t.Errorf("MX = %+v", mx)
+	}
+
+	unchanged := transformTestResponse(t)
+	raw := string(unchanged.DNSRecords.All[2].Raw)
+
+	if err := LowercaseNames()(unchanged); err != nil || string(unchanged.DNSRecords.All[2].Raw) != raw {
+		t.Errorf("unchanged record rewritten: %s", unchanged.DNSRecords.All[2].Raw)
+	}
+
+	errFail := errors.New("fail")
+	calls := 0
+	count := func(*DNSLookupResponse) error {
+		calls++
+		return nil
+	}
+
+	if err := ChainHooks(count, func(*DNSLookupResponse) error { return errFail }, count)(resp); err != errFail || calls != 1 {
+		t.Errorf("ChainHooks() = %v, calls %d", err, calls)
+	}
+}
+
+// TestWithDecodeHooks tests per-call decode hooks.
+func TestWithDecodeHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":` + transformTestRecords + `}}`))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL)
+
+	client := NewClient("at_key", ClientParams{
+		HTTPClient:       server.Client(),
+		DNSLookupBaseURL: baseURL,
+		DecodeHooks:      []DecodeHook{DropTypes("MX")},
+	})
+
+	ctx := WithDecodeHooks(context.Background(), DropTypes("A"))
+	ctx = WithDecodeHooks(ctx, LowercaseNames())
+
+	resp, _, err := client.Get(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.DNSRecords.Count() != 1 || len(resp.DNSRecords.TXT) != 1 {
+		t.Errorf("records = %+v", resp.DNSRecords.All)
+	}
+
+	if resp, _, _ = client.Get(context.Background(), "example.com"); resp.DNSRecords.Count() != 2 {
+		t.Errorf("records without per-call hooks = %d, want 2", resp.DNSRecords.Count())
+	}
+}
@@ -0,0 +1,295 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// warmTypes are the DNS record types warmed by TTLCache.Warm if not specified, the ones of the typed getters.
+var warmTypes = []string{"A", "AAAA", "MX", "TXT", "NS"}
+
+// TTLCache caches the results of the typed getters GetA, GetAAAA, GetMX, GetTXT and GetNS per domain name and type
+// until the smallest TTL of the returned records elapses, like a resolver does.
+// Unlike a fixed-duration response cache, each entry lives as long as its records are valid.
+// Errors are not cached. The cached records are shared by the callers and must not be modified.
+// The zero value is ready to use. It is safe for concurrent use and can be shared by several clients of the same API.
+type TTLCache struct {
+	// MinTTL is the minimum time the entries are kept, e.g. to avoid refetching records with zero TTL
+	MinTTL time.Duration
+
+	// MaxTTL caps the time the entries are kept if it's positive
+	MaxTTL time.Duration
+
+	// NegativeTTL is the time the results without records are kept
+	// If it's zero then such results are not cached
+	NegativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[ttlCacheKey]ttlCacheEntry
+	stats   TTLCacheStats
+
+	// inserts is the number of entries stored since the last sweep of the expired entries,
+	// the next sweep runs when it exceeds the number of entries left by the last one
+	inserts    int
+	sweepAfter int
+
+	// now is used for testing
+	now func() time.Time
+}
+
+// ttlCacheKey identifies the cached lookup.
+type ttlCacheKey struct {
+	domainName string
+	dnsType    string
+	query      string
+}
+
+// ttlCacheEntry is the cached lookup result.
+type ttlCacheEntry struct {
+	records *DNSRecords
+	resp    *Response
+	expires time.Time
+}
+
+// TTLCacheStats are the usage statistics of TTLCache.
+type TTLCacheStats struct {
+	// Hits is the number of lookups served from the cache
+	Hits int64
+
+	// Misses is the number of lookups not found in the cache or expired
+	Misses int64
+
+	// Invalidations is the number of entries removed by Invalidate and InvalidateAll
+	Invalidations int64
+}
+
+// Len returns the number of entries including the expired ones which were not evicted yet.
+func (c *TTLCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Stats returns the usage statistics since the cache was created.
+func (c *TTLCache) Stats() TTLCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
+
+// Invalidate removes all entries of the domain name, e.g. after a known zone change.
+// It returns the number of removed entries.
+func (c *TTLCache) Invalidate(domainName string) int {
+	domainName = ttlCacheName(domainName)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+
+	for key := range c.entries {
+		if key.domainName == domainName {
+			delete(c.entries, key)
+			n++
+		}
+	}
+
+	c.stats.Invalidations += int64(n)
+
+	return n
+}
+
+// InvalidateAll removes all entries.
+func (c *TTLCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stats.Invalidations += int64(len(c.entries))
+	c.entries = nil
+}
+
+// Warm looks up the domain names with the client and stores the records in the cache, e.g. to pre-warm
+// hot domains before a traffic spike. The entries are used by the typed getters called without options.
+// If no DNS types are given, the types of the typed getters are warmed. The lookups are made concurrently;
+// all of them are attempted and the first error is returned. If the context is done, the lookups not started yet
+// are skipped and the context error is returned unless another error occurred first.
+func (c *TTLCache) Warm(ctx context.Context, client *Client, domainNames []string, dnsTypes ...string) error {
+	if len(dnsTypes) == 0 {
+		dnsTypes = warmTypes
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, defaultConcurrency)
+	)
+
+	setErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+loop:
+	for _, domainName := range domainNames {
+		for _, dnsType := range dnsTypes {
+			dnsType = strings.ToUpper(dnsType)
+
+			if err := ctx.Err(); err != nil {
+				setErr(err)
+				break loop
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				setErr(ctx.Err())
+				break loop
+			}
+
+			wg.Add(1)
+
+			go func(domainName, dnsType string) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				dnsLookupResp, resp, err := client.Get(ctx, domainName, OptionType(dnsType))
+				if err != nil {
+					setErr(err)
+					return
+				}
+
+				c.set(newTTLCacheKey(domainName, dnsType, nil), dnsLookupResp.ParsedRecords(), resp)
+			}(domainName, dnsType)
+		}
+	}
+
+	wg.Wait()
+
+	return firstErr
+}
+
+// get returns the unexpired records of the lookup and the response they were fetched with.
+func (c *TTLCache) get(key ttlCacheKey) (*DNSRecords, *Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		return nil, nil, false
+	}
+
+	if !c.clock().Before(entry.expires) {
+		delete(c.entries, key)
+		c.stats.Misses++
+
+		return nil, nil, false
+	}
+
+	c.stats.Hits++
+
+	return entry.records, entry.resp, true
+}
+
+// set stores the records of the lookup until the smallest TTL elapses.
+// Expired entries are removed when they are read and by a sweep run after as many inserts as there were entries
+// left by the previous sweep, so the cost of the sweeps is constant per insert on average.
+func (c *TTLCache) set(key ttlCacheKey, records *DNSRecords, resp *Response) {
+	ttl, ok := c.ttl(records)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock()
+
+	if c.inserts++; c.inserts > c.sweepAfter {
+		c.sweep(now)
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[ttlCacheKey]ttlCacheEntry)
+	}
+
+	c.entries[key] = ttlCacheEntry{records: records, resp: resp, expires: now.Add(ttl)}
+}
+
+// sweep removes the expired entries. It must be called with the lock held.
+func (c *TTLCache) sweep(now time.Time) {
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.inserts, c.sweepAfter = 0, len(c.entries)
+}
+
+// ttl returns the time the records are kept and false if they must not be cached.
+func (c *TTLCache) ttl(records *DNSRecords) (time.Duration, bool) {
+	if len(records.All) == 0 {
+		return c.NegativeTTL, c.NegativeTTL > 0
+	}
+
+	ttl := -1
+	for _, record := range records.All {
+		if ttl < 0 || record.CommonFields.TTL < ttl {
+			ttl = record.CommonFields.TTL
+		}
+	}
+
+	d := time.Duration(ttl) * time.Second
+	if d < c.MinTTL {
+		d = c.MinTTL
+	}
+
+	if c.MaxTTL > 0 && d > c.MaxTTL {
+		d = c.MaxTTL
+	}
+
+	return d, d > 0
+}
+
+// clock returns the current time.
+func (c *TTLCache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+
+	return time.Now()
+}
+
+// newTTLCacheKey returns the cache key of the typed lookup. The options other than the type are part of the key.
+func newTTLCacheKey(domainName, dnsType string, opts []Option) ttlCacheKey {
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	query.Del("type")
+
+	return ttlCacheKey{
+		domainName: ttlCacheName(domainName),
+		dnsType:    dnsType,
+		query:      query.Encode(),
+	}
+}
+
+// ttlCacheName returns the domain name as used in the cache keys.
+func ttlCacheName(domainName string) string {
+	return strings.ToLower(strings.TrimSuffix(domainName, "."))
+}
@@ -0,0 +1,166 @@
+package dnslookupapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestTTLCache tests that the typed getters refetch the records after the smallest TTL elapses.
+func TestTTLCache(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+
+		if req.URL.Query().Get("type") == "TXT" {
+			_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[]}}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[` +
+			`{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"},` +
+			`{"type":1,"dnsType":"A","name":"example.com.","ttl":60,"address":"192.0.2.2"}]}}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	cache := &TTLCache{now: func() time.Time { return now }}
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL, TTLCache: cache})
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		after        time.Duration
+		domainName   string
+		opts         []Option
+		wantRequests int
+	}{
+		{name: "miss", domainName: "example.com", wantRequests: 1},
+		{name: "hit", after: 59 * time.Second, domainName: "Example.com.", wantRequests: 1},
+		{name: "other options", domainName: "example.com", opts: []Option{OptionOutputFormat("XML")}, wantRequests: 2},
+		{name: "expired", after: time.Second, domainName: "example.com", wantRequests: 3},
+	}
+
+	for _, tt := range tests {
+		now = now.Add(tt.after)
+
+		a, _, err := client.GetA(ctx, tt.domainName, tt.opts...)
+		if err != nil || len(a) != 2 {
+			t.Errorf("%s: GetA() = %v, %v", tt.name, a, err)
+		}
+
+		if requests != tt.wantRequests {
+			t.Errorf("%s: requests = %d, want %d", tt.name, requests, tt.wantRequests)
+		}
+	}
+
+	if _, _, err := client.GetTXT(ctx, "example.com"); err != nil || cache.Len() != 2 {
+		t.Errorf("GetTXT() error = %v, cache entries = %d, want no negative entry", err, cache.Len())
+	}
+
+	cache.InvalidateAll()
+
+	if cache.Len() != 0 {
+		t.Errorf("InvalidateAll() left %d entries", cache.Len())
+	}
+}
+
+// TestTTLCacheWarm tests warming, invalidation and the statistics of the cache.
+func TestTTLCacheWarm(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if req.URL.Query().Get("domainName") == "fail.example.com" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"DNSData":{"domainName":"example.com","dnsRecords":[` +
+			`{"type":1,"dnsType":"A","name":"example.com.","ttl":300,"address":"192.0.2.1"}]}}`))
+	}))
+	defer server.Close()
+
+	cache := &TTLCache{}
+
+	baseURL, _ := url.Parse(server.URL)
+	client := NewClient(apiKey, ClientParams{HTTPClient: server.Client(), DNSLookupBaseURL: baseURL, TTLCache: cache})
+	ctx := context.Background()
+
+	err := cache.Warm(ctx, client, []string{"example.com", "example.org", "fail.example.com"}, "a", "MX")
+	if err == nil {
+		t.Error("Warm() expected error of fail.example.com")
+	}
+
+	if cache.Len() != 4 || requests != 6 {
+		t.Errorf("Warm() cached %d entries with %d requests, want 4 with 6", cache.Len(), requests)
+	}
+
+	if _, _, err = client.GetA(ctx, "example.com"); err != nil || requests != 6 {
+		t.Errorf("GetA() error = %v, requests = %d, want served from the cache", err, requests)
+	}
+
+	if n := cache.Invalidate("EXAMPLE.com."); n != 2 || cache.Len() != 2 {
+		t.Errorf("Invalidate() = %d, %d entries left", n, cache.Len())
+	}
+
+	if _, _, err = client.GetA(ctx, "example.com"); err != nil || requests != 7 {
+		t.Errorf("GetA() error = %v, requests = %d, want refetched", err, requests)
+	}
+
+	cache.InvalidateAll()
+
+	if stats := cache.Stats(); stats != (TTLCacheStats{Hits: 1, Misses: 1, Invalidations: 5}) {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+// TestTTLCacheWarmCanceled tests that Warm stops starting lookups when the context is done.
+func TestTTLCacheWarmCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	service := &hostService{}
+	client := NewClient(apiKey, ClientParams{})
+	client.DNSLookupService = service
+
+	cache := &TTLCache{}
+
+	if err := cache.Warm(ctx, client, []string{"example.com", "example.org"}); err != context.Canceled {
+		t.Errorf("Warm() error = %v, want %v", err, context.Canceled)
+	}
+
+	if len(service.calls) != 0 {
+		t.Errorf("Warm() looked up %v", service.calls)
+	}
+}
+
+// TestTTLCacheSweep tests that the expired entries are swept by the inserts.
+func TestTTLCacheSweep(t *testing.T) {
+	now := time.Now()
+	cache := &TTLCache{now: func() time.Time { return now }}
+
+	records := &DNSRecords{All: []DNSRecord{{CommonFields: commonFields{DNSType: "A", TTL: 60}}}}
+
+	for _, name := range []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"} {
+		cache.set(newTTLCacheKey(name, "A", nil), records, nil)
+	}
+
+	now = now.Add(time.Minute)
+
+	for _, name := range []string{"e.example.com", "f.example.com", "g.example.com", "h.example.com"} {
+		cache.set(newTTLCacheKey(name, "A", nil), records, nil)
+	}
+
+	if cache.Len() != 4 {
+		t.Errorf("Len() = %d, want the expired entries swept", cache.Len())
+	}
+}
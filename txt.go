@@ -0,0 +1,49 @@
+package dnslookupapi
+
+import (
+	"errors"
+	"strings"
+)
+
+// Value returns the character strings of the record concatenated without separators,
+// as required by RFC 7208 section 3.3 for SPF and used by DKIM and DMARC.
+func (r TXTRecord) Value() string {
+	return strings.Join(r.Strings, "")
+}
+
+// ParseTags parses the "tag=value; tag2=value2" list used by DKIM, DMARC and verification records.
+// Tag names are lowercased, surrounding whitespace is removed and empty elements are skipped.
+func ParseTags(txt string) (map[string]string, error) {
+	tags := make(map[string]string)
+
+	for _, part := range strings.Split(txt, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		i := strings.IndexByte(part, '=')
+		if i <= 0 {
+			return nil, errors.New(`invalid tag "` + part + `"`)
+		}
+
+		tags[strings.ToLower(strings.TrimSpace(part[:i]))] = strings.TrimSpace(part[i+1:])
+	}
+
+	return tags, nil
+}
+
+// FindTXT returns TXT records whose value starts with the prefix, ignoring case,
+// e.g. "google-site-verification=" or "v=spf1".
+func (r *DNSRecords) FindTXT(prefix string) []TXTRecord {
+	var found []TXTRecord
+
+	for _, record := range r.TXT {
+		value := record.Value()
+		if len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			found = append(found, record)
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,91 @@
+package dnslookupapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestTXTValue tests joining TXT character strings.
+func TestTXTValue(t *testing.T) {
+	record := TXTRecord{Strings: []string{"v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3", "DQEBAQUAA4GNADCBiQKBgQ"}}
+
+	if got := record.Value(); got != "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQ" {
+		t.Errorf("Value() = %v", got)
+	}
+
+	if got := (TXTRecord{}).Value(); got != "" {
+		t.Errorf("Value() of empty record = %v", got)
+	}
+}
+
+// TestParseTags tests parsing tag=value lists.
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		txt     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "dmarc",
+			txt:  "v=DMARC1; p=reject; rua=mailto:dmarc@example.com;",
+			want: map[string]string{"v": "DMARC1", "p": "reject", "rua": "mailto:dmarc@example.com"},
+		},
+		{
+			name: "verification token",
+			txt:  "google-site-verification=Abc-123=",
+			want: map[string]string{"google-site-verification": "Abc-123="},
+		},
+		{
+			name: "spaces and case",
+			txt:  " K = rsa ;; P= ",
+			want: map[string]string{"k": "rsa", "p": ""},
+		},
+		{
+			name:    "missing value separator",
+			txt:     "v=DKIM1; rsa",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTags(tt.txt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFindTXT tests locating TXT records by prefix.
+func TestFindTXT(t *testing.T) {
+	const records = `[
+{"type":16,"dnsType":"TXT","name":"example.com.","strings":["v=spf1 include:_spf.google.com ~all"]},
+{"type":16,"dnsType":"TXT","name":"example.com.","strings":["google-site-verification=", "abc"]},
+{"type":16,"dnsType":"TXT","name":"example.com.","strings":["MS=ms12345"]}
+]`
+
+	var r DNSRecords
+	if err := json.Unmarshal([]byte(records), &r); err != nil {
+		t.Fatal(err)
+	}
+
+	found := r.FindTXT("Google-Site-Verification=")
+	if len(found) != 1 || found[0].Value() != "google-site-verification=abc" {
+		t.Errorf("FindTXT() = %v", found)
+	}
+
+	if found = r.FindTXT("v=spf1 "); len(found) != 1 {
+		t.Errorf("FindTXT(v=spf1) = %v", found)
+	}
+
+	if found = r.FindTXT("facebook-domain-verification="); len(found) != 0 {
+		t.Errorf("FindTXT() = %v, want none", found)
+	}
+}
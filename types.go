@@ -0,0 +1,155 @@
+package dnslookupapi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// TypeMismatchKind is the kind of TypeMismatch.
+type TypeMismatchKind string
+
+const (
+	// TypeCodeOnly is the type listed in Types but not in DNSTypes.
+	TypeCodeOnly TypeMismatchKind = "code-only"
+
+	// TypeNameOnly is the type listed in DNSTypes but not in Types.
+	TypeNameOnly TypeMismatchKind = "name-only"
+
+	// TypeUnrequested is the type of the returned records that was not requested.
+	TypeUnrequested TypeMismatchKind = "unrequested"
+)
+
+// TypeMismatch is an inconsistency between the requested and returned DNS record types.
+type TypeMismatch struct {
+	// Kind is the kind of the mismatch.
+	Kind TypeMismatchKind
+
+	// DNSType is the DNS record type name.
+	DNSType string
+
+	// Count is the number of returned records of the type. It's set for TypeUnrequested only.
+	Count int
+}
+
+// String returns the description of the mismatch.
+func (m TypeMismatch) String() string {
+	return m.DNSType + ": " + string(m.Kind)
+}
+
+// RequestedTypes returns the DNS record types listed in DNSTypes in upper case.
+// It returns nil if all types were requested.
+func (r *DNSLookupResponse) RequestedTypes() []string {
+	return splitTypes(r.DNSTypes)
+}
+
+// RequestedTypeNames returns the names of the type codes listed in Types, see RRTypeName.
+func (r *DNSLookupResponse) RequestedTypeNames() []string {
+	if len(r.Types) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(r.Types))
+	for _, code := range r.Types {
+		names = append(names, RRTypeName(code))
+	}
+
+	return names
+}
+
+// CheckTypes cross-checks Types with DNSTypes and the requested types with the types of the returned records.
+// CNAME and DNAME records are not reported as unrequested since they are returned for aliased names.
+// It returns nil if the types are consistent.
+func (r *DNSLookupResponse) CheckTypes() []TypeMismatch {
+	var mismatches []TypeMismatch
+
+	requested := r.RequestedTypes()
+	names := make(map[string]bool, len(requested))
+
+	for _, name := range requested {
+		names[name] = true
+	}
+
+	codes := make(map[string]bool, len(r.Types))
+
+	for _, name := range r.RequestedTypeNames() {
+		codes[name] = true
+
+		if len(requested) != 0 && !names[name] {
+			mismatches = append(mismatches, TypeMismatch{Kind: TypeCodeOnly, DNSType: name})
+		}
+	}
+
+	if len(r.Types) != 0 {
+		for _, name := range requested {
+			if !codes[name] {
+				mismatches = append(mismatches, TypeMismatch{Kind: TypeNameOnly, DNSType: name})
+			}
+		}
+	}
+
+	if len(requested) == 0 {
+		return mismatches
+	}
+
+	return append(mismatches, unrequestedTypes(r.DNSRecords.All, names)...)
+}
+
+// unrequestedTypes returns the types of the records which are not requested, counting the records.
+// CNAME and DNAME records are skipped.
+func unrequestedTypes(records []DNSRecord, requested map[string]bool) []TypeMismatch {
+	var (
+		mismatches []TypeMismatch
+		order      []string
+		counts     = make(map[string]int)
+	)
+
+	for _, record := range records {
+		name := strings.ToUpper(record.CommonFields.DNSType)
+		if name == "" || requested[name] || name == "CNAME" || name == "DNAME" {
+			continue
+		}
+
+		if counts[name] == 0 {
+			order = append(order, name)
+		}
+
+		counts[name]++
+	}
+
+	for _, name := range order {
+		mismatches = append(mismatches, TypeMismatch{Kind: TypeUnrequested, DNSType: name, Count: counts[name]})
+	}
+
+	return mismatches
+}
+
+// splitTypes returns the DNS record types of the comma-separated list in upper case.
+// It returns nil if all types are requested.
+func splitTypes(list string) []string {
+	var types []string
+
+	for _, t := range strings.Split(list, ",") {
+		t = strings.ToUpper(strings.TrimSpace(t))
+
+		switch t {
+		case "":
+			continue
+		case "_ALL", "ANY":
+			return nil
+		}
+
+		types = append(types, t)
+	}
+
+	return types
+}
+
+// typesOf returns the DNS record types requested by the options, nil if all types are requested.
+func typesOf(opts []Option) []string {
+	query := url.Values{}
+	for _, opt := range opts {
+		opt(query)
+	}
+
+	return splitTypes(query.Get("type"))
+}
@@ -0,0 +1,71 @@
+package dnslookupapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRequestedTypes tests parsing of DNSTypes.
+func TestRequestedTypes(t *testing.T) {
+	tests := []struct {
+		dnsTypes string
+		want     []string
+	}{
+		{"A", []string{"A"}},
+		{"a, mx ,,TXT", []string{"A", "MX", "TXT"}},
+		{"_all", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		r := &DNSLookupResponse{DNSTypes: tt.dnsTypes}
+		if got := r.RequestedTypes(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RequestedTypes(%q) = %v, want %v", tt.dnsTypes, got, tt.want)
+		}
+	}
+}
+
+// TestCheckTypes tests cross-checking of the requested and returned types.
+func TestCheckTypes(t *testing.T) {
+	records := func(types ...string) DNSRecords {
+		var r DNSRecords
+		for _, dnsType := range types {
+			r.All = append(r.All, DNSRecord{CommonFields: commonFields{DNSType: dnsType}})
+		}
+
+		return r
+	}
+
+	tests := []struct {
+		name string
+		resp DNSLookupResponse
+		want []TypeMismatch
+	}{
+		{
+			name: "consistent",
+			resp: DNSLookupResponse{Types: []int{1, 15}, DNSTypes: "A,MX", DNSRecords: records("A", "CNAME", "MX")},
+		},
+		{
+			name: "all types",
+			resp: DNSLookupResponse{Types: []int{1}, DNSTypes: "_all", DNSRecords: records("A", "TXT")},
+		},
+		{
+			name: "codes and names differ",
+			resp: DNSLookupResponse{Types: []int{1, 16}, DNSTypes: "A,MX"},
+			want: []TypeMismatch{{Kind: TypeCodeOnly, DNSType: "TXT"}, {Kind: TypeNameOnly, DNSType: "MX"}},
+		},
+		{
+			name: "unrequested records",
+			resp: DNSLookupResponse{DNSTypes: "A", DNSRecords: records("A", "TXT", "NS", "TXT")},
+			want: []TypeMismatch{{Kind: TypeUnrequested, DNSType: "TXT", Count: 2}, {Kind: TypeUnrequested, DNSType: "NS", Count: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.CheckTypes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckTypes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
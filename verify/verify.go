@@ -0,0 +1,274 @@
+// Package verify compares DNS records returned by DNS Lookup API with live DNS resolution
+// to detect stale or divergent data.
+package verify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dnslookupapi "github.com/whois-api-llc/dns-lookup-go"
+)
+
+// Resolver resolves DNS records. *net.Resolver implements it.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+var _ Resolver = &net.Resolver{}
+
+// DefaultTypes are the DNS record types compared by default.
+var DefaultTypes = []string{"A", "AAAA", "MX", "NS", "TXT", "CNAME"}
+
+// ErrUnsupportedType is returned for the DNS record types the resolver cannot look up.
+var ErrUnsupportedType = errors.New("unsupported DNS record type")
+
+// TypeResult is the comparison result of a single DNS record type.
+type TypeResult struct {
+	// DNSType is the DNS record type.
+	DNSType string `json:"dnsType"`
+
+	// API are the normalized record values returned by the API.
+	API []string `json:"api"`
+
+	// Live are the normalized record values returned by the resolver.
+	Live []string `json:"live"`
+
+	// Stale are the values returned by the API only.
+	Stale []string `json:"stale,omitempty"`
+
+	// Missing are the values returned by the resolver only.
+	Missing []string `json:"missing,omitempty"`
+
+	// Err is the error of the live lookup.
+	Err error `json:"-"`
+}
+
+// Divergent reports whether the API and the resolver returned different values.
+func (r TypeResult) Divergent() bool {
+	return len(r.Stale) != 0 || len(r.Missing) != 0
+}
+
+// Report is the comparison of the API data with live DNS resolution.
+type Report struct {
+	// Domain is the compared domain name.
+	Domain string `json:"domain"`
+
+	// UpdatedAt is the time the API data was updated, taken from the Audit block.
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Age is the age of the API data at the time of comparison, zero if UpdatedAt is unknown.
+	Age time.Duration `json:"age"`
+
+	// Types are the results per DNS record type in the requested order.
+	Types []TypeResult `json:"types"`
+}
+
+// Divergent reports whether any of the types diverges.
+func (r *Report) Divergent() bool {
+	for _, t := range r.Types {
+		if t.Divergent() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compare requests the DNS records of the domain from the API, resolves them with the resolver
+// and reports the differences. If resolver is nil then net.DefaultResolver is used,
+// if types are empty then DefaultTypes are compared.
+// Errors of the live lookups are reported per type; NXDOMAIN and no-data answers are treated as empty.
+func Compare(
+	ctx context.Context,
+	service dnslookupapi.DNSLookupService,
+	resolver Resolver,
+	domainName string,
+	types ...string,
+) (*Report, error) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	if len(types) == 0 {
+		types = DefaultTypes
+	}
+
+	resp, _, err := service.Get(ctx, domainName, dnslookupapi.OptionType(strings.Join(types, ",")))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Domain: domainName}
+
+	if updated := time.Time(resp.Audit.UpdatedDate); !updated.IsZero() {
+		report.UpdatedAt = updated
+		report.Age = time.Since(updated)
+	}
+
+	for _, dnsType := range types {
+		dnsType = strings.ToUpper(dnsType)
+
+		result := TypeResult{DNSType: dnsType, API: apiValues(&resp.DNSRecords, dnsType)}
+		result.Live, result.Err = liveValues(ctx, resolver, domainName, dnsType)
+
+		if result.Err == nil {
+			result.Stale = difference(result.API, result.Live)
+			result.Missing = difference(result.Live, result.API)
+		}
+
+		report.Types = append(report.Types, result)
+	}
+
+	return report, nil
+}
+
+// apiValues returns the normalized values of the API records of the type.
+func apiValues(records *dnslookupapi.DNSRecords, dnsType string) []string {
+	var values []string
+
+	switch dnsType {
+	case "A":
+		for _, r := range records.A {
+			values = append(values, normalizeIP(r.Address))
+		}
+	case "AAAA":
+		for _, r := range records.AAAA {
+			values = append(values, normalizeIP(r.Address))
+		}
+	case "MX":
+		for _, r := range records.MX {
+			values = append(values, strconv.Itoa(r.Priority)+" "+normalizeName(r.Target))
+		}
+	case "NS":
+		for _, r := range records.NS {
+			values = append(values, normalizeName(r.Target))
+		}
+	case "TXT":
+		for _, r := range records.TXT {
+			values = append(values, r.Value())
+		}
+	case "CNAME":
+		for _, r := range records.CNAME {
+			values = append(values, normalizeName(r.Target))
+		}
+	}
+
+	return unique(values)
+}
+
+// liveValues returns the normalized values resolved by the resolver.
+func liveValues(ctx context.Context, resolver Resolver, domainName, dnsType string) ([]string, error) {
+	var values []string
+
+	switch dnsType {
+	case "A", "AAAA":
+		addrs, err := resolver.LookupIPAddr(ctx, domainName)
+		if err != nil {
+			return notFound(err)
+		}
+
+		for _, addr := range addrs {
+			if (addr.IP.To4() != nil) == (dnsType == "A") {
+				values = append(values, addr.IP.String())
+			}
+		}
+	case "MX":
+		mxs, err := resolver.LookupMX(ctx, domainName)
+		if err != nil {
+			return notFound(err)
+		}
+
+		for _, mx := range mxs {
+			values = append(values, strconv.Itoa(int(mx.Pref))+" "+normalizeName(mx.Host))
+		}
+	case "NS":
+		nss, err := resolver.LookupNS(ctx, domainName)
+		if err != nil {
+			return notFound(err)
+		}
+
+		for _, ns := range nss {
+			values = append(values, normalizeName(ns.Host))
+		}
+	case "TXT":
+		txts, err := resolver.LookupTXT(ctx, domainName)
+		if err != nil {
+			return notFound(err)
+		}
+
+		values = append(values, txts...)
+	case "CNAME":
+		cname, err := resolver.LookupCNAME(ctx, domainName)
+		if err != nil {
+			return notFound(err)
+		}
+
+		if normalizeName(cname) != normalizeName(domainName) {
+			values = append(values, normalizeName(cname))
+		}
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	return unique(values), nil
+}
+
+// notFound returns no values for NXDOMAIN and no-data answers and the error otherwise.
+func notFound(err error) ([]string, error) {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, nil
+	}
+
+	return nil, err
+}
+
+// normalizeName returns the lowercased domain name without the trailing dot.
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// normalizeIP returns the canonical form of the IP address.
+func normalizeIP(addr string) string {
+	if ip := net.ParseIP(addr); ip != nil {
+		return ip.String()
+	}
+
+	return addr
+}
+
+// unique returns sorted unique values.
+func unique(values []string) []string {
+	sort.Strings(values)
+
+	result := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// difference returns the values of a missing in b. Both slices must be sorted.
+func difference(a, b []string) []string {
+	var result []string
+
+	for _, v := range a {
+		if i := sort.SearchStrings(b, v); i == len(b) || b[i] != v {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
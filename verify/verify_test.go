@@ -0,0 +1,101 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/whois-api-llc/dns-lookup-go/dnslookupapitest"
+)
+
+// fakeResolver is the Resolver returning canned answers for testing.
+type fakeResolver struct {
+	ips   []net.IPAddr
+	mx    []*net.MX
+	ns    []*net.NS
+	txt   []string
+	cname string
+	err   error
+}
+
+func (r *fakeResolver) LookupIPAddr(context.Context, string) ([]net.IPAddr, error) { return r.ips, nil }
+func (r *fakeResolver) LookupMX(context.Context, string) ([]*net.MX, error)        { return r.mx, nil }
+func (r *fakeResolver) LookupNS(context.Context, string) ([]*net.NS, error)        { return r.ns, r.err }
+func (r *fakeResolver) LookupTXT(context.Context, string) ([]string, error)        { return r.txt, nil }
+
+func (r *fakeResolver) LookupCNAME(context.Context, string) (string, error) {
+	if r.cname == "" {
+		return "", &net.DNSError{Err: "no such host", IsNotFound: true}
+	}
+
+	return r.cname, nil
+}
+
+// TestCompare tests comparing the API data with live resolution.
+func TestCompare(t *testing.T) {
+	resolver := &fakeResolver{
+		ips: []net.IPAddr{
+			{IP: net.ParseIP("104.26.13.210")},
+			{IP: net.ParseIP("172.67.70.191")},
+			{IP: net.ParseIP("2606:4700:20::681a:dd2")},
+		},
+		mx: []*net.MX{
+			{Host: "ASPMX.L.GOOGLE.COM.", Pref: 1},
+			{Host: "alt1.aspmx.l.google.com.", Pref: 5},
+		},
+		txt: []string{"v=spf1 include:_spf.google.com ~all"},
+		err: errors.New("timeout"),
+	}
+
+	report, err := Compare(context.Background(), dnslookupapitest.NewFake(), resolver, dnslookupapitest.FixtureDomain)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if report.UpdatedAt.IsZero() || report.Age <= 0 {
+		t.Errorf("Compare() age = %v, %v", report.UpdatedAt, report.Age)
+	}
+
+	if !report.Divergent() {
+		t.Error("Compare() expected divergence")
+	}
+
+	want := map[string]TypeResult{
+		"A":     {Stale: []string{"104.26.12.210"}, Missing: []string{"172.67.70.191"}},
+		"AAAA":  {},
+		"MX":    {},
+		"NS":    {},
+		"TXT":   {},
+		"CNAME": {},
+	}
+
+	if len(report.Types) != len(want) {
+		t.Fatalf("Compare() types = %v", report.Types)
+	}
+
+	for _, got := range report.Types {
+		w := want[got.DNSType]
+		if !reflect.DeepEqual(got.Stale, w.Stale) || !reflect.DeepEqual(got.Missing, w.Missing) {
+			t.Errorf("%s: stale = %v, missing = %v, want %v, %v", got.DNSType, got.Stale, got.Missing, w.Stale, w.Missing)
+		}
+
+		if (got.DNSType == "NS") != (got.Err != nil) {
+			t.Errorf("%s: error = %v", got.DNSType, got.Err)
+		}
+	}
+}
+
+// TestCompareUnsupportedType tests that unsupported types are reported per type.
+func TestCompareUnsupportedType(t *testing.T) {
+	report, err := Compare(context.Background(), dnslookupapitest.NewFake(), &fakeResolver{},
+		dnslookupapitest.FixtureDomain, "soa")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if len(report.Types) != 1 || report.Types[0].DNSType != "SOA" || !errors.Is(report.Types[0].Err, ErrUnsupportedType) {
+		t.Errorf("Compare() = %+v", report.Types)
+	}
+}